@@ -1,20 +1,21 @@
 package main
 
 import (
-	"compress/gzip"
 	"errors"
 	"flag"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"runtime/pprof"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
 
+	"procinspect/pkg/archive"
 	"procinspect/pkg/checker"
 	"procinspect/pkg/log"
+	"procinspect/pkg/parser"
 	"procinspect/pkg/semantic"
 )
 
@@ -23,11 +24,31 @@ var (
 	dir  = flag.String("dir", "", "")
 	prof = flag.Bool("prof", false, "")
 	bin  = flag.String("bin", "", "")
+
+	objects = flag.String("object", "", "with -bin, the objects to check, such as \"PACKAGE BODY:PKG_BILLING,PROCEDURE:ARCHIVE\"")
+
+	version = flag.String("version", "", "Oracle release the scripts target, such as 19c")
+	target  = flag.String("target", "", "database the scripts migrate to: postgresql, mysql or sqlserver; all rules are checked when empty")
+	opts    parser.Options
 )
 
 func main() {
 	flag.Parse()
 	// flag.PrintDefaults()
+	if *version != "" {
+		v, err := semantic.ParseVersion(*version)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		opts.Version = v
+	}
+	switch *target {
+	case "", checker.DatabasePostgreSQL, checker.DatabaseMySQL, checker.DatabaseSQLServer:
+	default:
+		fmt.Printf("unknown target database %q\n", *target)
+		return
+	}
 
 	if *prof {
 		pf, err := os.Create("./cpu.prof")
@@ -74,6 +95,10 @@ func main() {
 		return
 	} else if *bin != "" {
 		filename := *bin
+		if *objects != "" {
+			checkObjects(filename, *objects)
+			return
+		}
 		checkBinary(filename)
 	}
 }
@@ -103,11 +128,17 @@ func parseFile(sql string) error {
 	// parse file
 	fmt.Print("parse ", filepath.Base(absPath), " ")
 	start := time.Now()
-	script, err := checker.LoadScript(string(text))
+	script, err := checker.LoadScriptOptions(string(text), opts)
 	elapsed := time.Since(start)
+	err = reportVersions(script, err)
 	if err != nil {
 		// name := filepath.Base(absPath)
 		fmt.Printf("error:\n%s\n", err)
+		if script == nil {
+			return err
+		}
+		// check what was parsed around the statements in error
+		_ = check(script)
 		return err
 	} else {
 		fmt.Print("ok;")
@@ -117,12 +148,38 @@ func parseFile(sql string) error {
 	return check(script)
 }
 
+// reportVersions warns about the syntax newer than the target release and
+// returns the other errors of err.
+func reportVersions(script *semantic.Script, err error) error {
+	if script != nil && script.MinVersion != 0 {
+		log.Info("version", log.String("requires", fmt.Sprintf("Oracle %s+", script.MinVersion)))
+	}
+	var rest error
+	found := false
+	for _, d := range semantic.Diagnostics(err) {
+		if d.Code == semantic.CodeVersion {
+			log.Warn("unsupported", log.String("err", d.Message), log.Int("line", d.Start.Line))
+			found = true
+			continue
+		}
+		rest = errors.Join(rest, d)
+	}
+	if !found {
+		return err
+	}
+	return rest
+}
+
 func check(script *semantic.Script) error {
 	v := checker.NewValidVisitor()
+	v.SetDatabase(*target)
 	_ = script.Accept(v)
 
 	var errs *multierror.Error
 	errors.As(v.Error(), &errs)
+	if errs == nil {
+		return nil
+	}
 	for _, e := range errs.Errors {
 		err := e.(checker.SqlValidationError)
 		log.Warn("unsupported", log.String("err", err.Error()), log.Int("line", err.Line))
@@ -143,22 +200,15 @@ func checkBinary(filename string) {
 	}
 	defer f.Close()
 
-	gr, err := gzip.NewReader(f)
-	if err != nil {
-		return
-	}
-	defer gr.Close()
-
-	// read file to string
-	text, err := io.ReadAll(gr)
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
 	// parse file
 	log.Info("Start Parse", log.String("file", filepath.Base(absPath)))
 	start := time.Now()
-	script, err := semantic.NewNodeDecoder[*semantic.Script]().Decode(text)
+	header, script, err := semantic.ReadAstFile(f)
+	if header != nil && header.Format > 0 {
+		log.Debug("AST File", log.String("source", header.Source),
+			log.String("parser", header.ParserVersion),
+			log.String("created", header.Created.String()))
+	}
 	elapsed := time.Since(start)
 	log.Info("End Parse", log.String("file", filepath.Base(absPath)),
 		log.String("duration", elapsed.String()))
@@ -169,3 +219,29 @@ func checkBinary(filename string) {
 	}
 	check(script)
 }
+
+// checkObjects checks the objects of the .ast file listed in spec, each
+// given as KIND:NAME or just NAME, decoding only those.
+func checkObjects(filename string, spec string) {
+	a, err := archive.Open(filename)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer a.Close()
+
+	script := &semantic.Script{}
+	for _, object := range strings.Split(spec, ",") {
+		kind, name, found := strings.Cut(object, ":")
+		if !found {
+			kind, name = "", kind
+		}
+		stmt, err := a.Lookup(kind, strings.TrimSpace(name))
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		script.Statements = append(script.Statements, stmt)
+	}
+	check(script)
+}
@@ -0,0 +1,95 @@
+// Command diff reports the structural changes between two versions of a
+// script, each given as SQL source or as an .ast file.
+//
+//	diff [-object "PACKAGE BODY:PKG_BILLING"] old.sql new.sql
+//
+// It exits with status 1 when the versions differ.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"procinspect/pkg/archive"
+	"procinspect/pkg/parser"
+	"procinspect/pkg/semantic"
+)
+
+var object = flag.String("object", "", "compare only the object given as KIND:NAME or NAME")
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: diff [-object KIND:NAME] old new")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var kind, name string
+	if *object != "" {
+		var found bool
+		kind, name, found = strings.Cut(*object, ":")
+		if !found {
+			kind, name = "", kind
+		}
+		name = strings.TrimSpace(name)
+	}
+
+	before, err := load(flag.Arg(0), kind, name)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+	after, err := load(flag.Arg(1), kind, name)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+
+	edits := semantic.Diff(before, after)
+	for _, e := range edits {
+		fmt.Println(e)
+	}
+	if len(edits) > 0 {
+		os.Exit(1)
+	}
+}
+
+// load reads the script at path, or its object of the given kind and name
+// when name is not empty.
+func load(path string, kind, name string) (semantic.Node, error) {
+	if filepath.Ext(path) == ".ast" {
+		a, err := archive.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer a.Close()
+		if name != "" {
+			return a.Lookup(kind, name)
+		}
+		return a.Script()
+	}
+
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	script, err := parser.ParseScript(string(text))
+	if err != nil {
+		if script == nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		// the statements in error are compared by their text
+		fmt.Fprintf(os.Stderr, "%s: %s\n", path, err)
+	}
+	if name != "" {
+		return archive.Find(script, kind, name)
+	}
+	return script, nil
+}
@@ -1,15 +1,16 @@
 package main
 
 import (
-	"compress/gzip"
-	"errors"
+	"context"
+	"crypto/sha256"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
-	"regexp"
 	"runtime"
+	"runtime/debug"
 	"runtime/pprof"
 	"strings"
 	"sync"
@@ -35,7 +36,10 @@ var (
 	index      = flag.Int("index", 0, "start from index")
 	lines      = flag.Bool("lines", false, "progress by line")
 	serialize  = flag.Bool("s", false, "serialize")
+	asJSON     = flag.Bool("json", false, "serialize as JSON")
+	version    = flag.String("version", "", "with -p, Oracle release the scripts target, such as 19c")
 	totalLines int
+	opts       parser.Options
 )
 
 type WorkerPool struct {
@@ -113,6 +117,14 @@ type (
 func main() {
 	flag.Parse()
 	// flag.PrintDefaults()
+	if *version != "" {
+		v, err := semantic.ParseVersion(*version)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		opts.Version = v
+	}
 
 	if *prof {
 		pf, err := os.Create("./cpu.prof")
@@ -180,6 +192,10 @@ func parseFile(path string) error {
 		return err
 	}
 
+	if !*parallel {
+		return streamFile(absPath)
+	}
+
 	// read file to string
 	text, err := os.ReadFile(absPath)
 	if err != nil {
@@ -200,18 +216,12 @@ func parseFile(path string) error {
 	msgChan := make(chan msg)
 	defer close(msgChan)
 	start := time.Now()
-	if *parallel {
-		parallelParse(requests, msgChan, results)
-	} else {
-		for _, req := range requests[*index:] {
-			result := parseBlock(req)
-			results[result.Index] = result
-		}
-	}
+	parallelParse(requests, msgChan, results)
 	elapsed := time.Since(start)
 	lines := <-count
 	for _, result := range results {
-		var err error
+		// the statements that failed to parse are kept as error statements,
+		// so the rest of the block is still built below
 		if result.Error != nil {
 			log.Error("Parse Error", log.String("file", filepath.Base(absPath)),
 				log.String("duration", elapsed.String()),
@@ -221,10 +231,6 @@ func parseFile(path string) error {
 				log.String("error", result.Error.Error()),
 				log.String("source", result.Source),
 			)
-			err = errors.Join(err, result.Error)
-		}
-		if err != nil {
-			return err
 		}
 	}
 
@@ -237,49 +243,86 @@ func parseFile(path string) error {
 	for _, result := range results {
 		s, err := result.AstFunc(result.Start)
 		if err != nil {
-			joinErr, ok := err.(interface{ Unwrap() []error })
-			if ok {
-				errs := joinErr.Unwrap()
-				for _, e := range errs {
-					var pe parser.ParseError
-					if errors.As(e, &pe) {
-						log.Warn(pe.Msg, log.Int("line", pe.Line), log.Int("column", pe.Column))
-					} else {
-						log.Error("Semantic Error", log.String("file", filepath.Base(absPath)),
-							log.String("error", e.Error()),
-						)
-					}
-				}
-			} else {
-				log.Error("Semantic Error", log.String("file", filepath.Base(absPath)),
-					log.String("error", err.Error()),
-				)
-			}
+			logDiagnostics(absPath, err)
 		}
 		script = appendScript(script, s)
 	}
 
-	// serialize
 	if *serialize {
-		filename := strings.TrimSuffix(filepath.Base(absPath), filepath.Ext(absPath)) + ".ast"
-		err = marshal(filename, script)
+		serializeScript(absPath, script)
+	}
+	return nil
+}
+
+// streamFile parses the file one statement at a time, which keeps the
+// memory used bounded for large files.
+func streamFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Println(err)
+		return err
+	}
+	defer f.Close()
+
+	log.Info("Start Parse", log.String("file", filepath.Base(path)))
+	start := time.Now()
+	script := &semantic.Script{}
+	count := 0
+	stream := parser.ParseStream(f)
+	for {
+		stmt, err := stream.Next()
+		if err == io.EOF {
+			break
+		}
 		if err != nil {
-			log.Error("Serialize Error", log.String("file", filepath.Base(absPath)),
-				log.String("error", err.Error()),
-			)
+			logDiagnostics(path, err)
+		}
+		if stmt != nil {
+			count++
+			if *serialize {
+				script.Statements = append(script.Statements, stmt)
+			}
 		}
 	}
+	log.Info("End Parse", log.String("file", filepath.Base(path)),
+		log.Int("statements", count),
+		log.String("duration", time.Since(start).String()))
+
+	if *serialize {
+		serializeScript(path, script)
+	}
 	return nil
 }
 
-func marshal(path string, script *semantic.Script) error {
-	filename := path
-	buf, err := semantic.NewNodeEncoder().Encode(script)
+func logDiagnostics(path string, err error) {
+	diags := semantic.Diagnostics(err)
+	if len(diags) == 0 {
+		log.Error("Semantic Error", log.String("file", filepath.Base(path)),
+			log.String("error", err.Error()),
+		)
+	}
+	for _, d := range diags {
+		log.Warn(d.Message, log.String("code", d.Code),
+			log.Int("line", d.Start.Line), log.Int("column", d.Start.Column))
+	}
+}
+
+func serializeScript(path string, script *semantic.Script) {
+	ext := ".ast"
+	if *asJSON {
+		ext = ".json"
+	}
+	filename := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)) + ext
+	err := marshal(filename, path, script)
 	if err != nil {
-		log.Error("Serialize Error", log.String("error", err.Error()))
-		return err
+		log.Error("Serialize Error", log.String("file", filepath.Base(path)),
+			log.String("error", err.Error()),
+		)
 	}
+}
 
+func marshal(path string, source string, script *semantic.Script) error {
+	filename := path
 	file, err := os.Create(filename)
 	if err != nil {
 		log.Error("Serialize Error", log.String("error", err.Error()))
@@ -287,10 +330,27 @@ func marshal(path string, script *semantic.Script) error {
 	}
 	defer file.Close()
 
-	gw := gzip.NewWriter(file)
-	defer gw.Close()
+	if *asJSON {
+		// JSON is written plain, for readers other than cmd/check
+		var buf []byte
+		buf, err = semantic.NewJSONNodeEncoder().Encode(script)
+		if err == nil {
+			_, err = file.Write(buf)
+		}
+		if err != nil {
+			log.Error("Serialize Error", log.String("error", err.Error()))
+		}
+		return err
+	}
 
-	_, err = gw.Write(buf)
+	header := semantic.AstFileHeader{
+		Source:        source,
+		ParserVersion: buildVersion(),
+	}
+	if text, err := os.ReadFile(source); err == nil {
+		header.SourceHash = fmt.Sprintf("%x", sha256.Sum256(text))
+	}
+	err = semantic.WriteAstFile(file, header, script)
 	if err != nil {
 		log.Error("Serialize Error", log.String("error", err.Error()))
 		return err
@@ -298,6 +358,22 @@ func marshal(path string, script *semantic.Script) error {
 	return nil
 }
 
+// buildVersion returns the version of the module this binary was built
+// from, with its VCS revision when known.
+func buildVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	version := info.Main.Version
+	for _, s := range info.Settings {
+		if s.Key == "vcs.revision" {
+			version += " " + s.Value
+		}
+	}
+	return version
+}
+
 func appendScript(script *semantic.Script, s *semantic.Script) *semantic.Script {
 	for _, stmt := range s.Statements {
 		script.Statements = append(script.Statements, stmt)
@@ -376,25 +452,25 @@ func prepareRequest(path string) ([]*ParseRequest, error) {
 	source := string(text)
 
 	if *parallel && info.Size() > *size {
-		re := regexp.MustCompile(`\r\n`)
-		source = re.ReplaceAllString(source, "\n")
-		// split source by /
-		regex := regexp.MustCompile(`(?m)^/$`)
-		blocks := regex.Split(source, -1)
-		start := 0
-		offset := 0
-		for i, block := range blocks {
-			if strings.TrimSpace(block) == "" {
-				continue
+		i := 0
+		splitter := parser.SplitScript(strings.NewReader(source))
+		for {
+			chunk, err := splitter.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			if strings.TrimSpace(chunk.Text) != "" {
+				requests = append(requests, &ParseRequest{
+					FileName: name,
+					Source:   chunk.Text,
+					Index:    i,
+					Start:    chunk.Lines,
+				})
+				i++
 			}
-			requests = append(requests, &ParseRequest{
-				FileName: name,
-				Source:   block,
-				Index:    i,
-				Start:    start + offset,
-			})
-			start += strings.Count(block, "\n")
-			offset = 0
 		}
 	} else {
 		requests = append(requests, &ParseRequest{
@@ -419,24 +495,21 @@ func parseBlock(r *ParseRequest) *ParseResult {
 	}
 	log.Debug("start parse", log.String("foo", "sss"), log.Int("index", r.Index), log.Int("start", r.Start))
 	el := time.Now()
-	s, err := parser.ParseSql(r.Source)
+	s, err := parser.ParseSqlOptions(context.Background(), r.Source, opts)
 	du := time.Since(el)
 	log.Debug("stop parse", log.String("foo", "xxx"), log.Int("index", r.Index), log.String("duration", du.String()), log.Int("start", r.Start))
-	if err != nil {
-		result.Error = err
-	} else {
-		if *serialize {
-			result.AstFunc = func(start int) (*semantic.Script, error) {
-				script, err := s(start)
-				if err != nil {
-					return nil, err
-				}
-
-				return script, nil
+	result.Error = err
+	if *serialize {
+		result.AstFunc = func(start int) (*semantic.Script, error) {
+			script, err := s(start)
+			if err != nil {
+				return nil, err
 			}
-		} else {
-			result.AstFunc = s
+
+			return script, nil
 		}
+	} else {
+		result.AstFunc = s
 	}
 	return result
 }
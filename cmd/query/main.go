@@ -0,0 +1,96 @@
+// Command query prints the nodes of scripts that a selector matches, each
+// script given as SQL source or as an .ast file.
+//
+//	query 'CreateProcedureStatement LoopStatement CommitStatement' billing.sql
+//
+// See semantic.Selector for the selector syntax. It exits with status 1
+// when nothing matches.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"procinspect/pkg/archive"
+	"procinspect/pkg/parser"
+	"procinspect/pkg/semantic"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: query selector file...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() < 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	sel, err := semantic.ParseSelector(flag.Arg(0))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+
+	found := false
+	for _, path := range flag.Args()[1:] {
+		script, err := load(path)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(2)
+		}
+		for _, match := range sel.Select(script) {
+			found = true
+			fmt.Printf("%s: %s\n", position(path, match), describe(match))
+		}
+	}
+	if !found {
+		os.Exit(1)
+	}
+}
+
+// load reads the script at path.
+func load(path string) (*semantic.Script, error) {
+	if filepath.Ext(path) == ".ast" {
+		a, err := archive.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer a.Close()
+		return a.Script()
+	}
+
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	script, err := parser.ParseScript(string(text))
+	if err != nil {
+		if script == nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		// the statements that parsed are still queried
+		fmt.Fprintf(os.Stderr, "%s: %s\n", path, err)
+	}
+	return script, nil
+}
+
+func position(path string, node semantic.AstNode) string {
+	if n, ok := node.(semantic.Node); ok {
+		return fmt.Sprintf("%s:%d:%d", path, n.Line(), n.Column())
+	}
+	return path
+}
+
+// describe returns the type of node, followed by its name when it has one.
+func describe(node semantic.AstNode) string {
+	v := reflect.Indirect(reflect.ValueOf(node))
+	if name := v.FieldByName("Name"); name.Kind() == reflect.String && name.String() != "" {
+		return v.Type().Name() + " " + name.String()
+	}
+	return v.Type().Name()
+}
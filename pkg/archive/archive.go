@@ -0,0 +1,124 @@
+// Package archive reads the program units of an .ast file one at a time,
+// without decoding the statements around them.
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"procinspect/pkg/semantic"
+)
+
+// ErrNotFound is returned by Lookup for objects the archive does not hold.
+var ErrNotFound = errors.New("object not found")
+
+// Archive is an open .ast file.
+type Archive struct {
+	file *os.File
+	ast  *semantic.AstFile
+}
+
+// Open opens the .ast file at path and reads its index.
+func Open(path string) (*Archive, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	ast, err := semantic.OpenAstFile(f, info.Size())
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &Archive{file: f, ast: ast}, nil
+}
+
+func (a *Archive) Close() error {
+	return a.file.Close()
+}
+
+// Header returns the header of the file.
+func (a *Archive) Header() *semantic.AstFileHeader {
+	return a.ast.Header
+}
+
+// Objects returns the index entries of the program units, in source order.
+func (a *Archive) Objects() []semantic.AstFileEntry {
+	var objects []semantic.AstFileEntry
+	for _, e := range a.ast.Index {
+		if e.Kind != "" {
+			objects = append(objects, e)
+		}
+	}
+	return objects
+}
+
+// Lookup decodes the program unit of type kind, such as PACKAGE BODY, and
+// the given name. Names are matched as Oracle does, and an unqualified
+// name matches the objects of any schema; an empty kind matches any type.
+// When the file creates the object more than once, the last one is
+// returned, which is the one that stands after the script runs.
+func (a *Archive) Lookup(kind, name string) (semantic.Statement, error) {
+	m := newMatcher(kind, name)
+	for i := len(a.ast.Index) - 1; i >= 0; i-- {
+		if e := a.ast.Index[i]; m.match(e.Kind, e.Name) {
+			return a.ast.Statement(i)
+		}
+	}
+	return nil, m.notFound()
+}
+
+// Script decodes all the statements of the file.
+func (a *Archive) Script() (*semantic.Script, error) {
+	return a.ast.Script()
+}
+
+// Find returns the program unit of script of type kind and the given
+// name, matched as by Lookup.
+func Find(script *semantic.Script, kind, name string) (semantic.Statement, error) {
+	m := newMatcher(kind, name)
+	for i := len(script.Statements) - 1; i >= 0; i-- {
+		if s := script.Statements[i]; s != nil && m.match(semantic.UnitObject(s)) {
+			return s, nil
+		}
+	}
+	return nil, m.notFound()
+}
+
+type matcher struct {
+	kind string
+	name semantic.QualifiedName
+	text string
+}
+
+func newMatcher(kind, name string) matcher {
+	return matcher{
+		kind: strings.ToUpper(strings.Join(strings.Fields(kind), " ")),
+		name: semantic.ParseQualifiedName(name),
+		text: name,
+	}
+}
+
+func (m matcher) match(kind, name string) bool {
+	if kind == "" || m.kind != "" && kind != m.kind {
+		return false
+	}
+	q := semantic.ParseQualifiedName(name)
+	if len(m.name) == 1 && len(q) > 0 {
+		return q.Last().Equal(m.name.Last())
+	}
+	return q.Equal(m.name)
+}
+
+func (m matcher) notFound() error {
+	if m.kind == "" {
+		return fmt.Errorf("%w: %s", ErrNotFound, m.text)
+	}
+	return fmt.Errorf("%w: %s %s", ErrNotFound, m.kind, m.text)
+}
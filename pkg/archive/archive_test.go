@@ -0,0 +1,98 @@
+package archive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"procinspect/pkg/semantic"
+)
+
+func writeArchive(t *testing.T, script *semantic.Script) string {
+	path := filepath.Join(t.TempDir(), "billing.ast")
+	f, err := os.Create(path)
+	require.Nil(t, err)
+	defer f.Close()
+	require.Nil(t, semantic.WriteAstFile(f, semantic.AstFileHeader{Source: "billing.sql"}, script))
+	return path
+}
+
+func TestLookup(t *testing.T) {
+	old := &semantic.CreatePackageBodyStatement{Name: "pkg_billing"}
+	body := &semantic.CreatePackageBodyStatement{
+		Name: "billing.pkg_billing",
+		Procedures: []*semantic.CreateProcedureStatement{{
+			Name: "charge",
+			Body: &semantic.Body{Statements: []semantic.Statement{&semantic.NullStatement{}}},
+		}},
+	}
+	body.SetLine(12)
+	script := &semantic.Script{Statements: []semantic.Statement{
+		&semantic.CreatePackageStatement{Name: "pkg_billing"},
+		old,
+		&semantic.CommitStatement{},
+		body,
+		&semantic.CreateProcedureStatement{Name: `"Archive"`},
+	}}
+	a, err := Open(writeArchive(t, script))
+	require.Nil(t, err)
+	defer a.Close()
+
+	assert.Equal(t, "billing.sql", a.Header().Source)
+	objects := a.Objects()
+	require.Len(t, objects, 4)
+	assert.Equal(t, "PACKAGE BODY", objects[2].Kind)
+	assert.Equal(t, "billing.pkg_billing", objects[2].Name)
+	assert.Equal(t, 12, objects[2].Line)
+
+	stmt, err := a.Lookup("package  body", "PKG_BILLING")
+	require.Nil(t, err)
+	assert.Equal(t, body, stmt)
+	stmt, err = a.Lookup("PACKAGE BODY", "Billing.Pkg_Billing")
+	require.Nil(t, err)
+	assert.Equal(t, body, stmt)
+	stmt, err = a.Lookup("PACKAGE", "pkg_billing")
+	require.Nil(t, err)
+	assert.IsType(t, &semantic.CreatePackageStatement{}, stmt)
+	stmt, err = a.Lookup("", `"Archive"`)
+	require.Nil(t, err)
+	assert.IsType(t, &semantic.CreateProcedureStatement{}, stmt)
+
+	_, err = a.Lookup("PROCEDURE", "archive")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = a.Lookup("FUNCTION", "pkg_billing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	all, err := a.Script()
+	require.Nil(t, err)
+	assert.Equal(t, script, all)
+}
+
+func TestOpenInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.ast")
+	require.Nil(t, os.WriteFile(path, []byte("select 1 from dual;"), 0644))
+	_, err := Open(path)
+	assert.ErrorIs(t, err, semantic.ErrIncompatibleAstFile)
+}
+
+func TestFind(t *testing.T) {
+	first := &semantic.CreateProcedureStatement{Name: "archive"}
+	last := &semantic.CreateProcedureStatement{Name: "ARCHIVE"}
+	script := &semantic.Script{Statements: []semantic.Statement{
+		first,
+		&semantic.CreateFunctionStatement{Name: "archive"},
+		last,
+	}}
+
+	stmt, err := Find(script, "procedure", "Archive")
+	require.Nil(t, err)
+	assert.Same(t, last, stmt)
+	stmt, err = Find(script, "", "archive")
+	require.Nil(t, err)
+	assert.Same(t, last, stmt)
+	_, err = Find(script, "PACKAGE", "archive")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -6,11 +6,25 @@ import (
 	"procinspect/pkg/semantic"
 )
 
+// The target databases a script can be checked for.
+const (
+	DatabasePostgreSQL = "postgresql"
+	DatabaseMySQL      = "mysql"
+	DatabaseSQLServer  = "sqlserver"
+)
+
 type Rule struct {
-	Name      string
-	Target    semantic.Node
+	Name   string
+	Target semantic.Node
+	// Selector, when set, picks the nodes to check in place of Target, as
+	// in "CreateProcedureStatement LoopStatement CommitStatement". Without
+	// a CheckFunc, every node it picks is reported.
+	Selector  string
 	CheckFunc checkFunc
 	Message   string
+	// Databases lists the target databases that lack what the rule
+	// reports. A rule without any applies to every target.
+	Databases []string
 }
 
 type checkFunc func(r Rule, node semantic.Node) error
@@ -45,13 +59,58 @@ var rules = []Rule{{
 		return nil
 	},
 	Message: "unsupported: update set multiple columns with select",
+}, {
+	Name:   "pivot clause",
+	Target: &semantic.PivotClause{},
+	CheckFunc: func(r Rule, node semantic.Node) error {
+		return SqlValidationError{Line: node.Line(), Msg: r.Message}
+	},
+	Message:   "unsupported: pivot clause",
+	Databases: []string{DatabasePostgreSQL, DatabaseMySQL},
+}, {
+	Name:   "unpivot clause",
+	Target: &semantic.UnpivotClause{},
+	CheckFunc: func(r Rule, node semantic.Node) error {
+		return SqlValidationError{Line: node.Line(), Msg: r.Message}
+	},
+	Message:   "unsupported: unpivot clause",
+	Databases: []string{DatabasePostgreSQL, DatabaseMySQL},
+}, {
+	Name:   "model clause",
+	Target: &semantic.ModelClause{},
+	CheckFunc: func(r Rule, node semantic.Node) error {
+		return SqlValidationError{Line: node.Line(), Msg: r.Message}
+	},
+	Message:   "unsupported: model clause",
+	Databases: []string{DatabasePostgreSQL, DatabaseMySQL, DatabaseSQLServer},
+}, {
+	Name:   "json_table",
+	Target: &semantic.JsonTableExpression{},
+	CheckFunc: func(r Rule, node semantic.Node) error {
+		return SqlValidationError{Line: node.Line(), Msg: r.Message}
+	},
+	Message: "unsupported: json_table",
+}, {
+	Name:   "xmltable",
+	Target: &semantic.XmlTableExpression{},
+	CheckFunc: func(r Rule, node semantic.Node) error {
+		return SqlValidationError{Line: node.Line(), Msg: r.Message}
+	},
+	Message: "unsupported: xmltable",
 },
 }
 
+// ValidExprFunc returns a check that reports the node when validRule holds.
+// Besides node, the rule can use sameName(a, b), which compares names as
+// Oracle does, and name(s), which returns the canonical form of a name.
 func ValidExprFunc(validRule string) checkFunc {
 	return func(r Rule, node semantic.Node) error {
 		env := map[string]any{
 			"node": node,
+			"sameName": func(a, b string) bool {
+				return semantic.ParseQualifiedName(a).Equal(semantic.ParseQualifiedName(b))
+			},
+			"name": semantic.NormalizeName,
 		}
 		program, err := expr.Compile(validRule, expr.Env(env), expr.AsBool())
 		if err != nil {
@@ -11,8 +11,16 @@ import (
 
 type (
 	SqlValidator struct {
-		err     *multierror.Error
-		ruleMap map[reflect.Type]Rule
+		err       *multierror.Error
+		ruleMap   map[reflect.Type]Rule
+		selectors []selectorRule
+		// database is the target database, or empty to apply every rule.
+		database string
+	}
+
+	selectorRule struct {
+		Rule
+		selector *semantic.Selector
 	}
 
 	Validator interface {
@@ -32,19 +40,65 @@ func (v *SqlValidator) Validate(node semantic.AstNode) error {
 		t = t.Elem()
 	}
 	if r, ok := v.ruleMap[t]; ok {
-		n := node.(semantic.Node)
-		e := r.CheckFunc(r, n)
-		var verr SqlValidationError
-		if errors.As(e, &verr) {
-			v.err = multierror.Append(v.err, verr)
-		} else {
-			return e
+		if err := v.check(r, node.(semantic.Node)); err != nil {
+			return err
+		}
+	}
+	// selectors see the whole tree, so they run once for the script
+	if _, ok := node.(*semantic.Script); ok {
+		for _, r := range v.selectors {
+			for _, match := range r.selector.Select(node) {
+				n, ok := match.(semantic.Node)
+				if !ok {
+					continue
+				}
+				if err := v.check(r.Rule, n); err != nil {
+					return err
+				}
+			}
 		}
 	}
 
 	return nil
 }
 
+func (v *SqlValidator) check(r Rule, node semantic.Node) error {
+	if !v.applies(r) {
+		return nil
+	}
+	if r.CheckFunc == nil {
+		v.err = multierror.Append(v.err, SqlValidationError{Line: node.Line(), Msg: r.Message})
+		return nil
+	}
+	e := r.CheckFunc(r, node)
+	var verr SqlValidationError
+	if errors.As(e, &verr) {
+		v.err = multierror.Append(v.err, verr)
+	} else {
+		return e
+	}
+	return nil
+}
+
+// applies reports whether r is checked for the target database of v.
+func (v *SqlValidator) applies(r Rule) bool {
+	if v.database == "" || len(r.Databases) == 0 {
+		return true
+	}
+	for _, db := range r.Databases {
+		if db == v.database {
+			return true
+		}
+	}
+	return false
+}
+
+// SetDatabase sets the target database, such as DatabasePostgreSQL, so that
+// only the rules for it are checked. An empty name checks every rule.
+func (v *SqlValidator) SetDatabase(name string) {
+	v.database = name
+}
+
 func (v *SqlValidator) Error() error {
 	return v.err
 }
@@ -61,8 +115,14 @@ func NewSqlValidator() *SqlValidator {
 	return v
 }
 
+// RegisterValidateRules adds rs to the rules of v. Rules with a Selector
+// are matched against the whole script; it panics when one is not valid.
 func (v *SqlValidator) RegisterValidateRules(rs []Rule) {
 	for _, r := range rs {
+		if r.Selector != "" {
+			v.selectors = append(v.selectors, selectorRule{r, semantic.MustParseSelector(r.Selector)})
+			continue
+		}
 		t := reflect.TypeOf(r.Target)
 		if t.Kind() == reflect.Ptr {
 			t = t.Elem()
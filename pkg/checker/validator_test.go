@@ -148,3 +148,62 @@ func TestRuleEngine(t *testing.T) {
 		})
 	}
 }
+
+func TestRuleEngineSameName(t *testing.T) {
+	runTest(t, `select * from "TEST";`, func(t *testing.T, root any) {
+		require.IsType(t, &semantic.Script{}, root)
+		node := root.(*semantic.Script)
+
+		v := NewValidVisitor()
+		v.RegisterValidateRules([]Rule{{
+			Name:      "select from test",
+			Target:    &semantic.SelectStatement{},
+			CheckFunc: ValidExprFunc(`sameName(node.From.TableRefs[0].Table, "test")`),
+			Message:   "unsupported: select from test",
+		}})
+		assert.Nil(t, node.Accept(v))
+		var errs *multierror.Error
+		require.ErrorAs(t, v.Error(), &errs)
+		assert.Equal(t, 1, len(errs.Errors))
+	})
+}
+
+func TestRuleEngineSelector(t *testing.T) {
+	runTest(t, `create or replace procedure purge_orders is
+begin
+	loop
+		update orders set state = 'X' where rownum < 1000;
+		commit;
+		exit;
+	end loop;
+	commit;
+end;
+select * from orders@archive;`, func(t *testing.T, root any) {
+		require.IsType(t, &semantic.Script{}, root)
+		node := root.(*semantic.Script)
+
+		v := NewValidVisitor()
+		v.RegisterValidateRules([]Rule{{
+			Name:     "commit in loop",
+			Selector: "CreateProcedureStatement LoopStatement > CommitStatement",
+			Message:  "unsupported: commit in loop",
+		}, {
+			Name:     "select from dblink",
+			Selector: `SelectStatement[From.TableRefs.Table ~= "@"]`,
+			CheckFunc: func(r Rule, node semantic.Node) error {
+				return SqlValidationError{Line: node.Line(), Msg: r.Message}
+			},
+			Message: "unsupported: select from dblink",
+		}})
+		assert.Nil(t, node.Accept(v))
+		var errs *multierror.Error
+		require.ErrorAs(t, v.Error(), &errs)
+		require.Equal(t, 2, len(errs.Errors))
+		assert.Equal(t, SqlValidationError{Line: 5, Msg: "unsupported: commit in loop"}, errs.Errors[0])
+		assert.Equal(t, SqlValidationError{Line: 10, Msg: "unsupported: select from dblink"}, errs.Errors[1])
+	})
+
+	assert.Panics(t, func() {
+		NewValidVisitor().RegisterValidateRules([]Rule{{Selector: "LoopStatement[Label]"}})
+	})
+}
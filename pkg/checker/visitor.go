@@ -1,6 +1,7 @@
 package checker
 
 import (
+	"context"
 	"errors"
 
 	"github.com/hashicorp/go-multierror"
@@ -17,7 +18,12 @@ type (
 )
 
 func LoadScript(src string) (*semantic.Script, error) {
-	script, err := parser.ParseScript(src)
+	return LoadScriptOptions(src, parser.Options{})
+}
+
+// LoadScriptOptions parses src in the dialect given by opts.
+func LoadScriptOptions(src string, opts parser.Options) (*semantic.Script, error) {
+	script, err := parser.ParseScriptOptions(context.Background(), src, opts)
 	if err != nil {
 		return script, err
 	}
@@ -66,3 +72,8 @@ func (v *ValidVisitor) Error() error {
 func (v *ValidVisitor) RegisterValidateRules(r []Rule) {
 	v.v.RegisterValidateRules(r)
 }
+
+// SetDatabase sets the target database the rules are checked for.
+func (v *ValidVisitor) SetDatabase(name string) {
+	v.v.SetDatabase(name)
+}
@@ -72,3 +72,76 @@ select * from sql1;`,
 		})
 	}
 }
+
+func TestCheckPivotAndModel(t *testing.T) {
+	tests := testSuite{
+		{
+			name: "pivot unpivot and model",
+			text: `select * from sales pivot (sum(amount) for quarter in ('Q1', 'Q2'));
+select * from sales unpivot (amount for quarter in (q1, q2));
+select year, sales from sales_view
+model dimension by (year) measures (sales) rules (sales[2001] = 0);`,
+			Func: func(t *testing.T, src string) {
+				script, err := LoadScript(src)
+				assert.Nil(t, err)
+				require.NotNil(t, script)
+				v := NewValidVisitor()
+				_ = script.Accept(v)
+				err = v.Error()
+				require.NotNil(t, err)
+				errs := err.(*multierror.Error).Errors
+				require.Equal(t, 3, len(errs))
+				assert.Equal(t, SqlValidationError{Line: 1, Msg: "unsupported: pivot clause"}, errs[0])
+				assert.Equal(t, SqlValidationError{Line: 2, Msg: "unsupported: unpivot clause"}, errs[1])
+				assert.Equal(t, SqlValidationError{Line: 4, Msg: "unsupported: model clause"}, errs[2])
+			},
+		},
+		{
+			name: "pivot and model for sql server",
+			text: `select * from sales pivot (sum(amount) for quarter in ('Q1', 'Q2'));
+select year, sales from sales_view
+model dimension by (year) measures (sales) rules (sales[2001] = 0);`,
+			Func: func(t *testing.T, src string) {
+				script, err := LoadScript(src)
+				assert.Nil(t, err)
+				require.NotNil(t, script)
+				v := NewValidVisitor()
+				v.SetDatabase(DatabaseSQLServer)
+				_ = script.Accept(v)
+				err = v.Error()
+				require.NotNil(t, err)
+				errs := err.(*multierror.Error).Errors
+				require.Equal(t, 1, len(errs))
+				assert.Equal(t, SqlValidationError{Line: 3, Msg: "unsupported: model clause"}, errs[0])
+			},
+		},
+	}
+
+	runTestSuite(t, tests)
+}
+
+func TestCheckJsonAndXmlTables(t *testing.T) {
+	tests := testSuite{
+		{
+			name: "json_table and xmltable",
+			text: `select jt.* from orders o,
+  json_table(o.doc, '$.items[*]' columns (sku varchar2(20) path '$.sku')) jt;
+select x.* from xmltable('/rows/row' passing xmltype(:doc) columns id number path '@id') x;`,
+			Func: func(t *testing.T, src string) {
+				script, err := LoadScript(src)
+				assert.Nil(t, err)
+				require.NotNil(t, script)
+				v := NewValidVisitor()
+				_ = script.Accept(v)
+				err = v.Error()
+				require.NotNil(t, err)
+				errs := err.(*multierror.Error).Errors
+				require.Equal(t, 2, len(errs))
+				assert.Equal(t, SqlValidationError{Line: 2, Msg: "unsupported: json_table"}, errs[0])
+				assert.Equal(t, SqlValidationError{Line: 3, Msg: "unsupported: xmltable"}, errs[1])
+			},
+		},
+	}
+
+	runTestSuite(t, tests)
+}
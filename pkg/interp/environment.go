@@ -2,10 +2,13 @@ package interp
 
 import (
 	"fmt"
+
+	"procinspect/pkg/semantic"
 )
 
 // Environment is the environment of the interpreter.
-// storage of variables.
+// storage of variables. Names are looked up as Oracle does, so that
+// unquoted names match regardless of case.
 type Environment struct {
 	values map[string]interface{}
 	parent *Environment
@@ -28,12 +31,14 @@ func NewChildEnvironment(parent *Environment) *Environment {
 
 // Define a variable in the environment.
 func (e *Environment) Define(name string, value interface{}) {
-	e.values[name] = value
+	key := semantic.NormalizeName(name)
+	e.values[key] = value
 }
 
 // Get a variable from the environment.
 func (e *Environment) Get(name string) (value any, err error) {
-	value, ok := e.values[name]
+	key := semantic.NormalizeName(name)
+	value, ok := e.values[key]
 	if ok {
 		return
 	}
@@ -48,9 +53,10 @@ func (e *Environment) Get(name string) (value any, err error) {
 
 // Assign a variable in the environment with the given value.
 func (e *Environment) Assign(name string, value interface{}) (err error) {
-	_, ok := e.values[name]
+	key := semantic.NormalizeName(name)
+	_, ok := e.values[key]
 	if ok {
-		e.values[name] = value
+		e.values[key] = value
 		return
 	}
 
@@ -72,7 +78,7 @@ func (e *Environment) GetAt(distance int, name string) (result interface{}, err
 		}
 	}
 
-	result, ok := env.values[name]
+	result, ok := env.values[semantic.NormalizeName(name)]
 	if !ok {
 		err = fmt.Errorf("unexpected resolving on variable %q", name)
 		return
@@ -91,6 +97,6 @@ func (e *Environment) AssignAt(distance int, name string, result interface{}) (e
 		}
 	}
 
-	env.values[name] = result
+	env.values[semantic.NormalizeName(name)] = result
 	return
 }
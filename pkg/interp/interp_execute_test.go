@@ -272,3 +272,33 @@ END;`,
 
 	runTestSuite(t, tests)
 }
+
+func TestInterpreter_NameCase(t *testing.T) {
+	var tests testSuite
+
+	tests = append(tests, testCase{
+		name: "unquoted names ignore case",
+		text: `
+DECLARE
+	counter NUMBER;
+BEGIN
+	COUNTER := 1;
+END;`,
+		Func: func(t *testing.T, i *Interpreter) {
+			program, err := i.LoadScript(i.Source)
+			assert.Nil(t, err)
+			err = i.Interpret(context.Background(), program)
+			assert.Nil(t, err)
+			v, err := i.global.Get("Counter")
+			assert.Nil(t, err)
+			assert.Equal(t, &Number{Value: 1}, v)
+			v, err = i.global.Get(`"COUNTER"`)
+			assert.Nil(t, err)
+			assert.Equal(t, &Number{Value: 1}, v)
+			_, err = i.global.Get(`"counter"`)
+			assert.NotNil(t, err)
+		},
+	})
+
+	runTestSuite(t, tests)
+}
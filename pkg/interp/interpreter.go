@@ -202,3 +202,21 @@ func (i *Interpreter) VisitDotExpression(s *semantic.DotExpression) (result any,
 	name := s.Name.(*semantic.NameExpression).Name
 	return gettable.Get(name)
 }
+
+// The recognized names are evaluated as the expressions they were parsed as.
+
+func (i *Interpreter) VisitPseudoColumn(s *semantic.PseudoColumn) (result any, err error) {
+	return i.evaluate(s.Expr.(semantic.Expression))
+}
+
+func (i *Interpreter) VisitSequenceReference(s *semantic.SequenceReference) (result any, err error) {
+	return i.evaluate(s.Expr.(semantic.Expression))
+}
+
+func (i *Interpreter) VisitCorrelationReference(s *semantic.CorrelationReference) (result any, err error) {
+	return i.evaluate(s.Expr.(semantic.Expression))
+}
+
+func (i *Interpreter) VisitSqlCursorAttribute(s *semantic.SqlCursorAttribute) (result any, err error) {
+	return i.evaluate(s.Expr.(semantic.Expression))
+}
@@ -38,7 +38,8 @@ type (
 )
 
 func (p *Package) Get(name string) (any, error) {
-	proc, ok := p.procedures[name]
+	key := semantic.NormalizeName(name)
+	proc, ok := p.procedures[key]
 	if ok {
 		return proc, nil
 	}
@@ -49,9 +50,9 @@ func (p *Package) Get(name string) (any, error) {
 
 	if p.Body != nil {
 		for _, procedure := range p.Body.Procedures {
-			if procedure.Name == name {
+			if procedure.QualifiedName().Last().EqualFold(name) {
 				proc = &Procedure{Name: name, Proc: procedure}
-				p.procedures[name] = proc
+				p.procedures[key] = proc
 				return proc, nil
 			}
 		}
@@ -26,7 +26,7 @@ func (v *resolver) VisitCreatePackageStatement(s *semantic.CreatePackageStatemen
 
 func (v *resolver) VisitCreatePackageBodyStatement(s *semantic.CreatePackageBodyStatement) (err error) {
 	for _, p := range v.interp.program.Packages {
-		if p.Name == s.Name {
+		if p.Package.QualifiedName().Equal(s.QualifiedName()) {
 			p.Body = s
 			return
 		}
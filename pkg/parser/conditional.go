@@ -0,0 +1,801 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"procinspect/pkg/semantic"
+)
+
+// ConditionalOptions controls the evaluation of conditional compilation by
+// ParseConditionalScript.
+type ConditionalOptions struct {
+	// CCFlags is a PLSQL_CCFLAGS setting such as "debug:TRUE, level:2".
+	CCFlags string
+	// Version and Release are the values of DBMS_DB_VERSION.VERSION and
+	// DBMS_DB_VERSION.RELEASE. A zero Version stands for 19.0.
+	Version, Release int
+	// AllBranches parses the branches that are not selected as well, so
+	// that every branch in Script.Conditionals holds its nodes.
+	AllBranches bool
+}
+
+// ParseConditionalScript parses src after resolving its conditional
+// compilation directives: the branches whose condition does not hold are
+// blanked out, $$ inquiry directives are replaced by their values and the
+// $ERROR directives of the selected code are reported as errors.
+func ParseConditionalScript(src string, opts ConditionalOptions) (*semantic.Script, error) {
+	cc, err := newConditionalSource(src, opts)
+	if err != nil {
+		return nil, err
+	}
+	text, _, ccErr := cc.render(func(b *ccBlock) int { return b.chosen })
+	script, err := ParseScript(text)
+	if script == nil {
+		return nil, errors.Join(ccErr, err)
+	}
+	script.Conditionals = cc.nodes
+	if opts.AllBranches {
+		err = errors.Join(err, cc.parseBranches())
+	}
+	return script, errors.Join(ccErr, err)
+}
+
+type (
+	ccToken struct {
+		kind       string
+		start, end int
+		line, col  int
+	}
+
+	// ccItem is a directive found in the code: *ccBlock, *ccError or
+	// *ccInquiry.
+	ccItem interface {
+		bounds() (int, int)
+	}
+
+	ccBlock struct {
+		start, end int
+		branches   []*ccBranch
+		parent     *ccBranch
+		node       *semantic.ConditionalBlock
+		chosen     int
+	}
+
+	ccBranch struct {
+		// head is the offset of the $IF, $ELSIF or $ELSE keyword; the
+		// branch code runs from body to end.
+		head, body, end int
+		cond            string
+		block           *ccBlock
+		items           []ccItem
+		node            *semantic.ConditionalBranch
+	}
+
+	ccError struct {
+		start, end int
+		line, col  int
+		msg        string
+	}
+
+	ccInquiry struct {
+		start, end int
+		line       int
+		name       string
+	}
+
+	conditionalSource struct {
+		src    string
+		opts   ConditionalOptions
+		flags  map[string]any
+		items  []ccItem
+		blocks []*ccBlock
+		nodes  []*semantic.ConditionalBlock
+	}
+)
+
+func (b *ccBlock) bounds() (int, int)   { return b.start, b.end }
+func (e *ccError) bounds() (int, int)   { return e.start, e.end }
+func (i *ccInquiry) bounds() (int, int) { return i.start, i.end }
+
+func newConditionalSource(src string, opts ConditionalOptions) (*conditionalSource, error) {
+	if opts.Version == 0 {
+		opts.Version, opts.Release = 19, 0
+	}
+	flags, err := parseCCFlags(opts.CCFlags)
+	if err != nil {
+		return nil, err
+	}
+	cc := &conditionalSource{src: src, opts: opts, flags: flags}
+	p := &ccParser{cc: cc, tokens: scanDirectives(src)}
+	if cc.items, err = p.parseItems(nil); err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		tok := p.tokens[p.pos]
+		return nil, newDiagnostic(semantic.CodeConditional,
+			fmt.Sprintf("unexpected $%s", tok.kind), tok.line, tok.col)
+	}
+	for _, block := range cc.blocks {
+		block.chosen = -1
+		for i, branch := range block.branches {
+			if branch.cond == "" || cc.condition(branch) {
+				block.chosen = i
+				break
+			}
+		}
+		for i, branch := range block.branches {
+			branch.node.Selected = i == block.chosen
+		}
+	}
+	return cc, nil
+}
+
+// parseCCFlags parses a PLSQL_CCFLAGS setting, whose values are booleans,
+// integers or NULL.
+func parseCCFlags(text string) (map[string]any, error) {
+	flags := make(map[string]any)
+	for _, item := range strings.Split(text, ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(item, ":")
+		name = strings.ToUpper(strings.TrimSpace(name))
+		value = strings.TrimSpace(value)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid PLSQL_CCFLAGS item %q", item)
+		}
+		switch strings.ToUpper(value) {
+		case "TRUE":
+			flags[name] = true
+		case "FALSE":
+			flags[name] = false
+		case "NULL":
+			flags[name] = nil
+		default:
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid PLSQL_CCFLAGS value %q for %s", value, name)
+			}
+			flags[name] = n
+		}
+	}
+	return flags, nil
+}
+
+// scanDirectives returns the conditional compilation tokens of src, skipping
+// comments, string literals and quoted identifiers.
+func scanDirectives(src string) []ccToken {
+	var tokens []ccToken
+	line, lineStart := 1, 0
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line, lineStart = line+1, i+1
+		case c == '-' && strings.HasPrefix(src[i:], "--"):
+			for i+1 < len(src) && src[i+1] != '\n' {
+				i++
+			}
+		case c == '/' && strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				end = len(src) - i - 4
+			}
+			for _, r := range src[i : i+end+4] {
+				if r == '\n' {
+					line++
+				}
+			}
+			i += end + 3
+			lineStart = strings.LastIndexByte(src[:i+1], '\n') + 1
+		case c == '\'' || c == '"':
+			close := string(c)
+			if c == '\'' && i > 0 && (src[i-1] == 'q' || src[i-1] == 'Q') &&
+				(i == 1 || !isNameChar(src[i-2])) && i+1 < len(src) {
+				// q'[...]' quoting
+				close = string(ccClosingDelimiter(src[i+1])) + "'"
+				i++
+			}
+			for i++; i < len(src); i++ {
+				if strings.HasPrefix(src[i:], close) {
+					if close != "'" || !strings.HasPrefix(src[i:], "''") {
+						break
+					}
+					// doubled quote inside the literal
+					i++
+					continue
+				}
+				if src[i] == '\n' {
+					line, lineStart = line+1, i+1
+				}
+			}
+			i += len(close) - 1
+		case c == '$' && (i == 0 || !isNameChar(src[i-1])):
+			start := i
+			kind := "INQUIRY"
+			if strings.HasPrefix(src[i:], "$$") {
+				i++
+			}
+			j := i + 1
+			for j < len(src) && isNameChar(src[j]) {
+				j++
+			}
+			word := strings.ToUpper(src[i+1 : j])
+			if start == i {
+				switch word {
+				case "IF", "THEN", "ELSIF", "ELSE", "END", "ERROR":
+					kind = word
+				default:
+					i = j - 1
+					continue
+				}
+			} else if word == "" {
+				i = j - 1
+				continue
+			}
+			tokens = append(tokens, ccToken{kind: kind, start: start, end: j, line: line, col: start - lineStart})
+			i = j - 1
+		}
+	}
+	return tokens
+}
+
+func ccClosingDelimiter(c byte) byte {
+	switch c {
+	case '[':
+		return ']'
+	case '(':
+		return ')'
+	case '{':
+		return '}'
+	case '<':
+		return '>'
+	}
+	return c
+}
+
+type ccParser struct {
+	cc     *conditionalSource
+	tokens []ccToken
+	pos    int
+	branch *ccBranch
+}
+
+// parseItems parses the directives up to the first token whose kind is in
+// stop, which is left unconsumed.
+func (p *ccParser) parseItems(stop []string) ([]ccItem, error) {
+	var items []ccItem
+	for p.pos < len(p.tokens) {
+		tok := p.tokens[p.pos]
+		if isStop(stop, tok.kind) {
+			return items, nil
+		}
+		switch tok.kind {
+		case "INQUIRY":
+			p.pos++
+			items = append(items, &ccInquiry{start: tok.start, end: tok.end, line: tok.line,
+				name: strings.ToUpper(p.cc.src[tok.start+2 : tok.end])})
+		case "IF":
+			block, err := p.parseBlock()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, block)
+		case "ERROR":
+			p.pos++
+			end, err := p.expect(tok, "END")
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, &ccError{start: tok.start, end: end.end, line: tok.line, col: tok.col,
+				msg: p.cc.src[tok.end:end.start]})
+		default:
+			return items, nil
+		}
+	}
+	return items, nil
+}
+
+// isStop reports whether kind is one of the stop kinds.
+func isStop(stop []string, kind string) bool {
+	for _, k := range stop {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// expect skips the inquiry directives up to the next token, which must be
+// of the given kind.
+func (p *ccParser) expect(from ccToken, kind string) (ccToken, error) {
+	for ; p.pos < len(p.tokens); p.pos++ {
+		tok := p.tokens[p.pos]
+		if tok.kind == "INQUIRY" {
+			continue
+		}
+		if tok.kind != kind {
+			return tok, newDiagnostic(semantic.CodeConditional,
+				fmt.Sprintf("expected $%s, found $%s", kind, tok.kind), tok.line, tok.col)
+		}
+		p.pos++
+		return tok, nil
+	}
+	return from, newDiagnostic(semantic.CodeConditional,
+		fmt.Sprintf("$%s without $%s", from.kind, kind), from.line, from.col)
+}
+
+func (p *ccParser) parseBlock() (*ccBlock, error) {
+	ifTok := p.tokens[p.pos]
+	block := &ccBlock{start: ifTok.start, parent: p.branch}
+	block.node = &semantic.ConditionalBlock{}
+	p.cc.position(block.node, ifTok)
+	p.cc.blocks = append(p.cc.blocks, block)
+	p.cc.nodes = append(p.cc.nodes, block.node)
+
+	head := ifTok
+	for {
+		p.pos++
+		branch := &ccBranch{head: head.start, body: head.end, block: block}
+		if head.kind != "ELSE" {
+			then, err := p.expect(head, "THEN")
+			if err != nil {
+				return nil, err
+			}
+			branch.cond = strings.Join(strings.Fields(p.cc.src[head.end:then.start]), " ")
+			branch.body = then.end
+		}
+		branch.node = &semantic.ConditionalBranch{Condition: branch.cond}
+		p.cc.position(branch.node, head)
+		block.node.Branches = append(block.node.Branches, branch.node)
+		block.branches = append(block.branches, branch)
+
+		stop := []string{"ELSIF", "ELSE", "END"}
+		if head.kind == "ELSE" {
+			stop = []string{"END"}
+		}
+		outer := p.branch
+		p.branch = branch
+		items, err := p.parseItems(stop)
+		p.branch = outer
+		if err != nil {
+			return nil, err
+		}
+		branch.items = items
+		if p.pos >= len(p.tokens) {
+			return nil, newDiagnostic(semantic.CodeConditional, "$IF without $END", ifTok.line, ifTok.col)
+		}
+		head = p.tokens[p.pos]
+		if !isStop(stop, head.kind) {
+			return nil, newDiagnostic(semantic.CodeConditional,
+				fmt.Sprintf("unexpected $%s", head.kind), head.line, head.col)
+		}
+		branch.end = head.start
+		p.cc.setRange(branch.node, branch.head, strings.TrimRight(p.cc.src[:branch.end], " \t\r\n"))
+		if head.kind == "END" {
+			p.pos++
+			block.end = head.end
+			p.cc.setRange(block.node, block.start, p.cc.src[:block.end])
+			return block, nil
+		}
+	}
+}
+
+func (cc *conditionalSource) position(node semantic.SetPosition, tok ccToken) {
+	node.SetLine(tok.line)
+	node.SetColumn(tok.col + 1)
+	start := utf8.RuneCountInString(cc.src[:tok.start])
+	node.SetSpan(semantic.Span{Start: start, End: start + utf8.RuneCountInString(cc.src[tok.start:tok.end]) - 1})
+}
+
+// setRange sets the range of node from the byte offset start to the end of
+// prefix, which is the source up to where the node ends.
+func (cc *conditionalSource) setRange(node semantic.SetPosition, start int, prefix string) {
+	node.SetRange(semantic.Range{Start: positionAt(cc.src, start), End: positionAt(cc.src, len(prefix))})
+}
+
+// ccWriter builds the preprocessed text, which keeps the lines and, as
+// long as no inquiry directive is replaced, the offsets of the source.
+type ccWriter struct {
+	sb    strings.Builder
+	runes int
+}
+
+func (w *ccWriter) code(text string) {
+	w.sb.WriteString(text)
+	w.runes += utf8.RuneCountInString(text)
+}
+
+func (w *ccWriter) blank(text string) {
+	w.code(blankLine(text))
+}
+
+// render resolves the directives of the source with choose picking the
+// branch of each block, -1 for none. It returns the text along with the
+// rune ranges of the rendered branches.
+func (cc *conditionalSource) render(choose func(*ccBlock) int) (string, map[*ccBranch]semantic.Span, error) {
+	w := &ccWriter{}
+	spans := make(map[*ccBranch]semantic.Span)
+	err := cc.renderItems(w, 0, len(cc.src), cc.items, choose, spans)
+	return w.sb.String(), spans, err
+}
+
+func (cc *conditionalSource) renderItems(w *ccWriter, pos, end int, items []ccItem,
+	choose func(*ccBlock) int, spans map[*ccBranch]semantic.Span) (err error) {
+	for _, item := range items {
+		start, stop := item.bounds()
+		w.code(cc.src[pos:start])
+		switch it := item.(type) {
+		case *ccInquiry:
+			w.code(ccLiteral(cc.inquiry(it.name, it.start, it.line)))
+		case *ccError:
+			w.blank(cc.src[it.start:it.end])
+			msg := cc.eval(it.msg, it.start, it.line)
+			err = errors.Join(err, newDiagnostic(semantic.CodeConditional,
+				fmt.Sprintf("$ERROR: %v", msg), it.line, it.col))
+		case *ccBlock:
+			chosen := choose(it)
+			at := it.start
+			for i, branch := range it.branches {
+				w.blank(cc.src[at:branch.body])
+				if i == chosen {
+					from := w.runes
+					err = errors.Join(err, cc.renderItems(w, branch.body, branch.end, branch.items, choose, spans))
+					spans[branch] = semantic.Span{Start: from, End: w.runes}
+				} else {
+					w.blank(cc.src[branch.body:branch.end])
+				}
+				at = branch.end
+			}
+			w.blank(cc.src[at:it.end])
+		}
+		pos = stop
+	}
+	w.code(cc.src[pos:end])
+	return err
+}
+
+// parseBranches parses a variant of the source for every branch that is not
+// selected and records the nodes found in the branch.
+func (cc *conditionalSource) parseBranches() (err error) {
+	for _, block := range cc.blocks {
+		for i, branch := range block.branches {
+			if i == block.chosen {
+				continue
+			}
+			// select the branch along with the branches enclosing it
+			path := map[*ccBlock]int{block: i}
+			for outer := block.parent; outer != nil; outer = outer.block.parent {
+				for k, b := range outer.block.branches {
+					if b == outer {
+						path[outer.block] = k
+					}
+				}
+			}
+			text, spans, _ := cc.render(func(b *ccBlock) int {
+				if k, ok := path[b]; ok {
+					return k
+				}
+				return b.chosen
+			})
+			script, e := ParseScript(text)
+			if e != nil {
+				err = errors.Join(err, e)
+			}
+			if script != nil {
+				branch.node.Nodes = collectNodes(script, spans[branch])
+			}
+		}
+	}
+	return err
+}
+
+// collectNodes returns the outermost nodes of root lying within span.
+func collectNodes(root semantic.AstNode, span semantic.Span) []semantic.AstNode {
+	var nodes []semantic.AstNode
+	for _, child := range semantic.GetChildren(root) {
+		if n, ok := child.(semantic.Node); ok {
+			s := n.Span()
+			if s != (semantic.Span{}) && s.Start >= span.Start && s.End < span.End {
+				nodes = append(nodes, child)
+				continue
+			}
+		}
+		nodes = append(nodes, collectNodes(child, span)...)
+	}
+	return nodes
+}
+
+func (cc *conditionalSource) condition(branch *ccBranch) bool {
+	line := 1 + strings.Count(cc.src[:branch.head], "\n")
+	v, ok := cc.eval(branch.cond, branch.head, line).(bool)
+	return ok && v
+}
+
+// eval evaluates a static expression; NULL and values it cannot know, such
+// as constants of user packages, evaluate to nil.
+func (cc *conditionalSource) eval(expr string, offset, line int) any {
+	e := &ccEvaluator{cc: cc, tokens: ccExprTokens.FindAllString(expr, -1), offset: offset, line: line}
+	return e.or()
+}
+
+var ccExprTokens = regexp.MustCompile(`'(?:[^']|'')*'|<>|!=|<=|>=|\|\||[=<>(),]|\$\$[\w$#]+|[\w$#.]+`)
+
+type ccEvaluator struct {
+	cc     *conditionalSource
+	tokens []string
+	pos    int
+	offset int
+	line   int
+}
+
+func (e *ccEvaluator) peek() string {
+	if e.pos < len(e.tokens) {
+		return strings.ToUpper(e.tokens[e.pos])
+	}
+	return ""
+}
+
+func (e *ccEvaluator) next() string {
+	tok := e.peek()
+	e.pos++
+	return tok
+}
+
+func (e *ccEvaluator) or() any {
+	v := e.and()
+	for e.peek() == "OR" {
+		e.next()
+		r := e.and()
+		switch {
+		case v == true || r == true:
+			v = true
+		case v == nil || r == nil:
+			v = nil
+		default:
+			v = false
+		}
+	}
+	return v
+}
+
+func (e *ccEvaluator) and() any {
+	v := e.not()
+	for e.peek() == "AND" {
+		e.next()
+		r := e.not()
+		switch {
+		case v == false || r == false:
+			v = false
+		case v == nil || r == nil:
+			v = nil
+		default:
+			v = true
+		}
+	}
+	return v
+}
+
+func (e *ccEvaluator) not() any {
+	if e.peek() == "NOT" {
+		e.next()
+		if v, ok := e.not().(bool); ok {
+			return !v
+		}
+		return nil
+	}
+	return e.compare()
+}
+
+func (e *ccEvaluator) compare() any {
+	v := e.concat()
+	switch op := e.peek(); op {
+	case "IS":
+		e.next()
+		negate := e.peek() == "NOT"
+		if negate {
+			e.next()
+		}
+		e.next() // NULL
+		return (v == nil) != negate
+	case "=", "<>", "!=", "<", ">", "<=", ">=":
+		e.next()
+		r := e.concat()
+		if v == nil || r == nil {
+			return nil
+		}
+		var c int
+		switch l := v.(type) {
+		case int:
+			n, ok := r.(int)
+			if !ok {
+				return nil
+			}
+			c = l - n
+		case string:
+			s, ok := r.(string)
+			if !ok {
+				return nil
+			}
+			c = strings.Compare(l, s)
+		case bool:
+			b, ok := r.(bool)
+			if !ok || op != "=" && op != "<>" && op != "!=" {
+				return nil
+			}
+			if l != b {
+				c = 1
+			}
+		}
+		switch op {
+		case "=":
+			return c == 0
+		case "<>", "!=":
+			return c != 0
+		case "<":
+			return c < 0
+		case ">":
+			return c > 0
+		case "<=":
+			return c <= 0
+		default:
+			return c >= 0
+		}
+	}
+	return v
+}
+
+func (e *ccEvaluator) concat() any {
+	v := e.primary()
+	for e.peek() == "||" {
+		e.next()
+		r := e.primary()
+		v = ccText(v) + ccText(r)
+	}
+	return v
+}
+
+func (e *ccEvaluator) primary() any {
+	tok := e.next()
+	switch {
+	case tok == "(":
+		v := e.or()
+		e.next() // )
+		return v
+	case tok == "TRUE":
+		return true
+	case tok == "FALSE":
+		return false
+	case tok == "NULL" || tok == "":
+		return nil
+	case strings.HasPrefix(tok, "'"):
+		raw := e.tokens[e.pos-1]
+		return strings.ReplaceAll(raw[1:len(raw)-1], "''", "'")
+	case strings.HasPrefix(tok, "$$"):
+		return e.cc.inquiry(tok[2:], e.offset, e.line)
+	}
+	if n, err := strconv.Atoi(tok); err == nil {
+		return n
+	}
+	return e.cc.constant(tok)
+}
+
+func ccText(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case bool:
+		return strings.ToUpper(strconv.FormatBool(v))
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// ccLiteral returns the PL/SQL literal of an inquiry directive value.
+func ccLiteral(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	default:
+		return ccText(v)
+	}
+}
+
+// constant returns the value of a static constant of DBMS_DB_VERSION.
+func (cc *conditionalSource) constant(name string) any {
+	pkg, field, ok := strings.Cut(name, ".")
+	if !ok || pkg != "DBMS_DB_VERSION" {
+		return nil
+	}
+	switch field {
+	case "VERSION":
+		return cc.opts.Version
+	case "RELEASE":
+		return cc.opts.Release
+	}
+	if !strings.HasPrefix(field, "VER_LE_") {
+		return nil
+	}
+	parts := strings.Split(strings.TrimPrefix(field, "VER_LE_"), "_")
+	version, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil
+	}
+	if len(parts) == 1 || cc.opts.Version != version {
+		return cc.opts.Version <= version
+	}
+	release, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil
+	}
+	return cc.opts.Release <= release
+}
+
+var ccUnitPattern = regexp.MustCompile(`(?is)\bCREATE\s+(?:OR\s+REPLACE\s+)?(?:(?:NON)?EDITIONABLE\s+)?` +
+	`(PACKAGE\s+BODY|TYPE\s+BODY|PACKAGE|PROCEDURE|FUNCTION|TRIGGER|TYPE)\s+((?:"[^"]+"|[\w$#]+)(?:\s*\.\s*(?:"[^"]+"|[\w$#]+))?)`)
+
+// inquiry returns the value of the $$name inquiry directive at offset.
+func (cc *conditionalSource) inquiry(name string, offset, line int) any {
+	name = strings.ToUpper(name)
+	switch name {
+	case "PLSQL_LINE":
+		return line
+	case "PLSQL_UNIT", "PLSQL_UNIT_OWNER", "PLSQL_UNIT_TYPE":
+		matches := ccUnitPattern.FindAllStringSubmatch(cc.src[:offset], -1)
+		if len(matches) == 0 {
+			if name == "PLSQL_UNIT_TYPE" {
+				return "ANONYMOUS BLOCK"
+			}
+			return nil
+		}
+		m := matches[len(matches)-1]
+		owner, unit, ok := strings.Cut(m[2], ".")
+		if !ok {
+			owner, unit = "", owner
+		}
+		switch name {
+		case "PLSQL_UNIT":
+			return ccIdentifier(unit)
+		case "PLSQL_UNIT_OWNER":
+			if owner == "" {
+				return nil
+			}
+			return ccIdentifier(owner)
+		default:
+			return strings.ToUpper(strings.Join(strings.Fields(m[1]), " "))
+		}
+	}
+	if v, ok := cc.flags[name]; ok {
+		return v
+	}
+	switch name {
+	case "PLSQL_CODE_TYPE":
+		return "INTERPRETED"
+	case "PLSQL_OPTIMIZE_LEVEL":
+		return 2
+	case "PLSQL_DEBUG":
+		return false
+	case "NLS_LENGTH_SEMANTICS":
+		return "BYTE"
+	case "PLSQL_WARNINGS":
+		return "DISABLE:ALL"
+	case "PLSCOPE_SETTINGS":
+		return "IDENTIFIERS:NONE"
+	}
+	return nil
+}
+
+func ccIdentifier(name string) string {
+	name = strings.TrimSpace(name)
+	if strings.HasPrefix(name, `"`) {
+		return strings.Trim(name, `"`)
+	}
+	return strings.ToUpper(name)
+}
@@ -1,30 +1,26 @@
 package parser
 
-type ErrorKind int
+import (
+	"fmt"
 
-func (k ErrorKind) String() string {
-	switch k {
-	case ErrSyntax:
-		return "syntax"
-	case ErrSemantic:
-		return "semantic"
-	default:
-		return "unknown"
-	}
-}
+	"procinspect/pkg/semantic"
+)
 
-type ParseError struct {
-	Kind   ErrorKind
-	Line   int
-	Column int
-	Msg    string
+// newDiagnostic returns an error diagnostic for the line and the 0-based
+// column ANTLR reports.
+func newDiagnostic(code, msg string, line, column int) semantic.Diagnostic {
+	start := semantic.Position{Line: line, Column: column + 1}
+	return semantic.Diagnostic{
+		Start:    start,
+		End:      start,
+		Severity: semantic.SeverityError,
+		Code:     code,
+		Message:  msg,
+	}
 }
 
-func (p ParseError) Error() string {
-	return p.Msg
+// timeoutDiagnostic returns the diagnostic for parsing stopped with err at
+// line and the 0-based column.
+func timeoutDiagnostic(err error, line, column int) semantic.Diagnostic {
+	return newDiagnostic(semantic.CodeTimeout, fmt.Sprintf("parsing stopped: %v", err), line, column)
 }
-
-const (
-	ErrSyntax   ErrorKind = iota
-	ErrSemantic ErrorKind = iota
-)
@@ -26,12 +26,8 @@ func newExprVisitor(visitor *plsqlVisitor) *exprVisitor {
 }
 
 func (v *exprVisitor) ReportError(msg string, line, column int) {
-	v.stmtVisitor.errors = append(v.stmtVisitor.errors, ParseError{
-		Kind:   ErrSemantic,
-		Line:   line + v.StartLine,
-		Column: column,
-		Msg:    msg,
-	})
+	v.stmtVisitor.errors = append(v.stmtVisitor.errors,
+		newDiagnostic(semantic.CodeSemantic, msg, line+v.StartLine, column))
 }
 
 func (v *exprVisitor) Visit(tree antlr.ParseTree) interface{} {
@@ -93,7 +89,7 @@ func (v *exprVisitor) VisitChildren(node antlr.RuleNode) interface{} {
 			nodes = append(nodes, v.VisitNumeric_function(c))
 		case *plsql.General_element_partContext:
 			c := child.(*plsql.General_element_partContext)
-			nodes = append(nodes, v.VisitGeneral_element_part(c))
+			nodes = append(nodes, v.recognize(v.VisitGeneral_element_part(c)))
 		case *plsql.ConstantContext:
 			c := child.(*plsql.ConstantContext)
 			nodes = append(nodes, v.VisitConstant(c))
@@ -102,7 +98,7 @@ func (v *exprVisitor) VisitChildren(node antlr.RuleNode) interface{} {
 			nodes = append(nodes, v.VisitQuoted_string(c))
 		case *plsql.Variable_nameContext:
 			c := child.(*plsql.Variable_nameContext)
-			nodes = append(nodes, v.VisitVariable_name(c))
+			nodes = append(nodes, v.recognize(v.VisitVariable_name(c)))
 		case *plsql.NumericContext:
 			c := child.(*plsql.NumericContext)
 			nodes = append(nodes, v.VisitNumeric(c))
@@ -155,6 +151,12 @@ func (v *exprVisitor) VisitExpressions(ctx *plsql.ExpressionsContext) interface{
 }
 
 func (v *exprVisitor) VisitOther_function(ctx *plsql.Other_functionContext) interface{} {
+	switch ctx.GetStart().GetTokenType() {
+	case plsql.PlSqlParserXMLAGG:
+		return v.xmlAgg(ctx)
+	case plsql.PlSqlParserXMLELEMENT:
+		return v.xmlElement(ctx)
+	}
 	if ctx.Over_clause_keyword() != nil {
 		name := ctx.Over_clause_keyword().GetText()
 		expr := &semantic.FunctionCallExpression{Name: &semantic.NameExpression{Name: name}}
@@ -254,7 +256,7 @@ func (v *exprVisitor) VisitOther_function(ctx *plsql.Other_functionContext) inte
 		} else if ctx.PERCENT_ISOPEN() != nil {
 			ca.Attr = "ISOPEN"
 		}
-		return ca
+		return recognize(ca)
 	}
 
 	if ctx.TO_NUMBER() != nil {
@@ -612,6 +614,46 @@ func (v *exprVisitor) VisitUnary_expression(ctx *plsql.Unary_expressionContext)
 	return v.VisitChildren(ctx)
 }
 
+func (v *exprVisitor) VisitModel_expression(ctx *plsql.Model_expressionContext) interface{} {
+	if ctx.Model_expression_element() == nil {
+		return ctx.Unary_expression().Accept(v)
+	}
+
+	expr := newAstNode[semantic.ModelCellExpression](ctx)
+	var ok bool
+	expr.Measure, ok = ctx.Unary_expression().Accept(v).(semantic.Expr)
+	if !ok {
+		v.ReportError(fmt.Sprintf("unsupported expression %T", ctx.Unary_expression()),
+			ctx.Unary_expression().GetStart().GetLine(),
+			ctx.Unary_expression().GetStart().GetColumn())
+		return expr
+	}
+	for _, child := range ctx.Model_expression_element().GetChildren() {
+		switch child.(type) {
+		case antlr.TerminalNode:
+			if child.(antlr.TerminalNode).GetSymbol().GetTokenType() == plsql.PlSqlParserANY {
+				expr.Dimensions = append(expr.Dimensions, &semantic.NameExpression{Name: "ANY"})
+			}
+		case *plsql.ExpressionContext:
+			node := child.(*plsql.ExpressionContext)
+			dim, ok := v.VisitExpression(node).(semantic.Expr)
+			if !ok {
+				v.ReportError(fmt.Sprintf("unsupported expression %T", node),
+					node.GetStart().GetLine(),
+					node.GetStart().GetColumn())
+				continue
+			}
+			expr.Dimensions = append(expr.Dimensions, dim)
+		default:
+			node := child.(antlr.ParserRuleContext)
+			v.ReportError(fmt.Sprintf("unsupported expression %T", node),
+				node.GetStart().GetLine(),
+				node.GetStart().GetColumn())
+		}
+	}
+	return expr
+}
+
 func (v *exprVisitor) VisitQuantified_expression(ctx *plsql.Quantified_expressionContext) interface{} {
 	if ctx.EXISTS() != nil {
 		expr := newAstNode[semantic.ExistsExpression](ctx)
@@ -680,8 +722,7 @@ func (v *exprVisitor) VisitAtom(ctx *plsql.AtomContext) interface{} {
 }
 
 func (v *exprVisitor) VisitTable_element(ctx *plsql.Table_elementContext) interface{} {
-	text := ctx.GetText()
-	return v.parseDotExpr(text)
+	return v.parseDotExpr(ctx)
 }
 
 func (v *exprVisitor) VisitString_function(ctx *plsql.String_functionContext) interface{} {
@@ -961,7 +1002,7 @@ func (v *exprVisitor) VisitBind_variable(ctx *plsql.Bind_variableContext) interf
 		}
 		expr = dotExpr
 	}
-	return expr
+	return recognize(expr)
 }
 
 func (v *exprVisitor) VisitGeneral_element_part(ctx *plsql.General_element_partContext) interface{} {
@@ -1010,6 +1051,15 @@ func (v *exprVisitor) VisitGeneral_element_part(ctx *plsql.General_element_partC
 	}
 }
 
+// recognize applies recognize to the result of a visit when it is an
+// expression.
+func (v *exprVisitor) recognize(result interface{}) interface{} {
+	if expr, ok := result.(semantic.Expr); ok {
+		return recognize(expr)
+	}
+	return result
+}
+
 func (v *exprVisitor) VisitFunction_argument(ctx *plsql.Function_argumentContext) interface{} {
 	args := make([]semantic.Expr, 0)
 	if len(ctx.AllArgument()) > 0 {
@@ -1057,14 +1107,7 @@ func (v *exprVisitor) VisitConstant(ctx *plsql.ConstantContext) interface{} {
 }
 
 func (v *exprVisitor) VisitVariable_name(ctx *plsql.Variable_nameContext) interface{} {
-	parts := strings.Split(ctx.GetText(), ".")
-	if len(parts) == 1 {
-		name := &semantic.NameExpression{}
-		name.Name = ctx.GetText()
-		return name
-	}
-
-	return v.parseDotExpr(ctx.GetText())
+	return v.parseDotExpr(ctx)
 }
 
 func (v *exprVisitor) VisitNumeric(ctx *plsql.NumericContext) interface{} {
@@ -1078,7 +1121,7 @@ func (v *exprVisitor) VisitNumeric(ctx *plsql.NumericContext) interface{} {
 }
 
 func (v *exprVisitor) VisitRoutine_name(ctx *plsql.Routine_nameContext) interface{} {
-	return v.parseDotExpr(ctx.GetText())
+	return v.parseDotExpr(ctx)
 }
 
 func (v *exprVisitor) VisitSelect_list_elements(ctx *plsql.Select_list_elementsContext) interface{} {
@@ -1160,12 +1203,7 @@ func (v *exprVisitor) VisitDml_table_expression_clause(ctx *plsql.Dml_table_expr
 		)
 		return nil
 	} else if ctx.Json_table_clause() != nil {
-		v.ReportError(
-			fmt.Sprintf("unsupported expression %T", ctx.Json_table_clause()),
-			ctx.Json_table_clause().GetStart().GetLine(),
-			ctx.Json_table_clause().GetStart().GetColumn(),
-		)
-		return nil
+		return ctx.Json_table_clause().Accept(v)
 	} else if ctx.Select_statement() != nil {
 		stmt, ok := ctx.Select_statement().Accept(v.stmtVisitor).(semantic.Statement)
 		if !ok {
@@ -1223,7 +1261,7 @@ func (v *exprVisitor) VisitColumn_based_update_set_clause(ctx *plsql.Column_base
 	} else {
 		var ok bool
 		expr := &semantic.BinaryExpression{Operator: "="}
-		object := v.parseDotExpr(ctx.Column_name().GetText())
+		object := v.parseDotExpr(ctx.Column_name())
 		expr.Left, ok = object.(semantic.Expr)
 		if !ok {
 			v.ReportError(
@@ -1245,11 +1283,11 @@ func (v *exprVisitor) VisitColumn_based_update_set_clause(ctx *plsql.Column_base
 }
 
 func (v *exprVisitor) VisitSynonym_name(ctx *plsql.Synonym_nameContext) interface{} {
-	return v.parseDotExpr(ctx.GetText())
+	return v.parseDotExpr(ctx)
 }
 
 func (v *exprVisitor) VisitSchema_object_name(ctx *plsql.Schema_object_nameContext) interface{} {
-	return v.parseDotExpr(ctx.GetText())
+	return v.parseDotExpr(ctx)
 }
 
 func (v *exprVisitor) VisitParen_column_list(ctx *plsql.Paren_column_listContext) interface{} {
@@ -1259,8 +1297,7 @@ func (v *exprVisitor) VisitParen_column_list(ctx *plsql.Paren_column_listContext
 func (v *exprVisitor) VisitColumn_list(ctx *plsql.Column_listContext) interface{} {
 	exprs := make([]semantic.Expr, 0)
 	for _, col := range ctx.AllColumn_name() {
-		name := col.GetText()
-		expr, ok := v.parseDotExpr(name).(semantic.Expr)
+		expr, ok := v.parseDotExpr(col).(semantic.Expr)
 		if !ok {
 			v.ReportError(fmt.Sprintf("unsupported expression %T", col),
 				col.GetStart().GetLine(),
@@ -1297,7 +1334,7 @@ func (v *exprVisitor) VisitSelected_tableview(ctx *plsql.Selected_tableviewConte
 func (v *exprVisitor) VisitMerge_element(ctx *plsql.Merge_elementContext) interface{} {
 	expr := newAstNode[semantic.BinaryExpression](ctx)
 	ok := false
-	expr.Left, ok = v.parseDotExpr(ctx.Column_name().GetText()).(semantic.Expr)
+	expr.Left, ok = v.parseDotExpr(ctx.Column_name()).(semantic.Expr)
 	if !ok {
 		v.ReportError(fmt.Sprintf("unsupported expression %T", ctx.Column_name()),
 			ctx.Column_name().GetStart().GetLine(),
@@ -1356,20 +1393,36 @@ func (v *exprVisitor) VisitFactoring_element(ctx *plsql.Factoring_elementContext
 	return expr
 }
 
-func (v *exprVisitor) parseDotExpr(text string) semantic.Expr {
-	parts := strings.Split(text, ".")
+// parseDotExpr splits the dotted name of ctx into a chain of dot
+// expressions, each part positioned at its place in the source. Dots within
+// quoted parts do not separate.
+func (v *exprVisitor) parseDotExpr(ctx antlr.ParserRuleContext) semantic.Expr {
+	text := ctx.GetText()
+	parts := semantic.SplitQualifiedName(text)
 	if len(parts) == 1 {
-		return &semantic.NameExpression{
-			Name: text,
-		}
+		name := newAstNode[semantic.NameExpression](ctx)
+		name.Name = text
+		return name
 	}
 
+	src := sourceText(ctx)
+	cursor := 0
 	var dotExpr semantic.Expr
 	for _, part := range parts {
-		dotExpr = &semantic.DotExpression{
-			Name:   &semantic.NameExpression{Name: part},
-			Parent: dotExpr,
-		}
+		from := cursor
+		if i := strings.Index(src[cursor:], part); i >= 0 {
+			from = cursor + i
+		}
+		cursor = from + len(part)
+		name := &semantic.NameExpression{Name: part}
+		setRange(name, subRange(ctx.GetStart(), src, from, cursor))
+		dot := &semantic.DotExpression{Name: name, Parent: dotExpr}
+		r := name.Range()
+		if dotExpr != nil {
+			r.Start = dotExpr.Range().Start
+		}
+		setRange(dot, r)
+		dotExpr = dot
 	}
 	return dotExpr
 }
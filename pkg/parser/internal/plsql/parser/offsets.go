@@ -0,0 +1,47 @@
+package parser
+
+import (
+	"sort"
+	"unicode/utf8"
+
+	"github.com/antlr4-go/antlr/v4"
+)
+
+// OffsetMap converts the character indexes ANTLR uses into byte offsets.
+type OffsetMap struct {
+	// runes holds the indexes of the multibyte characters, extra the
+	// number of bytes they add up to and including each of them.
+	runes []int
+	extra []int
+}
+
+func NewOffsetMap(text string) *OffsetMap {
+	m := &OffsetMap{}
+	index, extra := 0, 0
+	for _, r := range text {
+		if n := utf8.RuneLen(r); n > 1 {
+			extra += n - 1
+			m.runes = append(m.runes, index)
+			m.extra = append(m.extra, extra)
+		}
+		index++
+	}
+	return m
+}
+
+// NewStreamOffsetMap returns the offset map of the text of input.
+func NewStreamOffsetMap(input antlr.CharStream) *OffsetMap {
+	if input == nil || input.Size() == 0 {
+		return &OffsetMap{}
+	}
+	return NewOffsetMap(input.GetText(0, input.Size()-1))
+}
+
+// ByteOffset returns the byte offset of the character at index.
+func (m *OffsetMap) ByteOffset(index int) int {
+	i := sort.SearchInts(m.runes, index)
+	if i == 0 {
+		return index
+	}
+	return index + m.extra[i-1]
+}
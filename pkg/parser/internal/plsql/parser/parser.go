@@ -1,72 +1,329 @@
 package parser
 
 import (
+	"context"
 	"errors"
-	"fmt"
+	"sync"
+	"unicode/utf8"
 
 	"github.com/antlr4-go/antlr/v4"
+
+	"procinspect/pkg/semantic"
 )
 
 type (
-	SyntaxError struct {
-		Line    int
-		Column  int
-		Stack   []string
-		Message string
-		Origin  string
-	}
 	MyErrorListener struct {
 		antlr.DefaultErrorListener
-		err []error
+		diagnostics []semantic.Diagnostic
+		offsets     *OffsetMap
+		// failed holds the rule contexts that were being parsed when an
+		// error was reported.
+		failed map[antlr.Tree]bool
 	}
 
+	// SqlParser parses a script in two stages: the faster SLL prediction
+	// mode is tried first, bailing out on the first error, and the script
+	// is parsed again in full LL mode only when that fails.
 	SqlParser struct {
 		*PlSqlParser
+		// LL skips the SLL stage.
+		LL bool
+
+		lexer    *PlSqlLexer
+		stream   *cancelStream
 		listener *MyErrorListener
 	}
+
+	// cancelStream is the token stream of the parser. Once its context is
+	// done, it reads as the end of the input, so that the parser stops
+	// predicting and finishes the rules it is in.
+	cancelStream struct {
+		*antlr.CommonTokenStream
+		ctx   context.Context
+		calls int
+		err   error
+		// stop is the token the stream was at when the context was done,
+		// eof the end of input it reads as from then on.
+		stop, eof antlr.Token
+	}
+
+	// bailErrorStrategy ends the SLL stage at the first syntax error. It
+	// skips the rest of the input rather than recovering, so that the
+	// parse finishes quickly and can be done again in LL mode.
+	bailErrorStrategy struct {
+		*antlr.DefaultErrorStrategy
+		failed bool
+	}
 )
 
-func NewParser(source string) *SqlParser {
-	input := antlr.NewInputStream(source)
-	lexer := NewPlSqlLexer(input)
-	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
+// parserPool keeps lexers and parsers for reuse. The DFA caches are shared
+// by all of them, so they stay warm from one script to the next.
+var parserPool = sync.Pool{
+	New: func() any {
+		return newSqlParser()
+	},
+}
+
+func newSqlParser() *SqlParser {
+	lexer := NewPlSqlLexer(nil)
+	listener := NewMyErrorListener()
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(listener)
+	stream := &cancelStream{CommonTokenStream: antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)}
 	parser := NewPlSqlParser(stream)
 	parser.RemoveErrorListeners()
-	listener := NewMyErrorListener()
 	parser.AddErrorListener(listener)
 	return &SqlParser{
 		PlSqlParser: parser,
+		lexer:       lexer,
+		stream:      stream,
 		listener:    listener,
 	}
 }
 
+// NewParser returns a parser for source, taken from the pool. Call Release
+// when done with it.
+func NewParser(source string) *SqlParser {
+	p := parserPool.Get().(*SqlParser)
+	p.lexer.SetInputStream(antlr.NewInputStream(source))
+	p.lexer.lastToken = nil
+	p.stream.SetTokenSource(p.lexer)
+	p.stream.reset(nil)
+	p.SetInputStream(p.stream)
+	p.listener.diagnostics, p.listener.offsets = nil, nil
+	p.listener.failed = make(map[antlr.Tree]bool)
+	p.LL = false
+	p.setVersion10(false)
+	p.setVersion12(false)
+	return p
+}
+
+// SetContext makes the parser stop when ctx is done. The rule being parsed
+// then returns what it has so far, without reporting syntax errors for the
+// rest of the input.
+func (p *SqlParser) SetContext(ctx context.Context) {
+	p.stream.reset(ctx)
+}
+
+// Interrupted returns the error of the context and the token the parser
+// was at when it stopped, or nil when the parse was not interrupted.
+func (p *SqlParser) Interrupted() (antlr.Token, error) {
+	return p.stream.stop, p.stream.err
+}
+
+// SetVersion turns the syntax the grammar ties to Oracle releases on or off
+// for the release major, such as 11 for 11g.
+func (p *SqlParser) SetVersion(major int) {
+	p.setVersion10(major >= 10)
+	p.setVersion12(major >= 12)
+}
+
+// Tokens returns the tokens read so far, those on hidden channels included.
+func (p *SqlParser) Tokens() []antlr.Token {
+	return p.stream.GetAllTokens()
+}
+
+// Release puts p back into the pool. The trees it returned stay usable.
+func (p *SqlParser) Release() {
+	parserPool.Put(p)
+}
+
+// Sql_script parses a whole script.
+func (p *SqlParser) Sql_script() ISql_scriptContext {
+	return parseTwoStage(p, p.PlSqlParser.Sql_script)
+}
+
+// Block parses a PL/SQL block.
+func (p *SqlParser) Block() IBlockContext {
+	return parseTwoStage(p, p.PlSqlParser.Block)
+}
+
+// parseTwoStage runs rule in SLL mode, which is correct whenever it
+// succeeds, and again in LL mode when it does not.
+func parseTwoStage[T antlr.ParserRuleContext](p *SqlParser, rule func() T) T {
+	if p.LL {
+		return rule()
+	}
+	bail := &bailErrorStrategy{DefaultErrorStrategy: antlr.NewDefaultErrorStrategy()}
+	p.GetInterpreter().SetPredictionMode(antlr.PredictionModeSLL)
+	p.SetErrorHandler(bail)
+	p.RemoveErrorListeners()
+	tree := rule()
+
+	p.GetInterpreter().SetPredictionMode(antlr.PredictionModeLL)
+	p.SetErrorHandler(antlr.NewDefaultErrorStrategy())
+	p.AddErrorListener(p.listener)
+	if !bail.failed || p.stream.err != nil {
+		return tree
+	}
+
+	// the tokens are buffered, so the lexer errors are not reported twice
+	p.SetError(nil)
+	p.stream.Seek(0)
+	p.SetTokenStream(p.stream)
+	return rule()
+}
+
+// checkInterval is the number of lookups between two checks of the
+// context, which are too slow to do for every token.
+const checkInterval = 1024
+
+func (s *cancelStream) reset(ctx context.Context) {
+	s.ctx, s.calls, s.err, s.stop, s.eof = ctx, 0, nil, nil, nil
+}
+
+func (s *cancelStream) LA(i int) int {
+	if s.done() {
+		return antlr.TokenEOF
+	}
+	return s.CommonTokenStream.LA(i)
+}
+
+func (s *cancelStream) LT(k int) antlr.Token {
+	if s.done() {
+		return s.eof
+	}
+	return s.CommonTokenStream.LT(k)
+}
+
+// done reports whether the context of the stream is done.
+func (s *cancelStream) done() bool {
+	if s.err != nil {
+		return true
+	}
+	if s.ctx == nil {
+		return false
+	}
+	if s.calls++; s.calls%checkInterval != 0 {
+		return false
+	}
+	err := s.ctx.Err()
+	if err == nil {
+		return false
+	}
+	s.stop = s.CommonTokenStream.LT(1)
+	s.eof = antlr.CommonTokenFactoryDEFAULT.Create(s.stop.GetSource(), antlr.TokenEOF, "<EOF>",
+		antlr.TokenDefaultChannel, s.stop.GetStart(), s.stop.GetStart()-1, s.stop.GetLine(), s.stop.GetColumn())
+	s.eof.SetTokenIndex(s.stop.GetTokenIndex())
+	s.err = err
+	return true
+}
+
 func (p *SqlParser) Error() error {
 	return p.listener.Error()
 }
 
+// Diagnostics returns the errors reported by the lexer and the parser, in
+// order.
+func (p *SqlParser) Diagnostics() []semantic.Diagnostic {
+	return p.listener.diagnostics
+}
+
+// FailedRules returns the rule contexts that were being parsed when a
+// syntax error was reported. A rule that fails before it matches a token
+// leaves no error node in the tree, so this is the only trace of it.
+func (p *SqlParser) FailedRules() map[antlr.Tree]bool {
+	return p.listener.failed
+}
+
+func (s *bailErrorStrategy) ReportError(antlr.Parser, antlr.RecognitionException) {
+	s.failed = true
+}
+
+func (s *bailErrorStrategy) Recover(recognizer antlr.Parser, _ antlr.RecognitionException) {
+	s.bail(recognizer)
+}
+
+func (s *bailErrorStrategy) RecoverInline(recognizer antlr.Parser) antlr.Token {
+	s.bail(recognizer)
+	return recognizer.GetCurrentToken()
+}
+
+func (s *bailErrorStrategy) Sync(antlr.Parser) {}
+
+func (s *bailErrorStrategy) bail(recognizer antlr.Parser) {
+	s.failed = true
+	input := recognizer.GetTokenStream()
+	for input.LA(1) != antlr.TokenEOF {
+		input.Consume()
+	}
+}
+
 func NewMyErrorListener() *MyErrorListener {
-	return &MyErrorListener{err: nil}
+	return &MyErrorListener{failed: make(map[antlr.Tree]bool)}
 }
 
 func (el *MyErrorListener) SyntaxError(recognizer antlr.Recognizer, offendingSymbol interface{}, line, column int, msg string, e antlr.RecognitionException) {
-	p := recognizer.(antlr.Parser)
-	stack := p.GetRuleInvocationStack(p.GetParserRuleContext())
-	el.err = append(el.err, SyntaxError{
-		Line:    line,
-		Column:  column,
-		Stack:   stack,
-		Message: msg,
-		Origin:  fmt.Sprintf("%v", offendingSymbol),
-	})
+	if p, ok := recognizer.(antlr.Parser); ok {
+		if s, ok := p.GetTokenStream().(*cancelStream); ok && s.err != nil {
+			// the input did not end here, the parse was interrupted
+			return
+		}
+	}
+	d := semantic.Diagnostic{
+		Start:    semantic.Position{Line: line, Column: column + 1},
+		Severity: semantic.SeverityError,
+		Code:     semantic.CodeSyntax,
+		Message:  msg,
+	}
+	d.End = d.Start
+	if token, ok := offendingSymbol.(antlr.Token); ok {
+		d.Start.Offset = el.byteOffset(token.GetInputStream(), token.GetStart())
+		d.End.Offset = d.Start.Offset
+		if token.GetTokenType() != antlr.TokenEOF {
+			d.End.Column += utf8.RuneCountInString(token.GetText())
+			d.End.Offset = el.byteOffset(token.GetInputStream(), token.GetStop()+1)
+		}
+	}
+	if l, ok := recognizer.(*PlSqlLexer); ok {
+		d.Start.Offset = el.byteOffset(l.GetInputStream(), l.TokenStartCharIndex)
+		d.End.Offset = d.Start.Offset
+	}
+	if p, ok := recognizer.(antlr.Parser); ok {
+		d.Expected = expectedTokens(p)
+		if ctx := p.GetParserRuleContext(); ctx != nil {
+			el.failed[ctx] = true
+		}
+	}
+	el.diagnostics = append(el.diagnostics, d)
 }
 
-func (el *MyErrorListener) Error() error {
-	var errs error
-	errs = errors.Join(el.err...)
-	// }
-	return errs
+func (el *MyErrorListener) byteOffset(input antlr.CharStream, index int) int {
+	if el.offsets == nil {
+		el.offsets = NewStreamOffsetMap(input)
+	}
+	return el.offsets.ByteOffset(index)
 }
 
-func (e SyntaxError) Error() string {
-	return fmt.Sprintf("syntax error at line %d, column %d: %v", e.Line, e.Column, e.Stack)
+// expectedTokens returns the display names of the tokens p accepts in its
+// current state.
+func expectedTokens(p antlr.Parser) (names []string) {
+	defer func() {
+		// the state may be left inconsistent by the error being reported
+		if recover() != nil {
+			names = nil
+		}
+	}()
+	literals, symbols := p.GetLiteralNames(), p.GetSymbolicNames()
+	for _, interval := range p.GetExpectedTokens().GetIntervals() {
+		for t := interval.Start; t < interval.Stop; t++ {
+			switch {
+			case t == antlr.TokenEOF:
+				names = append(names, "<EOF>")
+			case t < len(literals) && literals[t] != "":
+				names = append(names, literals[t])
+			case t < len(symbols) && symbols[t] != "":
+				names = append(names, symbols[t])
+			}
+		}
+	}
+	return names
+}
+
+func (el *MyErrorListener) Error() error {
+	errs := make([]error, 0, len(el.diagnostics))
+	for _, d := range el.diagnostics {
+		errs = append(errs, d)
+	}
+	return errors.Join(errs...)
 }
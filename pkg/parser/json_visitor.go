@@ -0,0 +1,333 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+
+	plsql "procinspect/pkg/parser/internal/plsql/parser"
+	"procinspect/pkg/semantic"
+)
+
+// jsonClauses collects the parts of a SQL/JSON function call. The grammar
+// spreads them over inline tokens and clause rules alike, so the children
+// are read in order, descending into the clause rules.
+type jsonClauses struct {
+	// rules are the operands: expressions, column names and the like.
+	rules      []antlr.ParserRuleContext
+	path       string
+	formatJson bool
+	returning  string
+	wrapper    string
+	strict     bool
+	uniqueKeys bool
+	wildcard   bool
+
+	onError, onEmpty, onMismatch, onNull *semantic.JsonOnClause
+
+	entries []*semantic.JsonObjectEntry
+	columns []*semantic.JsonTableColumn
+	orderBy *semantic.OrderByClause
+
+	// action holds the words of an ON clause until its ON is read, and def
+	// the value of ON ... DEFAULT.
+	action []string
+	def    semantic.Expr
+}
+
+// jsonActions are the words of the behaviors of ON clauses.
+var jsonActions = map[int]bool{
+	plsql.PlSqlParserERROR:   true,
+	plsql.PlSqlParserNULL_:   true,
+	plsql.PlSqlParserEMPTY:   true,
+	plsql.PlSqlParserABSENT:  true,
+	plsql.PlSqlParserTRUE:    true,
+	plsql.PlSqlParserFALSE:   true,
+	plsql.PlSqlParserARRAY:   true,
+	plsql.PlSqlParserOBJECT:  true,
+	plsql.PlSqlParserDEFAULT: true,
+	plsql.PlSqlParserIGNORE:  true,
+}
+
+// jsonReturningEnd are the tokens ending an inline RETURNING clause.
+var jsonReturningEnd = map[int]bool{
+	plsql.PlSqlParserSTRICT:      true,
+	plsql.PlSqlParserWITH:        true,
+	plsql.PlSqlParserPRETTY:      true,
+	plsql.PlSqlParserASCII:       true,
+	plsql.PlSqlParserRIGHT_PAREN: true,
+}
+
+func (v *exprVisitor) jsonClauses(tree antlr.Tree) *jsonClauses {
+	c := &jsonClauses{}
+	v.readJsonClauses(c, tree)
+	return c
+}
+
+func (v *exprVisitor) readJsonClauses(c *jsonClauses, tree antlr.Tree) {
+	children := tree.GetChildren()
+	for i := 0; i < len(children); i++ {
+		switch child := children[i].(type) {
+		case antlr.TerminalNode:
+			tt := child.GetSymbol().GetTokenType()
+			switch {
+			case tt == plsql.PlSqlParserCHAR_STRING:
+				if c.path == "" {
+					c.path = unquote(child.GetText())
+				}
+			case tt == plsql.PlSqlParserFORMAT:
+				c.formatJson = true
+			case tt == plsql.PlSqlParserSTRICT:
+				c.strict = true
+			case tt == plsql.PlSqlParserUNIQUE:
+				c.uniqueKeys = true
+			case tt == plsql.PlSqlParserASTERISK:
+				c.wildcard = true
+			case tt == plsql.PlSqlParserRETURNING:
+				j := i + 1
+				for j < len(children) && !jsonReturningEnd[tokenType(children[j])] {
+					j++
+				}
+				if j > i+1 {
+					c.returning = treeText(children[i+1], children[j-1])
+				}
+				i = j - 1
+			case tt == plsql.PlSqlParserON && i+1 < len(children):
+				i++
+				v.finishJsonOn(c, tokenType(children[i]))
+			case jsonActions[tt]:
+				c.action = append(c.action, strings.ToUpper(child.GetText()))
+			}
+		case *plsql.Json_object_entryContext:
+			c.entries = append(c.entries, v.jsonObjectEntry(child))
+		case *plsql.Json_column_clauseContext:
+			c.columns = append(c.columns, v.jsonColumns(child)...)
+		case *plsql.Json_query_wrapper_clauseContext:
+			c.wrapper = treeText(child, child)
+		case *plsql.Json_query_return_typeContext, *plsql.Json_value_return_typeContext:
+			c.returning = treeText(child, child)
+		case *plsql.Json_object_contentContext,
+			*plsql.Json_return_clauseContext,
+			*plsql.Json_query_returning_clauseContext,
+			*plsql.Json_value_return_clauseContext,
+			*plsql.Json_on_null_clauseContext,
+			*plsql.Json_query_on_error_clauseContext,
+			*plsql.Json_query_on_empty_clauseContext,
+			*plsql.Json_value_on_mismatch_clauseContext:
+			v.readJsonClauses(c, child)
+		case *plsql.Order_by_clauseContext:
+			c.orderBy, _ = child.Accept(v).(*semantic.OrderByClause)
+		case antlr.ParserRuleContext:
+			if len(c.action) > 0 && c.action[len(c.action)-1] == "DEFAULT" {
+				c.def = v.acceptExpr(child)
+				continue
+			}
+			c.rules = append(c.rules, child)
+		}
+	}
+}
+
+// finishJsonOn ends the ON clause whose ON is followed by a token of type
+// target, such as ERROR in NULL ON ERROR.
+func (v *exprVisitor) finishJsonOn(c *jsonClauses, target int) {
+	clause := &semantic.JsonOnClause{Action: strings.Join(c.action, " "), Default: c.def}
+	c.action, c.def = nil, nil
+	switch target {
+	case plsql.PlSqlParserERROR:
+		c.onError = clause
+	case plsql.PlSqlParserEMPTY:
+		c.onEmpty = clause
+	case plsql.PlSqlParserMISMATCH:
+		c.onMismatch = clause
+	case plsql.PlSqlParserNULL_:
+		c.onNull = clause
+	}
+}
+
+// jsonOperands returns the operands as expressions.
+func (v *exprVisitor) jsonOperands(c *jsonClauses) []semantic.Expr {
+	exprs := make([]semantic.Expr, 0, len(c.rules))
+	for _, rule := range c.rules {
+		if expr := v.acceptExpr(rule); expr != nil {
+			exprs = append(exprs, expr)
+		}
+	}
+	return exprs
+}
+
+func (v *exprVisitor) VisitJson_function(ctx *plsql.Json_functionContext) interface{} {
+	switch ctx.GetStart().GetTokenType() {
+	case plsql.PlSqlParserJSON_VALUE, plsql.PlSqlParserJSON_QUERY:
+		expr := newAstNode[semantic.JsonValueExpression](ctx)
+		expr.Function = strings.ToUpper(ctx.GetStart().GetText())
+		c := v.jsonClauses(ctx)
+		if args := v.jsonOperands(c); len(args) > 0 {
+			expr.Expr = args[0]
+		}
+		expr.FormatJson, expr.Path = c.formatJson, c.path
+		expr.Returning, expr.Wrapper = c.returning, c.wrapper
+		expr.OnError, expr.OnEmpty, expr.OnMismatch = c.onError, c.onEmpty, c.onMismatch
+		return expr
+	case plsql.PlSqlParserJSON_OBJECT, plsql.PlSqlParserJSON_OBJECTAGG:
+		expr := newAstNode[semantic.JsonObjectExpression](ctx)
+		expr.Function = strings.ToUpper(ctx.GetStart().GetText())
+		c := v.jsonClauses(ctx)
+		expr.Entries = c.entries
+		if c.wildcard {
+			expr.Entries = append(expr.Entries, &semantic.JsonObjectEntry{
+				Key: &semantic.NameExpression{Name: "*"},
+			})
+		}
+		if args := v.jsonOperands(c); len(args) == 2 {
+			// JSON_OBJECTAGG(KEY k VALUE v)
+			entry := &semantic.JsonObjectEntry{Key: args[0], Value: args[1], FormatJson: c.formatJson}
+			expr.Entries = append(expr.Entries, entry)
+		}
+		expr.OnNull, expr.Returning = c.onNull, c.returning
+		expr.Strict, expr.UniqueKeys = c.strict, c.uniqueKeys
+		return expr
+	case plsql.PlSqlParserJSON_ARRAY, plsql.PlSqlParserJSON_ARRAYAGG:
+		expr := newAstNode[semantic.JsonArrayExpression](ctx)
+		expr.Function = strings.ToUpper(ctx.GetStart().GetText())
+		c := v.jsonClauses(ctx)
+		expr.Elements = v.jsonOperands(c)
+		expr.OrderBy, expr.OnNull, expr.Returning = c.orderBy, c.onNull, c.returning
+		expr.Strict = c.strict
+		return expr
+	}
+	return v.VisitChildren(ctx)
+}
+
+func (v *exprVisitor) VisitJson_array_element(ctx *plsql.Json_array_elementContext) interface{} {
+	for _, child := range ctx.GetChildren() {
+		if rule, ok := child.(antlr.ParserRuleContext); ok {
+			return v.acceptExpr(rule)
+		}
+	}
+	return nil
+}
+
+func (v *exprVisitor) jsonObjectEntry(ctx *plsql.Json_object_entryContext) *semantic.JsonObjectEntry {
+	entry := newAstNode[semantic.JsonObjectEntry](ctx)
+	c := v.jsonClauses(ctx)
+	args := v.jsonOperands(c)
+	switch len(args) {
+	case 0:
+	case 1:
+		// JSON_OBJECT(ename) names the entry after the column
+		entry.Value = args[0]
+	default:
+		entry.Key, entry.Value = args[0], args[1]
+	}
+	entry.FormatJson = c.formatJson
+	return entry
+}
+
+func (v *exprVisitor) VisitJson_table_clause(ctx *plsql.Json_table_clauseContext) interface{} {
+	expr := newAstNode[semantic.JsonTableExpression](ctx)
+	c := v.jsonClauses(ctx)
+	if args := v.jsonOperands(c); len(args) > 0 {
+		expr.Expr = args[0]
+	}
+	expr.FormatJson, expr.Path = c.formatJson, c.path
+	expr.OnError, expr.OnEmpty = c.onError, c.onEmpty
+	expr.Columns = c.columns
+	return expr
+}
+
+func (v *exprVisitor) jsonColumns(ctx *plsql.Json_column_clauseContext) []*semantic.JsonTableColumn {
+	var columns []*semantic.JsonTableColumn
+	for _, child := range ctx.GetChildren() {
+		if def, ok := child.(*plsql.Json_column_definitionContext); ok {
+			columns = append(columns, v.jsonColumn(def))
+		}
+	}
+	return columns
+}
+
+func (v *exprVisitor) jsonColumn(ctx *plsql.Json_column_definitionContext) *semantic.JsonTableColumn {
+	column := newAstNode[semantic.JsonTableColumn](ctx)
+	c := v.jsonClauses(ctx)
+	for _, child := range ctx.GetChildren() {
+		switch tokenType(child) {
+		case plsql.PlSqlParserEXISTS:
+			column.Exists = true
+		case plsql.PlSqlParserORDINALITY:
+			column.ForOrdinality = true
+		}
+	}
+	switch {
+	case len(c.columns) > 0:
+		// NESTED PATH '...' COLUMNS (...)
+		column.Nested = c.columns
+	case len(c.rules) > 0:
+		column.Name = treeText(c.rules[0], c.rules[0])
+		if len(c.rules) > 1 {
+			column.DataType = treeText(c.rules[1], c.rules[1])
+		}
+	}
+	if c.returning != "" {
+		column.DataType = c.returning
+	}
+	column.FormatJson, column.Path, column.Wrapper = c.formatJson, c.path, c.wrapper
+	column.OnError, column.OnEmpty = c.onError, c.onEmpty
+	return column
+}
+
+// acceptExpr visits ctx, which must give an expression.
+func (v *exprVisitor) acceptExpr(ctx antlr.ParserRuleContext) semantic.Expr {
+	expr, ok := ctx.Accept(v).(semantic.Expr)
+	if !ok {
+		v.ReportError(fmt.Sprintf("unsupported expression %T", ctx),
+			ctx.GetStart().GetLine(), ctx.GetStart().GetColumn())
+		return nil
+	}
+	return expr
+}
+
+// tokenType returns the token type of tree when it is a terminal, or 0.
+func tokenType(tree antlr.Tree) int {
+	if t, ok := tree.(antlr.TerminalNode); ok {
+		return t.GetSymbol().GetTokenType()
+	}
+	return 0
+}
+
+// treeText returns the source text from the start of first to the end of
+// last, as written.
+func treeText(first, last antlr.Tree) string {
+	start, stop := treeStart(first), treeStop(last)
+	if start == nil || stop == nil || stop.GetStop() < start.GetStart() {
+		return ""
+	}
+	return start.GetInputStream().GetTextFromInterval(antlr.NewInterval(start.GetStart(), stop.GetStop()))
+}
+
+func treeStart(tree antlr.Tree) antlr.Token {
+	switch t := tree.(type) {
+	case antlr.TerminalNode:
+		return t.GetSymbol()
+	case antlr.ParserRuleContext:
+		return t.GetStart()
+	}
+	return nil
+}
+
+func treeStop(tree antlr.Tree) antlr.Token {
+	switch t := tree.(type) {
+	case antlr.TerminalNode:
+		return t.GetSymbol()
+	case antlr.ParserRuleContext:
+		return t.GetStop()
+	}
+	return nil
+}
+
+// unquote returns the value of the string literal text.
+func unquote(text string) string {
+	if len(text) >= 2 && text[0] == '\'' && text[len(text)-1] == '\'' {
+		text = strings.ReplaceAll(text[1:len(text)-1], "''", "'")
+	}
+	return text
+}
@@ -61,7 +61,7 @@ func (l *sqlListener) EnterSql_script(ctx *plsql.Sql_scriptContext) {
 		Statements: make([]semantic.Statement, 0),
 	}
 	l.script.SetLine(ctx.GetStart().GetLine())
-	l.script.SetColumn(ctx.GetStart().GetColumn())
+	l.script.SetColumn(ctx.GetStart().GetColumn() + 1)
 	l.nodeStack.Push(l.script)
 }
 
@@ -83,7 +83,7 @@ func (l *sqlListener) ExitUnit_statement(ctx *plsql.Unit_statementContext) {
 func (l *sqlListener) EnterSelect_statement(ctx *plsql.Select_statementContext) {
 	stmt := &semantic.SelectStatement{}
 	stmt.SetLine(ctx.GetStart().GetLine())
-	stmt.SetColumn(ctx.GetStart().GetColumn())
+	stmt.SetColumn(ctx.GetStart().GetColumn() + 1)
 	l.nodeStack.Push(stmt)
 }
 
@@ -166,7 +166,7 @@ func (l *sqlListener) ExitCreate_procedure_body(ctx *plsql.Create_procedure_body
 
 	// set line & column
 	stmt.SetLine(ctx.GetStart().GetLine())
-	stmt.SetColumn(ctx.GetStart().GetColumn())
+	stmt.SetColumn(ctx.GetStart().GetColumn() + 1)
 
 	// set name
 	stmt.Name = ctx.Procedure_name().GetText()
@@ -228,7 +228,7 @@ func (l *sqlListener) ExitAssignment_statement(ctx *plsql.Assignment_statementCo
 
 	// set line & column
 	stmt.SetLine(ctx.GetStart().GetLine())
-	stmt.SetColumn(ctx.GetStart().GetColumn())
+	stmt.SetColumn(ctx.GetStart().GetColumn() + 1)
 
 	// set left
 	stmt.Left = ctx.General_element().GetText()
@@ -248,7 +248,7 @@ func (l *sqlListener) ExitVariable_declaration(ctx *plsql.Variable_declarationCo
 	stmt := &semantic.VariableDeclaration{}
 	// set line & column
 	stmt.SetLine(ctx.GetStart().GetLine())
-	stmt.SetColumn(ctx.GetStart().GetColumn())
+	stmt.SetColumn(ctx.GetStart().GetColumn() + 1)
 	stmt.Name = ctx.Identifier().GetText()
 	stmt.DataType = ctx.Type_spec().GetText()
 	l.nodeStack.Push(stmt)
@@ -258,7 +258,7 @@ func (l *sqlListener) ExitException_declaration(ctx *plsql.Exception_declaration
 	stmt := &semantic.ExceptionDeclaration{}
 	// set line & column
 	stmt.SetLine(ctx.GetStart().GetLine())
-	stmt.SetColumn(ctx.GetStart().GetColumn())
+	stmt.SetColumn(ctx.GetStart().GetColumn() + 1)
 	stmt.Name = ctx.Identifier().GetText()
 	l.nodeStack.Push(stmt)
 }
@@ -267,7 +267,7 @@ func (l *sqlListener) ExitCursor_declaration(ctx *plsql.Cursor_declarationContex
 	decl := &semantic.CursorDeclaration{}
 	// set line & column
 	decl.SetLine(ctx.GetStart().GetLine())
-	decl.SetColumn(ctx.GetStart().GetColumn())
+	decl.SetColumn(ctx.GetStart().GetColumn() + 1)
 	decl.Name = ctx.Identifier().GetText()
 	if ctx.Select_statement() != nil {
 		stmt := l.nodeStack.Pop().(*semantic.SelectStatement)
@@ -296,7 +296,7 @@ func (l *sqlListener) ExitParameter(ctx *plsql.ParameterContext) {
 func (l *sqlListener) EnterIf_statement(ctx *plsql.If_statementContext) {
 	stmt := &semantic.IfStatement{}
 	stmt.SetLine(ctx.GetStart().GetLine())
-	stmt.SetColumn(ctx.GetStart().GetColumn())
+	stmt.SetColumn(ctx.GetStart().GetColumn() + 1)
 	stmt.Set(atomic.LoadInt64(&stmtDepth))
 	atomic.AddInt64(&stmtDepth, 1)
 	l.nodeStack.Push(stmt)
@@ -363,7 +363,7 @@ func (l *sqlListener) ExitElse_part(ctx *plsql.Else_partContext) {
 func (l *sqlListener) ExitOpen_statement(ctx *plsql.Open_statementContext) {
 	stmt := &semantic.OpenStatement{}
 	stmt.SetLine(ctx.GetStart().GetLine())
-	stmt.SetColumn(ctx.GetStart().GetColumn())
+	stmt.SetColumn(ctx.GetStart().GetColumn() + 1)
 	stmt.Name = ctx.Cursor_name().GetText()
 	l.nodeStack.Push(stmt)
 }
@@ -371,7 +371,7 @@ func (l *sqlListener) ExitOpen_statement(ctx *plsql.Open_statementContext) {
 func (l *sqlListener) ExitClose_statement(ctx *plsql.Close_statementContext) {
 	stmt := &semantic.CloseStatement{}
 	stmt.SetLine(ctx.GetStart().GetLine())
-	stmt.SetColumn(ctx.GetStart().GetColumn())
+	stmt.SetColumn(ctx.GetStart().GetColumn() + 1)
 	stmt.Name = ctx.Cursor_name().GetText()
 	l.nodeStack.Push(stmt)
 }
@@ -379,7 +379,7 @@ func (l *sqlListener) ExitClose_statement(ctx *plsql.Close_statementContext) {
 func (l *sqlListener) EnterLoop_statement(ctx *plsql.Loop_statementContext) {
 	stmt := &semantic.LoopStatement{}
 	stmt.SetLine(ctx.GetStart().GetLine())
-	stmt.SetColumn(ctx.GetStart().GetColumn())
+	stmt.SetColumn(ctx.GetStart().GetColumn() + 1)
 	stmt.Set(atomic.LoadInt64(&stmtDepth))
 	atomic.AddInt64(&stmtDepth, 1)
 	l.nodeStack.Push(stmt)
@@ -409,7 +409,7 @@ func (l *sqlListener) ExitLoop_statement(ctx *plsql.Loop_statementContext) {
 func (l *sqlListener) ExitFetch_statement(ctx *plsql.Fetch_statementContext) {
 	stmt := &semantic.FetchStatement{}
 	stmt.SetLine(ctx.GetStart().GetLine())
-	stmt.SetColumn(ctx.GetStart().GetColumn())
+	stmt.SetColumn(ctx.GetStart().GetColumn() + 1)
 	stmt.Cursor = ctx.Cursor_name().GetText()
 	stmt.Into = ctx.Variable_name(0).GetText()
 	l.nodeStack.Push(stmt)
@@ -418,7 +418,7 @@ func (l *sqlListener) ExitFetch_statement(ctx *plsql.Fetch_statementContext) {
 func (l *sqlListener) ExitExit_statement(ctx *plsql.Exit_statementContext) {
 	stmt := &semantic.ExitStatement{}
 	stmt.SetLine(ctx.GetStart().GetLine())
-	stmt.SetColumn(ctx.GetStart().GetColumn())
+	stmt.SetColumn(ctx.GetStart().GetColumn() + 1)
 	if ctx.Condition() != nil {
 		vistior := exprVisitor{}
 		stmt.Condition = vistior.VisitCondition(ctx.Condition().(*plsql.ConditionContext)).(semantic.Expr)
@@ -429,7 +429,7 @@ func (l *sqlListener) ExitExit_statement(ctx *plsql.Exit_statementContext) {
 func (l *sqlListener) ExitFunction_call(ctx *plsql.Function_callContext) {
 	stmt := &semantic.ProcedureCall{}
 	stmt.SetLine(ctx.GetStart().GetLine())
-	stmt.SetColumn(ctx.GetStart().GetColumn())
+	stmt.SetColumn(ctx.GetStart().GetColumn() + 1)
 	stmt.Name = ctx.Routine_name().GetText()
 	for range ctx.Function_argument().AllArgument() {
 		node := l.nodeStack.Top()
@@ -446,7 +446,7 @@ func (l *sqlListener) ExitFunction_argument(ctx *plsql.Function_argumentContext)
 	for _, arg := range ctx.AllArgument() {
 		stmt := &semantic.Argument{}
 		stmt.SetLine(arg.GetStart().GetLine())
-		stmt.SetColumn(arg.GetStart().GetColumn())
+		stmt.SetColumn(arg.GetStart().GetColumn() + 1)
 
 		stmt.Name = arg.GetText()
 		l.nodeStack.Push(stmt)
@@ -456,7 +456,7 @@ func (l *sqlListener) ExitFunction_argument(ctx *plsql.Function_argumentContext)
 func (l *sqlListener) ExitNumeric(ctx *plsql.NumericContext) {
 	number := &semantic.NumericLiteral{}
 	number.SetLine(ctx.GetStart().GetLine())
-	number.SetColumn(ctx.GetStart().GetColumn())
+	number.SetColumn(ctx.GetStart().GetColumn() + 1)
 	if ctx.UNSIGNED_INTEGER() != nil {
 		if v, err := strconv.ParseInt(ctx.GetText(), 10, 64); err == nil {
 			number.Value = v
@@ -1,6 +1,9 @@
 package parser
 
 import (
+	"context"
+	"errors"
+
 	"procinspect/pkg/parser/internal/plsql/parser"
 	"procinspect/pkg/semantic"
 )
@@ -12,44 +15,119 @@ func Parse(text string) (any, error) {
 }
 
 func ParseScript(src string) (*semantic.Script, error) {
+	return ParseScriptContext(context.Background(), src)
+}
+
+// ParseScriptContext is like ParseScript, but stops when ctx is done. It
+// then returns the statements parsed so far, along with a diagnostic with
+// code semantic.CodeTimeout where the missing statements start.
+func ParseScriptContext(ctx context.Context, src string) (*semantic.Script, error) {
+	return ParseScriptOptions(ctx, src, Options{})
+}
+
+// ParseScriptOptions is like ParseScriptContext, parsing the dialect given
+// by opts.
+func ParseScriptOptions(ctx context.Context, src string, opts Options) (*semantic.Script, error) {
+	src, cmds, cmdErr := newSqlPlusState(opts.SqlPlus).preprocess(src)
 	p := parser.NewParser(src)
-	root := p.Sql_script()
-	if p.Error() != nil {
-		return nil, p.Error()
+	defer p.Release()
+	p.SetContext(ctx)
+	if opts.Version != 0 {
+		p.SetVersion(int(opts.Version))
 	}
+	root := p.Sql_script()
+	_, interrupted := p.Interrupted()
 	visitor := newPlSqlVisitor()
+	visitor.ctx, visitor.interrupted = ctx, interrupted
+	visitor.syntaxErrors = p.Diagnostics()
+	visitor.failedRules = p.FailedRules()
 	script := visitor.VisitSql_script(root.(*parser.Sql_scriptContext)).(*semantic.Script)
+	mergeCommands(script, commandsBefore(cmds, visitor.cutoff))
+	versionErr := checkVersions(script, p.Tokens(), opts, 0)
 
-	return script, visitor.Error()
+	return script, errors.Join(cmdErr, p.Error(), visitor.Error(), versionErr)
 }
 
+// ParseSql parses src and returns a function that builds its script with
+// line numbers counted from start. Syntax errors do not stop the parse: the
+// function is returned together with the error, and the script it builds
+// holds an error statement for each statement that failed.
 func ParseSql(src string) (func(int) (*semantic.Script, error), error) {
+	return ParseSqlContext(context.Background(), src)
+}
+
+// ParseSqlContext is like ParseSql, but stops when ctx is done, both while
+// parsing and while building the statements. See ParseScriptContext.
+func ParseSqlContext(ctx context.Context, src string) (func(int) (*semantic.Script, error), error) {
+	return ParseSqlOptions(ctx, src, Options{})
+}
+
+// ParseSqlOptions is like ParseSqlContext, parsing the dialect given by
+// opts. The syntax newer than opts.Version is reported by the function.
+func ParseSqlOptions(ctx context.Context, src string, opts Options) (func(int) (*semantic.Script, error), error) {
+	src, cmds, cmdErr := newSqlPlusState(opts.SqlPlus).preprocess(src)
 	p := parser.NewParser(src)
-	root := p.Sql_script()
-	if p.Error() != nil {
-		return nil, p.Error()
+	defer p.Release()
+	p.SetContext(ctx)
+	if opts.Version != 0 {
+		p.SetVersion(int(opts.Version))
 	}
+	root := p.Sql_script()
+	_, interrupted := p.Interrupted()
+	syntaxErrors, failedRules := p.Diagnostics(), p.FailedRules()
+	tokens := p.Tokens()
 
 	return func(start int) (*semantic.Script, error) {
 		visitor := newPlSqlVisitor(start)
+		visitor.ctx, visitor.interrupted = ctx, interrupted
+		visitor.syntaxErrors = syntaxErrors
+		visitor.failedRules = failedRules
 		script := visitor.VisitSql_script(root.(*parser.Sql_scriptContext)).(*semantic.Script)
+		mergeCommands(script, commandsBefore(cmds, visitor.cutoff))
+		versionErr := checkVersions(script, tokens, opts, start)
 
-		return script, visitor.Error()
-	}, nil
+		return script, errors.Join(cmdErr, visitor.Error(), versionErr)
+	}, p.Error()
 }
 
 func ParseBlock(src string) (*semantic.Script, error) {
+	return ParseBlockContext(context.Background(), src)
+}
+
+// ParseBlockContext is like ParseBlock, but stops when ctx is done. A block
+// is parsed as a whole, so the partial result is an empty script.
+func ParseBlockContext(ctx context.Context, src string) (*semantic.Script, error) {
 	p := parser.NewParser(src)
+	defer p.Release()
+	p.SetContext(ctx)
 	root := p.Block()
+	script := &semantic.Script{}
+	if tok, err := p.Interrupted(); err != nil {
+		return script, timeoutDiagnostic(err, tok.GetLine(), tok.GetColumn())
+	}
 	if p.Error() != nil {
 		return nil, p.Error()
 	}
 	visitor := newPlSqlVisitor(73)
 	block := visitor.VisitBlock(root.(*parser.BlockContext)).(*semantic.BlockStatement)
-
-	script := &semantic.Script{}
+	finishRanges(block, parser.NewOffsetMap(src))
 
 	script.Statements = append(script.Statements, block)
 
 	return script, nil
 }
+
+// commandsBefore returns the SQL*Plus commands before line, or all of them
+// when line is 0.
+func commandsBefore(cmds []*semantic.SqlPlusCommand, line int) []*semantic.SqlPlusCommand {
+	if line == 0 {
+		return cmds
+	}
+	kept := cmds[:0:0]
+	for _, cmd := range cmds {
+		if cmd.Line() < line {
+			kept = append(kept, cmd)
+		}
+	}
+	return kept
+}
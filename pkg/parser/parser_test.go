@@ -1,6 +1,16 @@
 package parser
 
 import (
+	"context"
+	"fmt"
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -388,6 +398,46 @@ rollback;`,
 		},
 	})
 
+	tests = append(tests, testCase{
+		name: "transaction and session statements",
+		text: `set transaction read only name 'nightly';
+set transaction isolation level serializable;
+savepoint before_load;
+lock table emp, dept in row exclusive mode nowait;
+lock table emp in share mode wait 5;
+rollback to savepoint before_load;
+alter session set nls_date_format = 'YYYY-MM-DD' time_zone = '+00:00';
+alter session enable parallel dml;`,
+		Func: func(t *testing.T, root any) {
+			node := root.(*semantic.Script)
+			assert.Equal(t, 8, len(node.Statements))
+			tx := node.Statements[0].(*semantic.SetTransactionStatement)
+			assert.True(t, tx.ReadOnly)
+			assert.Equal(t, "'nightly'", tx.Name)
+			tx = node.Statements[1].(*semantic.SetTransactionStatement)
+			assert.False(t, tx.ReadOnly)
+			assert.Equal(t, "SERIALIZABLE", tx.IsolationLevel)
+			assert.Equal(t, "before_load", node.Statements[2].(*semantic.SavepointStatement).Name)
+			lock := node.Statements[3].(*semantic.LockTableStatement)
+			assert.Equal(t, 2, len(lock.Tables))
+			assert.Equal(t, "dept", lock.Tables[1].Table)
+			assert.Equal(t, "ROW EXCLUSIVE", lock.Mode)
+			assert.True(t, lock.NoWait)
+			lock = node.Statements[4].(*semantic.LockTableStatement)
+			assert.Equal(t, "SHARE", lock.Mode)
+			assert.IsType(t, &semantic.NumericLiteral{}, lock.Wait)
+			assert.Equal(t, "before_load", node.Statements[5].(*semantic.RollbackStatement).Savepoint)
+			alter := node.Statements[6].(*semantic.AlterSessionStatement)
+			assert.Equal(t, 2, len(alter.Parameters))
+			assert.Equal(t, "nls_date_format", alter.Parameters[0].Name)
+			assert.Equal(t, "'YYYY-MM-DD'", alter.Parameters[0].Value)
+			assert.Equal(t, "time_zone", alter.Parameters[1].Name)
+			alter = node.Statements[7].(*semantic.AlterSessionStatement)
+			assert.Empty(t, alter.Parameters)
+			assert.Equal(t, "enable parallel dml", alter.Clause)
+		},
+	})
+
 	tests = append(tests, testCase{
 		name: "delete",
 		text: `delete from t1 where t1.id =1;`,
@@ -1801,8 +1851,12 @@ END`,
 				assert.IsType(t, &semantic.AssignmentStatement{}, node.Body.Statements[i])
 				stmt := node.Body.Statements[i].(*semantic.AssignmentStatement)
 				assert.Equal(t, stmt.Left, "a")
-				assert.IsType(t, &semantic.DotExpression{}, stmt.Right)
-				dotExp := stmt.Right.(*semantic.DotExpression)
+				assert.IsType(t, &semantic.CorrelationReference{}, stmt.Right)
+				ref := stmt.Right.(*semantic.CorrelationReference)
+				assert.Equal(t, "OLD", ref.Row)
+				assert.Equal(t, "id", ref.Name)
+				assert.IsType(t, &semantic.DotExpression{}, ref.Expr)
+				dotExp := ref.Expr.(*semantic.DotExpression)
 				assert.IsType(t, &semantic.NameExpression{}, dotExp.Name)
 				name := dotExp.Name.(*semantic.NameExpression)
 				assert.Equal(t, name.Name, "id")
@@ -1816,6 +1870,44 @@ END`,
 			}
 		},
 	})
+	tests = append(tests, testCase{
+		name: "pseudo columns and sequences",
+		root: getBlock,
+		text: `
+BEGIN
+	a := hr.emp_seq.NEXTVAL;
+	b := rownum;
+	c := sysdate;
+	d := e.rowid;
+	x := SQL%ROWCOUNT;
+	y := c1%ROWCOUNT;
+END`,
+		Func: func(t *testing.T, root any) {
+			node := root.(*semantic.BlockStatement)
+			assert.Equal(t, 6, len(node.Body.Statements))
+			right := func(i int) semantic.Expr {
+				return node.Body.Statements[i].(*semantic.AssignmentStatement).Right
+			}
+			assert.IsType(t, &semantic.SequenceReference{}, right(0))
+			seq := right(0).(*semantic.SequenceReference)
+			assert.Equal(t, "hr.emp_seq", seq.Sequence)
+			assert.Equal(t, "NEXTVAL", seq.Pseudo)
+			assert.IsType(t, &semantic.DotExpression{}, seq.Expr)
+			assert.IsType(t, &semantic.PseudoColumn{}, right(1))
+			pseudo := right(1).(*semantic.PseudoColumn)
+			assert.Equal(t, "ROWNUM", pseudo.Name)
+			assert.Equal(t, "rownum", pseudo.Expr.(*semantic.NameExpression).Name)
+			assert.Equal(t, "SYSDATE", right(2).(*semantic.PseudoColumn).Name)
+			pseudo = right(3).(*semantic.PseudoColumn)
+			assert.Equal(t, "ROWID", pseudo.Name)
+			assert.Equal(t, "e", pseudo.Table)
+			assert.IsType(t, &semantic.SqlCursorAttribute{}, right(4))
+			cursor := right(4).(*semantic.SqlCursorAttribute)
+			assert.Equal(t, "ROWCOUNT", cursor.Attr)
+			assert.IsType(t, &semantic.CursorAttribute{}, cursor.Expr)
+			assert.IsType(t, &semantic.CursorAttribute{}, right(5))
+		},
+	})
 	tests = append(tests, testCase{
 		name: "execute_immediate",
 		root: getBlock,
@@ -2218,6 +2310,146 @@ minus select * from t5;`,
 		},
 	})
 
+	tests = append(tests, testCase{
+		name: "select pivot",
+		text: `select * from sales
+pivot (sum(amount) as total, count(*) cnt for quarter in ('Q1' as q1, 'Q2' as q2));`,
+		Func: func(t *testing.T, root any) {
+			node := root.(*semantic.Script)
+			assert.Equal(t, 1, len(node.Statements))
+			require.IsType(t, &semantic.SelectStatement{}, node.Statements[0])
+			stmt := node.Statements[0].(*semantic.SelectStatement)
+			require.Equal(t, 1, len(stmt.From.TableRefs))
+			ref := stmt.From.TableRefs[0]
+			assert.Equal(t, "sales", ref.Table)
+			require.NotNil(t, ref.Pivot)
+			assert.Nil(t, ref.Unpivot)
+			pivot := ref.Pivot
+			assert.Equal(t, 2, pivot.Line())
+			assert.False(t, pivot.IsXML)
+			require.Equal(t, 2, len(pivot.Aggregates))
+			assert.Equal(t, "SUM", pivot.Aggregates[0].Function)
+			assert.Equal(t, "total", pivot.Aggregates[0].Alias)
+			assert.IsType(t, &semantic.NameExpression{}, pivot.Aggregates[0].Expr)
+			assert.Equal(t, "COUNT", pivot.Aggregates[1].Function)
+			assert.Equal(t, "cnt", pivot.Aggregates[1].Alias)
+			require.Equal(t, 1, len(pivot.For))
+			assert.Equal(t, "quarter", pivot.For[0].(*semantic.NameExpression).Name)
+			require.Equal(t, 2, len(pivot.In))
+			assert.Equal(t, "q1", pivot.In[0].Alias)
+			require.Equal(t, 1, len(pivot.In[0].Values))
+			assert.IsType(t, &semantic.StringLiteral{}, pivot.In[0].Values[0])
+			assert.Equal(t, "q2", pivot.In[1].Alias)
+			assert.False(t, pivot.InAny)
+			assert.Nil(t, pivot.InQuery)
+		},
+	})
+
+	tests = append(tests, testCase{
+		name: "select unpivot",
+		text: `select * from sales
+unpivot include nulls (amount for quarter in (q1 as 'Q1', q2 as 'Q2'));`,
+		Func: func(t *testing.T, root any) {
+			node := root.(*semantic.Script)
+			assert.Equal(t, 1, len(node.Statements))
+			require.IsType(t, &semantic.SelectStatement{}, node.Statements[0])
+			stmt := node.Statements[0].(*semantic.SelectStatement)
+			require.Equal(t, 1, len(stmt.From.TableRefs))
+			ref := stmt.From.TableRefs[0]
+			assert.Equal(t, "sales", ref.Table)
+			assert.Nil(t, ref.Pivot)
+			require.NotNil(t, ref.Unpivot)
+			unpivot := ref.Unpivot
+			assert.True(t, unpivot.IncludeNulls)
+			require.Equal(t, 1, len(unpivot.Columns))
+			assert.Equal(t, "amount", unpivot.Columns[0].(*semantic.NameExpression).Name)
+			require.Equal(t, 1, len(unpivot.For))
+			assert.Equal(t, "quarter", unpivot.For[0].(*semantic.NameExpression).Name)
+			require.Equal(t, 2, len(unpivot.In))
+			require.Equal(t, 1, len(unpivot.In[0].Columns))
+			assert.Equal(t, "q1", unpivot.In[0].Columns[0].(*semantic.NameExpression).Name)
+			require.Equal(t, 1, len(unpivot.In[0].Values))
+			assert.IsType(t, &semantic.StringLiteral{}, unpivot.In[0].Values[0])
+		},
+	})
+
+	tests = append(tests, testCase{
+		name: "select pivot with alias",
+		text: `select * from sales pivot (sum(amount) for quarter in ('Q1', 'Q2')) p;`,
+		Func: func(t *testing.T, root any) {
+			node := root.(*semantic.Script)
+			require.IsType(t, &semantic.SelectStatement{}, node.Statements[0])
+			stmt := node.Statements[0].(*semantic.SelectStatement)
+			require.Equal(t, 1, len(stmt.From.TableRefs))
+			ref := stmt.From.TableRefs[0]
+			assert.Equal(t, "sales", ref.Table)
+			assert.Equal(t, "p", ref.Alias)
+			assert.NotNil(t, ref.Pivot)
+		},
+	})
+
+	tests = append(tests, testCase{
+		name: "select pivot after join",
+		text: `select * from sales s join regions r on s.region = r.id
+unpivot (amount for quarter in (q1, q2));`,
+		Func: func(t *testing.T, root any) {
+			node := root.(*semantic.Script)
+			require.IsType(t, &semantic.SelectStatement{}, node.Statements[0])
+			stmt := node.Statements[0].(*semantic.SelectStatement)
+			require.Equal(t, 1, len(stmt.From.TableRefs))
+			ref := stmt.From.TableRefs[0]
+			assert.Equal(t, "sales", ref.Table)
+			assert.Equal(t, "s", ref.Alias)
+			assert.NotNil(t, ref.Unpivot)
+		},
+	})
+
+	tests = append(tests, testCase{
+		name: "select model",
+		text: `select country, year, sales from sales_view
+model return updated rows
+	partition by (country)
+	dimension by (year)
+	measures (sales)
+	rules upsert (
+		sales[2001] = sales[2000] + sales[1999],
+		update sales[any] = 0
+	);`,
+		Func: func(t *testing.T, root any) {
+			node := root.(*semantic.Script)
+			assert.Equal(t, 1, len(node.Statements))
+			require.IsType(t, &semantic.SelectStatement{}, node.Statements[0])
+			stmt := node.Statements[0].(*semantic.SelectStatement)
+			require.NotNil(t, stmt.Model)
+			model := stmt.Model
+			assert.Equal(t, 2, model.Line())
+			assert.Equal(t, "RETURN UPDATED ROWS", model.ReturnRows)
+			assert.Equal(t, 0, len(model.ReferenceModels))
+			require.NotNil(t, model.MainModel)
+			main := model.MainModel
+			assert.Equal(t, 1, len(main.PartitionBy))
+			assert.Equal(t, 1, len(main.DimensionBy))
+			assert.Equal(t, 1, len(main.Measures))
+			assert.Equal(t, "RULES UPSERT", main.RulesOption)
+			require.Equal(t, 2, len(main.Rules))
+			rule := main.Rules[0]
+			assert.Equal(t, "", rule.Option)
+			require.IsType(t, &semantic.ModelCellExpression{}, rule.Cell)
+			cell := rule.Cell.(*semantic.ModelCellExpression)
+			assert.IsType(t, &semantic.NameExpression{}, cell.Measure)
+			require.Equal(t, 1, len(cell.Dimensions))
+			assert.IsType(t, &semantic.NumericLiteral{}, cell.Dimensions[0])
+			require.IsType(t, &semantic.BinaryExpression{}, rule.Expr)
+			assert.IsType(t, &semantic.ModelCellExpression{}, rule.Expr.(*semantic.BinaryExpression).Left)
+			rule = main.Rules[1]
+			assert.Equal(t, "UPDATE", rule.Option)
+			require.IsType(t, &semantic.ModelCellExpression{}, rule.Cell)
+			cell = rule.Cell.(*semantic.ModelCellExpression)
+			require.Equal(t, 1, len(cell.Dimensions))
+			assert.Equal(t, "ANY", cell.Dimensions[0].(*semantic.NameExpression).Name)
+		},
+	})
+
 	runTestSuite(t, tests)
 }
 
@@ -2323,6 +2555,54 @@ select 1 from dual;`,
 		},
 	})
 
+	tests = append(tests, testCase{
+		name: "conditional insert first",
+		text: `insert first
+when amount > 1000 then
+  into big_orders values (id, amount)
+  into audit_orders (id) values (id)
+when amount > 100 then
+  into medium_orders values (id, amount)
+else
+  into small_orders values (id, amount)
+select id, amount from orders;`,
+		Func: func(t *testing.T, root any) {
+			node := root.(*semantic.Script)
+			assert.Equal(t, 1, len(node.Statements))
+			stmt := node.Statements[0].(*semantic.InsertStatement)
+			assert.True(t, stmt.IsFirst)
+			assert.Empty(t, stmt.AllInto)
+			assert.Equal(t, 2, len(stmt.Conditions))
+			cond := stmt.Conditions[0]
+			assert.IsType(t, &semantic.RelationalExpression{}, cond.Condition)
+			assert.Equal(t, 2, len(cond.Into))
+			assert.Equal(t, "big_orders", cond.Into[0].Table.Table)
+			assert.Equal(t, 2, len(cond.Into[0].Values))
+			assert.Equal(t, "audit_orders", cond.Into[1].Table.Table)
+			assert.Equal(t, 1, len(cond.Into[1].Columns))
+			assert.Equal(t, 1, len(stmt.Conditions[1].Into))
+			assert.Equal(t, 1, len(stmt.Else))
+			assert.Equal(t, "small_orders", stmt.Else[0].Table.Table)
+			assert.Equal(t, 4, len(stmt.Targets()))
+			assert.NotNil(t, stmt.Select)
+		},
+	})
+
+	tests = append(tests, testCase{
+		name: "conditional insert all",
+		text: `insert all
+when kind = 'A' then into t_a values (id)
+when kind = 'B' then into t_b values (id)
+select id, kind from src;`,
+		Func: func(t *testing.T, root any) {
+			node := root.(*semantic.Script)
+			stmt := node.Statements[0].(*semantic.InsertStatement)
+			assert.False(t, stmt.IsFirst)
+			assert.Equal(t, 2, len(stmt.Conditions))
+			assert.Empty(t, stmt.Else)
+		},
+	})
+
 	runTestSuite(t, tests)
 }
 
@@ -2471,6 +2751,29 @@ when matched then
 				assert.NotNil(t, stmt.MergeUpdate)
 			},
 		},
+		{
+			name: "merge insert",
+			text: `
+merge into t1
+using t2
+on (t1.a=t2.a)
+when matched then
+	update set t1.b=t2.b
+when not matched then
+	insert (a, b) values (t2.a, t2.b) where t2.b > 0;`,
+			Func: func(t *testing.T, root any) {
+				node := root.(*semantic.Script)
+				assert.Equal(t, 1, len(node.Statements))
+				stmt := node.Statements[0].(*semantic.MergeStatement)
+				assert.NotNil(t, stmt.MergeUpdate)
+				insert := stmt.MergeInsert
+				assert.NotNil(t, insert)
+				assert.Equal(t, 2, len(insert.Columns))
+				assert.Equal(t, 2, len(insert.Values))
+				assert.IsType(t, &semantic.DotExpression{}, insert.Values[0])
+				assert.IsType(t, &semantic.RelationalExpression{}, insert.Where)
+			},
+		},
 		{
 			name: "merge using select",
 			text: `
@@ -2666,6 +2969,94 @@ end;`,
 				assert.Equal(t, 1, len(tp.Body.Statements))
 			},
 		},
+		{
+			name: "update of with referencing and when",
+			text: `
+create or replace trigger emp_salary_audit
+after update of salary, commission on emp
+referencing old as o new as n
+for each row
+follows emp_audit, emp_log
+disable
+when (n.salary > o.salary)
+begin
+  insert into emp_audit values (:o.salary, :n.salary);
+end;`,
+			Func: func(t *testing.T, root any) {
+				node := root.(*semantic.Script)
+				assert.Equal(t, 1, len(node.Statements))
+				stmt := node.Statements[0].(*semantic.CreateSimpleDmlTriggerStatement)
+				assert.True(t, stmt.IsReplace)
+				assert.False(t, stmt.IsBefore)
+				assert.False(t, stmt.IsInsteadOf)
+				assert.Equal(t, []string{"update"}, stmt.Events)
+				assert.Equal(t, []string{"salary", "commission"}, stmt.UpdateColumns)
+				assert.Equal(t, "emp", stmt.TableView)
+				assert.Equal(t, &semantic.TriggerReferencing{SyntaxNode: stmt.Referencing.SyntaxNode, Old: "o", New: "n"}, stmt.Referencing)
+				assert.True(t, stmt.ForEachRow)
+				assert.Equal(t, []string{"emp_audit", "emp_log"}, stmt.Follows)
+				assert.True(t, stmt.Disabled)
+				assert.IsType(t, &semantic.RelationalExpression{}, stmt.When)
+				assert.IsType(t, &semantic.TriggerBlock{}, stmt.TriggerBody)
+				assert.Contains(t, semantic.GetChildren(stmt), stmt.When)
+			},
+		},
+		{
+			name: "instead of",
+			text: `
+create trigger emp_dept_insert
+instead of insert on emp_dept_view
+for each row
+begin
+  insert into emp values (:new.empno, :new.ename);
+end;`,
+			Func: func(t *testing.T, root any) {
+				node := root.(*semantic.Script)
+				stmt := node.Statements[0].(*semantic.CreateSimpleDmlTriggerStatement)
+				assert.False(t, stmt.IsReplace)
+				assert.True(t, stmt.IsInsteadOf)
+				assert.False(t, stmt.IsBefore)
+				assert.Equal(t, []string{"insert"}, stmt.Events)
+				assert.Equal(t, "emp_dept_view", stmt.TableView)
+				assert.Nil(t, stmt.Referencing)
+			},
+		},
+		{
+			name: "logon on database",
+			text: `
+create or replace trigger logon_audit
+after logon on database
+begin
+  insert into logons values (user, sysdate);
+end;`,
+			Func: func(t *testing.T, root any) {
+				node := root.(*semantic.Script)
+				assert.Equal(t, 1, len(node.Statements))
+				stmt := node.Statements[0].(*semantic.CreateNonDmlTriggerStatement)
+				assert.Equal(t, "logon_audit", stmt.Name)
+				assert.False(t, stmt.IsBefore)
+				assert.Equal(t, []string{"logon"}, stmt.Events)
+				assert.True(t, stmt.OnDatabase)
+				assert.IsType(t, &semantic.TriggerBlock{}, stmt.TriggerBody)
+			},
+		},
+		{
+			name: "ddl on schema",
+			text: `
+create trigger no_drops
+before drop or truncate on scott.schema
+begin
+  raise_application_error(-20001, 'not allowed');
+end;`,
+			Func: func(t *testing.T, root any) {
+				node := root.(*semantic.Script)
+				stmt := node.Statements[0].(*semantic.CreateNonDmlTriggerStatement)
+				assert.True(t, stmt.IsBefore)
+				assert.Equal(t, []string{"drop", "truncate"}, stmt.Events)
+				assert.False(t, stmt.OnDatabase)
+				assert.Equal(t, "scott", stmt.Schema)
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -2806,16 +3197,12 @@ func TestParseErrors(t *testing.T) {
 				assert.NotNil(t, root)
 				err, ok := root.(error)
 				assert.True(t, ok)
-				assert.Equal(t, "unprocessed syntax *parser.Create_tableContext", err.Error())
-				errs, ok := err.(interface {
-					Unwrap() []error
-				})
-				assert.True(t, ok)
-				assert.IsType(t, ParseError{
-					Line:   1,
-					Column: 1,
-					Msg:    "unprocessed syntax *parser.Create_tableContext",
-				}, errs.Unwrap()[0])
+				assert.Equal(t, "1:1: unprocessed syntax *parser.Create_tableContext", err.Error())
+				diags := semantic.Diagnostics(err)
+				require.Len(t, diags, 1)
+				assert.Equal(t, semantic.CodeSemantic, diags[0].Code)
+				assert.Equal(t, semantic.Position{Line: 1, Column: 1}, diags[0].Start)
+				assert.Equal(t, "unprocessed syntax *parser.Create_tableContext", diags[0].Message)
 			},
 		},
 		{
@@ -2826,7 +3213,15 @@ select * from test.1));`,
 				assert.NotNil(t, root)
 				err := root.(error)
 				assert.NotNil(t, err)
-				assert.Equal(t, "syntax error at line 2, column 18: [dml_table_expression_clause table_ref_aux_internal table_ref_aux table_ref table_ref_list from_clause query_block subquery_basic_elements subquery select_only_statement select_statement dml_table_expression_clause table_ref_aux_internal table_ref_aux table_ref table_ref_list from_clause query_block subquery_basic_elements subquery select_only_statement select_statement data_manipulation_language_statements unit_statement sql_script]", err.Error())
+				diags := semantic.Diagnostics(err)
+				require.NotEmpty(t, diags)
+				assert.Equal(t, semantic.CodeSyntax, diags[0].Code)
+				assert.Equal(t, 2, diags[0].Start.Line)
+				assert.Equal(t, 19, diags[0].Start.Column)
+				assert.True(t, strings.HasPrefix(err.Error(), "2:19: "), err.Error())
+				excerpt := diags[0].Excerpt(`select * from (select * from (
+select * from test.1));`)
+				assert.True(t, strings.HasPrefix(excerpt, "2 | select * from test.1));\n  |                   ^"), excerpt)
 			},
 		},
 	}
@@ -2856,3 +3251,856 @@ select * from test.1));`,
 	}
 
 }
+
+func TestParseSqlPlusCommands(t *testing.T) {
+	parseScript := func(t *testing.T, text string) any {
+		script, err := ParseScript(text)
+		require.Nil(t, err, err)
+		return script
+	}
+	tests := testSuite{
+		{
+			name: "script commands",
+			text: `SET DEFINE OFF
+PROMPT creating objects
+SPOOL install.log
+WHENEVER SQLERROR EXIT SQL.SQLCODE
+select * from dual;
+@@child.sql
+EXEC dbms_output.put_line('done')
+SPOOL OFF
+EXIT`,
+			root: parseScript,
+			Func: func(t *testing.T, root any) {
+				script := root.(*semantic.Script)
+				require.Len(t, script.Statements, 9)
+				expected := []struct{ name, args string }{
+					{"SET", "DEFINE OFF"},
+					{"PROMPT", "creating objects"},
+					{"SPOOL", "install.log"},
+					{"WHENEVER", "SQLERROR EXIT SQL.SQLCODE"},
+					{},
+					{"@@", "child.sql"},
+					{"EXECUTE", "dbms_output.put_line('done')"},
+					{"SPOOL", "OFF"},
+					{"EXIT", ""},
+				}
+				for i, e := range expected {
+					if e.name == "" {
+						assert.IsType(t, &semantic.SelectStatement{}, script.Statements[i])
+						continue
+					}
+					cmd, ok := script.Statements[i].(*semantic.SqlPlusCommand)
+					require.True(t, ok, "statement %d", i)
+					assert.Equal(t, e.name, cmd.Name)
+					assert.Equal(t, e.args, cmd.Args)
+					assert.Equal(t, i+1, cmd.Line())
+				}
+				call := script.Statements[6].(*semantic.SqlPlusCommand).Call
+				assert.IsType(t, &semantic.ProcedureCall{}, call)
+				assert.Equal(t, 7, call.Line())
+			},
+		},
+		{
+			name: "set transaction is sql",
+			text: `set transaction read only;`,
+			Func: func(t *testing.T, root any) {
+				script := root.(*semantic.Script)
+				assert.Len(t, script.Statements, 1)
+				_, ok := script.Statements[0].(*semantic.SqlPlusCommand)
+				assert.False(t, ok)
+			},
+			root: parseScript,
+		},
+		{
+			name: "unit header over two lines",
+			text: `CREATE OR REPLACE
+PROCEDURE p IS
+  a NUMBER;
+  col NUMBER;
+BEGIN
+  col := a;
+END;
+/
+PROMPT done`,
+			root: parseScript,
+			Func: func(t *testing.T, root any) {
+				script := root.(*semantic.Script)
+				require.Len(t, script.Statements, 2)
+				assert.IsType(t, &semantic.CreateProcedureStatement{}, script.Statements[0])
+				cmd, ok := script.Statements[1].(*semantic.SqlPlusCommand)
+				require.True(t, ok)
+				assert.Equal(t, "PROMPT", cmd.Name)
+				assert.Equal(t, 9, cmd.Line())
+			},
+		},
+		{
+			name: "substitution from options",
+			text: `select * from &tab;`,
+			root: func(t *testing.T, text string) any {
+				script, err := ParseScriptOptions(context.Background(), text, Options{
+					SqlPlus: SqlPlusOptions{Substitute: true, Defines: map[string]string{"tab": "dual"}},
+				})
+				require.Nil(t, err, err)
+				return script
+			},
+			Func: func(t *testing.T, root any) {
+				script := root.(*semantic.Script)
+				require.Len(t, script.Statements, 1)
+				stmt := script.Statements[0].(*semantic.SelectStatement)
+				assert.Equal(t, "dual", stmt.From.TableRefs[0].Table)
+			},
+		},
+	}
+	runTestSuite(t, tests)
+}
+
+func TestParseScriptFile(t *testing.T) {
+	writeFiles := func(t *testing.T, files map[string]string) string {
+		dir := t.TempDir()
+		for name, text := range files {
+			path := filepath.Join(dir, name)
+			require.Nil(t, os.MkdirAll(filepath.Dir(path), 0o755))
+			require.Nil(t, os.WriteFile(path, []byte(text), 0o644))
+		}
+		return dir
+	}
+
+	t.Run("include", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{
+			"main.sql":      "@sub/child\nselect 1 from dual;\n",
+			"sub/child.sql": "@@leaf.sql\n",
+			"sub/leaf.sql":  "select 2 from dual;\n",
+		})
+		script, err := ParseScriptFile(filepath.Join(dir, "main.sql"), SqlPlusOptions{})
+		require.Nil(t, err, err)
+		require.Len(t, script.Statements, 2)
+		child := script.Statements[0].(*semantic.SqlPlusCommand)
+		require.NotNil(t, child.Include)
+		leaf := child.Include.Statements[0].(*semantic.SqlPlusCommand)
+		require.NotNil(t, leaf.Include)
+		assert.IsType(t, &semantic.SelectStatement{}, leaf.Include.Statements[0])
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{
+			"a.sql": "@@b.sql\n",
+			"b.sql": "@@a.sql\n",
+		})
+		_, err := ParseScriptFile(filepath.Join(dir, "a.sql"), SqlPlusOptions{})
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "include cycle")
+	})
+
+	t.Run("substitution", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{
+			"main.sql": `DEFINE tab = employees
+select * from &tab;
+select * from &&owner..&tab;
+SET DEFINE OFF
+select 'R&D' from dual;
+`,
+		})
+		script, err := ParseScriptFile(filepath.Join(dir, "main.sql"), SqlPlusOptions{
+			Substitute: true,
+			Defines:    map[string]string{"owner": "hr"},
+		})
+		require.Nil(t, err, err)
+		require.Len(t, script.Statements, 5)
+		from := func(i int) string {
+			return script.Statements[i].(*semantic.SelectStatement).From.TableRefs[0].Table
+		}
+		assert.Equal(t, "employees", from(1))
+		assert.Equal(t, "hr.employees", from(2))
+	})
+}
+
+func TestParseConditionalCompilation(t *testing.T) {
+	const src = `begin
+  $IF $$debug $THEN
+    dbg($$PLSQL_LINE);
+  $ELSIF DBMS_DB_VERSION.VER_LE_12 $THEN
+    legacy;
+  $ELSE
+    run;
+  $END
+end;`
+	conditional := func(opts ConditionalOptions) rootFunc {
+		return func(t *testing.T, text string) any {
+			script, err := ParseConditionalScript(text, opts)
+			require.Nil(t, err, err)
+			return script
+		}
+	}
+	body := func(t *testing.T, root any) []semantic.Statement {
+		script := root.(*semantic.Script)
+		require.Len(t, script.Statements, 1)
+		block, ok := script.Statements[0].(*semantic.BlockStatement)
+		require.True(t, ok)
+		return block.Body.Statements
+	}
+	tests := testSuite{
+		{
+			name: "default flags",
+			text: src,
+			root: conditional(ConditionalOptions{}),
+			Func: func(t *testing.T, root any) {
+				stmts := body(t, root)
+				require.Len(t, stmts, 1)
+				assert.Equal(t, 7, stmts[0].Line())
+				branches := root.(*semantic.Script).Conditionals[0].Branches
+				require.Len(t, branches, 3)
+				assert.Equal(t, "$$debug", branches[0].Condition)
+				assert.Equal(t, "DBMS_DB_VERSION.VER_LE_12", branches[1].Condition)
+				assert.Equal(t, "", branches[2].Condition)
+				assert.True(t, branches[2].Selected)
+				assert.Nil(t, branches[0].Nodes)
+			},
+		},
+		{
+			name: "ccflags",
+			text: src,
+			root: conditional(ConditionalOptions{CCFlags: "debug:TRUE"}),
+			Func: func(t *testing.T, root any) {
+				stmts := body(t, root)
+				require.Len(t, stmts, 1)
+				assert.Equal(t, 3, stmts[0].Line())
+				assert.True(t, root.(*semantic.Script).Conditionals[0].Branches[0].Selected)
+			},
+		},
+		{
+			name: "version",
+			text: src,
+			root: conditional(ConditionalOptions{Version: 11, Release: 2}),
+			Func: func(t *testing.T, root any) {
+				stmts := body(t, root)
+				require.Len(t, stmts, 1)
+				assert.Equal(t, 5, stmts[0].Line())
+			},
+		},
+		{
+			name: "all branches",
+			text: src,
+			root: conditional(ConditionalOptions{AllBranches: true}),
+			Func: func(t *testing.T, root any) {
+				branches := root.(*semantic.Script).Conditionals[0].Branches
+				require.Len(t, branches[0].Nodes, 1)
+				assert.IsType(t, &semantic.ProcedureCall{}, branches[0].Nodes[0])
+				require.Len(t, branches[1].Nodes, 1)
+				assert.Equal(t, 5, branches[1].Nodes[0].(semantic.Node).Line())
+				assert.Nil(t, branches[2].Nodes)
+			},
+		},
+	}
+	runTestSuite(t, tests)
+
+	_, err := ParseConditionalScript(`begin $IF $$x IS NULL $THEN $ERROR 'x is required' $END $END null; end;`,
+		ConditionalOptions{})
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "x is required")
+
+	_, err = ParseConditionalScript(`begin $IF $$x $THEN null; end;`, ConditionalOptions{})
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "$IF without $END")
+
+	// the upper case of ɐ is longer in bytes
+	_, err = ParseConditionalScript(`begin $IF 'ɐɐ' = 'x' $THEN null; $ELSE $ERROR 'ɐɐ''s' $END $END end;`,
+		ConditionalOptions{})
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "ɐɐ's")
+	assert.NotContains(t, err.Error(), "ɐɐ's'")
+}
+
+func TestParseErrorRecovery(t *testing.T) {
+	script, err := ParseScript(`select * from dual;
+select * from from;
+select 1 from dual;`)
+	require.NotNil(t, err)
+	require.NotNil(t, script)
+	require.GreaterOrEqual(t, len(script.Statements), 3)
+
+	first := script.Statements[0]
+	assert.IsType(t, &semantic.SelectStatement{}, first)
+	last := script.Statements[len(script.Statements)-1]
+	assert.IsType(t, &semantic.SelectStatement{}, last)
+	assert.Equal(t, 3, last.Line())
+
+	stmt, ok := script.Statements[1].(*semantic.ErrorStatement)
+	require.True(t, ok)
+	assert.Equal(t, 2, stmt.Line())
+	assert.Contains(t, stmt.Text, "from")
+	assert.Equal(t, 2, stmt.Diagnostic.Start.Line)
+	assert.Equal(t, semantic.CodeSyntax, stmt.Diagnostic.Code)
+	assert.NotEmpty(t, stmt.Diagnostic.Message)
+}
+
+func TestParseSqlSyntaxError(t *testing.T) {
+	build, err := ParseSql(`select * from from;
+select 1 from dual;`)
+	require.NotNil(t, err)
+	require.NotNil(t, build)
+
+	script, _ := build(10)
+	require.NotNil(t, script)
+	require.GreaterOrEqual(t, len(script.Statements), 2)
+	stmt, ok := script.Statements[0].(*semantic.ErrorStatement)
+	require.True(t, ok)
+	assert.Equal(t, 11, stmt.Diagnostic.Start.Line)
+	assert.IsType(t, &semantic.SelectStatement{}, script.Statements[len(script.Statements)-1])
+}
+
+func TestParseSourceRanges(t *testing.T) {
+	src := `prompt héllo
+select 'é', t.col
+  from t;
+exec proc('ü');`
+	script, err := ParseScript(src)
+	require.Nil(t, err)
+	require.Len(t, script.Statements, 3)
+
+	var walk func(node semantic.AstNode)
+	walk = func(node semantic.AstNode) {
+		if n, ok := node.(semantic.Node); ok {
+			assert.False(t, n.Range().IsZero(), "%T has no range", node)
+		}
+		for _, child := range semantic.GetChildren(node) {
+			walk(child)
+		}
+	}
+	walk(script)
+
+	cmd, ok := script.Statements[0].(*semantic.SqlPlusCommand)
+	require.True(t, ok)
+	assert.Equal(t, "prompt héllo", semantic.SourceText(cmd, src))
+
+	stmt, ok := script.Statements[1].(*semantic.SelectStatement)
+	require.True(t, ok)
+	// the statement may or may not take the terminating semicolon along
+	assert.Equal(t, "select 'é', t.col\n  from t", strings.TrimSuffix(semantic.SourceText(stmt, src), ";"))
+	assert.Equal(t, semantic.Position{Line: 2, Column: 1, Offset: 14}, stmt.Range().Start)
+	assert.Equal(t, 3, stmt.Range().End.Line)
+
+	var dot *semantic.DotExpression
+	var find func(node semantic.AstNode)
+	find = func(node semantic.AstNode) {
+		if d, ok := node.(*semantic.DotExpression); ok && dot == nil {
+			dot = d
+		}
+		for _, child := range semantic.GetChildren(node) {
+			find(child)
+		}
+	}
+	find(stmt)
+	require.NotNil(t, dot)
+	assert.Equal(t, "t.col", semantic.SourceText(dot, src))
+	assert.Equal(t, "col", semantic.SourceText(dot.Name, src))
+	assert.Equal(t, 13, dot.Range().Start.Column)
+
+	exec, ok := script.Statements[2].(*semantic.SqlPlusCommand)
+	require.True(t, ok)
+	require.NotNil(t, exec.Call)
+	assert.Equal(t, "proc('ü')", semantic.SourceText(exec.Call, src))
+	assert.Equal(t, 4, exec.Call.Line())
+	assert.Equal(t, 6, exec.Call.Column())
+}
+
+func TestParseQualifiedNames(t *testing.T) {
+	script, err := ParseScript(`create or replace package body "My.Pkg" as
+procedure run is
+begin
+	nthis."Sub.Pkg".run_all(1);
+end;
+end;`)
+	require.Nil(t, err)
+	require.Len(t, script.Statements, 1)
+	body, ok := script.Statements[0].(*semantic.CreatePackageBodyStatement)
+	require.True(t, ok)
+	assert.Equal(t, semantic.QualifiedName{{Name: "My.Pkg", Quoted: true}}, body.QualifiedName())
+	require.Len(t, body.Procedures, 1)
+	assert.True(t, body.Procedures[0].QualifiedName().Last().EqualFold("RUN"))
+
+	call, ok := body.Procedures[0].Body.Statements[0].(*semantic.ProcedureCall)
+	require.True(t, ok)
+	dot, ok := call.Name.(*semantic.DotExpression)
+	require.True(t, ok)
+	assert.Equal(t, `NTHIS."Sub.Pkg".RUN_ALL`, dot.QualifiedName().String())
+	parent, ok := dot.Parent.(*semantic.DotExpression)
+	require.True(t, ok)
+	assert.Equal(t, `"Sub.Pkg"`, parent.Name.(*semantic.NameExpression).Name)
+}
+
+func clearPositions(node semantic.AstNode) {
+	semantic.Inspect(node, func(n semantic.AstNode) bool {
+		if p, ok := n.(semantic.SetPosition); ok {
+			p.SetLine(0)
+			p.SetColumn(0)
+			p.SetSpan(semantic.Span{})
+			p.SetRange(semantic.Range{})
+		}
+		return true
+	})
+}
+
+func TestFormat(t *testing.T) {
+	src := `create or replace procedure archive(cutoff date, batch number) is
+	total number := 0;
+	cursor stale is select id from orders where created < cutoff;
+	overflow exception;
+begin
+	total := (batch + 1) * 2;
+	if total > 10 and cutoff is not null then
+		update accounts a set a.status = 'closed' where a.id = batch;
+	elsif not total = 0 then
+		delete from orders where id in (1, 2, 3);
+	else
+		null;
+	end if;
+	loop
+		exit when total between 1 and 5;
+		total := total - 1;
+	end loop;
+	open stale;
+	close stale;
+	insert into audit (id, note) values (batch, 'archived');
+	commit;
+end;
+/
+select o.id, count(*) cnt from orders o where exists (select 1 from items i where i.order_id = o.id) and o.note like 'x%';
+create or replace trigger audit_orders
+before update of amount on orders
+referencing new as n
+for each row
+when (n.amount > 0)
+begin
+	:n.amount := 0;
+end;
+/
+`
+	script, err := ParseScript(src)
+	require.Nil(t, err)
+
+	proc := script.Statements[0].(*semantic.CreateProcedureStatement)
+	open := proc.Body.Statements[3].(*semantic.OpenStatement)
+	assert.Equal(t, "stale", open.Name)
+	closeStmt := proc.Body.Statements[4].(*semantic.CloseStatement)
+	assert.Equal(t, "stale", closeStmt.Name)
+	query := script.Statements[1].(*semantic.SelectStatement)
+	from := query.From.TableRefs[0]
+	assert.Equal(t, "orders", from.Table)
+	assert.Equal(t, "o", from.Alias)
+
+	clearPositions(script)
+	for _, tc := range []struct {
+		name string
+		opts *semantic.FormatOptions
+		want string
+	}{
+		{"default", nil, "CREATE OR REPLACE PROCEDURE archive"},
+		{"lower", &semantic.FormatOptions{Keywords: semantic.LowerKeywords}, "create or replace procedure archive"},
+		{"indent", &semantic.FormatOptions{Indent: "\t"}, "\n\ttotal := (batch + 1) * 2;"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf strings.Builder
+			require.Nil(t, semantic.Format(&buf, script, tc.opts))
+			assert.Contains(t, buf.String(), tc.want)
+
+			again, err := ParseScript(buf.String())
+			require.Nil(t, err, buf.String())
+			clearPositions(again)
+			assert.Equal(t, script, again)
+		})
+	}
+
+	t.Run("declarations", func(t *testing.T) {
+		script, err := ParseScript(`create or replace package orders_api is
+	type id_list is table of number index by pls_integer;
+	type names is table of varchar2(30);
+	function total(id number, since date) return number;
+end;`)
+		require.Nil(t, err)
+		pkg := script.Statements[0].(*semantic.CreatePackageStatement)
+		var lists []*semantic.NestTableTypeDeclaration
+		var fn *semantic.FunctionDeclaration
+		semantic.Inspect(pkg, func(n semantic.AstNode) bool {
+			switch n := n.(type) {
+			case *semantic.NestTableTypeDeclaration:
+				lists = append(lists, n)
+			case *semantic.FunctionDeclaration:
+				fn = n
+			}
+			return true
+		})
+		require.Len(t, lists, 2)
+		assert.Equal(t, "number", lists[0].ElementType)
+		assert.Equal(t, "pls_integer", lists[0].IndexBy)
+		assert.Equal(t, "varchar2(30)", lists[1].ElementType)
+		assert.Equal(t, "", lists[1].IndexBy)
+		require.NotNil(t, fn)
+		assert.Equal(t, "number", fn.Return)
+		require.Len(t, fn.Parameters, 2)
+		assert.Equal(t, "since", fn.Parameters[1].Name)
+	})
+}
+
+func TestDiffReformatted(t *testing.T) {
+	before, err := ParseScript(`create or replace package body pkg_billing is
+	procedure charge(id number) is
+	begin
+		update accounts set balance = 0 where id = id;
+	end;
+	procedure refund(id number) is
+	begin
+		null;
+	end;
+end;`)
+	require.Nil(t, err)
+	reformatted, err := ParseScript(`create or replace package body pkg_billing is
+  procedure charge(id number) is begin update   accounts set balance = 0 where id = id; end;
+  procedure refund(id number) is begin null; end;
+end;`)
+	require.Nil(t, err)
+	assert.Empty(t, semantic.Diff(before, reformatted))
+}
+
+func TestParseJsonXmlFunctions(t *testing.T) {
+	script, err := ParseScript(`select json_value(doc, '$.price' returning number default 0 on error),
+  json_query(doc, '$.items' with conditional array wrapper empty array on empty),
+  json_object('id' value id, 'name' value name absent on null returning clob),
+  xmlagg(xmlelement("item", xmlattributes(id as "id"), name) order by id).getClobVal()
+from orders;
+select jt.sku, jt.qty from orders o,
+  json_table(o.doc, '$.items[*]' null on error
+    columns (sku varchar2(20) path '$.sku', qty number path '$.qty' default 1 on empty,
+      nested path '$.tags[*]' columns (tag varchar2(10) path '$'))) jt;
+select x.id from xmltable('/rows/row' passing xmltype(:doc)
+  columns id number path '@id', rn for ordinality) x;`)
+	require.Nil(t, err)
+	require.Len(t, script.Statements, 3)
+
+	fields := script.Statements[0].(*semantic.SelectStatement).Fields.Fields
+	require.Len(t, fields, 4)
+	value, ok := fields[0].Expr.(*semantic.JsonValueExpression)
+	require.True(t, ok)
+	assert.Equal(t, "JSON_VALUE", value.Function)
+	assert.Equal(t, "$.price", value.Path)
+	assert.Equal(t, "number", value.Returning)
+	require.NotNil(t, value.OnError)
+	assert.Equal(t, "DEFAULT", value.OnError.Action)
+	assert.IsType(t, &semantic.NumericLiteral{}, value.OnError.Default)
+
+	query, ok := fields[1].Expr.(*semantic.JsonValueExpression)
+	require.True(t, ok)
+	assert.Equal(t, "JSON_QUERY", query.Function)
+	assert.Equal(t, "with conditional array wrapper", query.Wrapper)
+	require.NotNil(t, query.OnEmpty)
+	assert.Equal(t, "EMPTY ARRAY", query.OnEmpty.Action)
+
+	object, ok := fields[2].Expr.(*semantic.JsonObjectExpression)
+	require.True(t, ok)
+	require.Len(t, object.Entries, 2)
+	assert.Equal(t, "'id'", object.Entries[0].Key.(*semantic.StringLiteral).Value)
+	require.NotNil(t, object.OnNull)
+	assert.Equal(t, "ABSENT", object.OnNull.Action)
+	assert.Equal(t, "clob", object.Returning)
+
+	dot, ok := fields[3].Expr.(*semantic.DotExpression)
+	require.True(t, ok)
+	agg, ok := dot.Parent.(*semantic.XmlAggExpression)
+	require.True(t, ok)
+	require.NotNil(t, agg.OrderBy)
+	element, ok := agg.Expr.(*semantic.XmlElementExpression)
+	require.True(t, ok)
+	assert.Equal(t, `"item"`, element.Name.(*semantic.NameExpression).Name)
+	require.Len(t, element.Attributes, 1)
+	assert.Equal(t, `"id"`, element.Attributes[0].(*semantic.AliasExpression).Alias)
+	assert.Len(t, element.Content, 1)
+
+	refs := script.Statements[1].(*semantic.SelectStatement).From.TableRefs
+	require.Len(t, refs, 2)
+	table, ok := refs[1].Source.(*semantic.JsonTableExpression)
+	require.True(t, ok)
+	assert.Equal(t, "$.items[*]", table.Path)
+	assert.Equal(t, "NULL", table.OnError.Action)
+	require.Len(t, table.Columns, 3)
+	assert.Equal(t, "sku", table.Columns[0].Name)
+	assert.Equal(t, "varchar2(20)", table.Columns[0].DataType)
+	assert.Equal(t, "$.sku", table.Columns[0].Path)
+	assert.Equal(t, "DEFAULT", table.Columns[1].OnEmpty.Action)
+	require.Len(t, table.Columns[2].Nested, 1)
+	assert.Equal(t, "$.tags[*]", table.Columns[2].Path)
+
+	refs = script.Statements[2].(*semantic.SelectStatement).From.TableRefs
+	require.Len(t, refs, 1)
+	xml, ok := refs[0].Source.(*semantic.XmlTableExpression)
+	require.True(t, ok)
+	assert.Equal(t, "'/rows/row'", xml.Query.(*semantic.StringLiteral).Value)
+	assert.Len(t, xml.Passing, 1)
+	require.Len(t, xml.Columns, 2)
+	assert.Equal(t, "id", xml.Columns[0].Name)
+	assert.Equal(t, "@id", xml.Columns[0].Path)
+	assert.True(t, xml.Columns[1].ForOrdinality)
+}
+
+func TestParseStream(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("begin\n  x := q'[;\n/]';\nend;\n/\n")
+	// large enough to be split into several chunks
+	for i := 0; i < 5000; i++ {
+		fmt.Fprintf(&sb, "select 'é;%d' from dual;\n", i)
+	}
+	sb.WriteString("select * from from;\n")
+	src := sb.String()
+
+	var stmts []semantic.Statement
+	var errs []error
+	stream := ParseStream(strings.NewReader(src))
+	for {
+		stmt, err := stream.Next()
+		if err == io.EOF {
+			break
+		}
+		if stmt != nil {
+			stmts = append(stmts, stmt)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	require.Len(t, stmts, 5002)
+	assert.IsType(t, &semantic.BlockStatement{}, stmts[0])
+
+	last := stmts[5000]
+	assert.IsType(t, &semantic.SelectStatement{}, last)
+	assert.Equal(t, 5005, last.Line())
+	assert.Equal(t, "select 'é;4999' from dual", strings.TrimSuffix(semantic.SourceText(last, src), ";"))
+
+	stmt, ok := stmts[5001].(*semantic.ErrorStatement)
+	require.True(t, ok)
+	assert.Equal(t, 5006, stmt.Line())
+	require.Len(t, errs, 1)
+	d, ok := errs[0].(semantic.Diagnostic)
+	require.True(t, ok)
+	assert.Equal(t, 5006, d.Start.Line)
+	assert.Equal(t, "from", src[d.Start.Offset:d.End.Offset])
+
+	_, err := stream.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestParseStreamSyntaxErrors(t *testing.T) {
+	src := `select * from from;
+update t set a = 1 where b = = 2 and c = = 3;
+select 1 from dual;
+`
+	syntaxErrors := func(err error) []string {
+		var got []string
+		for _, d := range semantic.Diagnostics(err) {
+			if d.Code == semantic.CodeSyntax {
+				got = append(got, d.Error())
+			}
+		}
+		return got
+	}
+	_, err := ParseScriptOptions(context.Background(), src, Options{})
+	batch := syntaxErrors(err)
+	require.GreaterOrEqual(t, len(batch), 2)
+
+	var streamed []string
+	stream := ParseStream(strings.NewReader(src))
+	for {
+		_, err := stream.Next()
+		if err == io.EOF {
+			break
+		}
+		streamed = append(streamed, syntaxErrors(err)...)
+	}
+	assert.Equal(t, batch, streamed)
+}
+
+func TestSplitScriptUnits(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+	}{
+		{"header on one line", []string{"create or replace package body pkg as", "  procedure p is begin null; end;", "end pkg;"}},
+		{"header over two lines", []string{"CREATE OR REPLACE", "PACKAGE BODY pkg AS", "  PROCEDURE p IS BEGIN NULL; END;", "END pkg;"}},
+		{"comment in header", []string{"create or replace -- the billing package", "editionable", "package pkg as", "  procedure p;", "end;"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := SplitScript(strings.NewReader(""))
+			for _, line := range tt.lines {
+				s.scan(line)
+				assert.False(t, s.between(), line)
+			}
+			s.scan("/")
+			assert.True(t, s.between())
+		})
+	}
+}
+
+func TestParseContext(t *testing.T) {
+	var sb strings.Builder
+	for i := 0; i < 3000; i++ {
+		fmt.Fprintf(&sb, "select %d from dual;\n", i)
+	}
+	src := sb.String()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	script, err := ParseScriptContext(ctx, src)
+	require.NotNil(t, script)
+	require.NotEmpty(t, script.Statements)
+	require.Less(t, len(script.Statements), 3000)
+	for i, stmt := range script.Statements {
+		require.IsType(t, &semantic.SelectStatement{}, stmt)
+		assert.Equal(t, i+1, stmt.Line())
+	}
+	diags := semantic.Diagnostics(err)
+	require.Len(t, diags, 1)
+	assert.Equal(t, semantic.CodeTimeout, diags[0].Code)
+	assert.Equal(t, len(script.Statements)+1, diags[0].Start.Line)
+
+	script, err = ParseScriptContext(context.Background(), src)
+	assert.Nil(t, err)
+	assert.Len(t, script.Statements, 3000)
+
+	block := "begin\n" + strings.Repeat("  x := x + 1;\n", 3000) + "end;"
+	script, err = ParseBlockContext(ctx, block)
+	require.NotNil(t, script)
+	assert.Empty(t, script.Statements)
+	diags = semantic.Diagnostics(err)
+	require.Len(t, diags, 1)
+	assert.Equal(t, semantic.CodeTimeout, diags[0].Code)
+}
+
+func TestParseVersions(t *testing.T) {
+	src := `select a from t fetch first 3 rows only;
+select extract(year from sysdate) from dual;
+create table t (id number generated always as identity, data json);
+`
+	script, err := ParseScript(src)
+	require.NotNil(t, script)
+	assert.Equal(t, semantic.Version21, script.MinVersion)
+	for _, d := range semantic.Diagnostics(err) {
+		assert.NotEqual(t, semantic.CodeVersion, d.Code)
+	}
+
+	script, err = ParseScriptOptions(context.Background(), src, Options{Version: semantic.Version11})
+	require.NotNil(t, script)
+	var messages []string
+	for _, d := range semantic.Diagnostics(err) {
+		if d.Code == semantic.CodeVersion {
+			messages = append(messages, d.Error())
+		}
+	}
+	assert.Equal(t, []string{
+		"1:17: FETCH FIRST requires Oracle 12c+",
+		"3:47: identity columns requires Oracle 12c+",
+		"3:62: JSON columns requires Oracle 21c+",
+	}, messages)
+
+	build, _ := ParseSqlOptions(context.Background(), src, Options{Version: semantic.Version12})
+	require.NotNil(t, build)
+	script, err = build(10)
+	require.NotNil(t, script)
+	messages = nil
+	for _, d := range semantic.Diagnostics(err) {
+		if d.Code == semantic.CodeVersion {
+			messages = append(messages, d.Error())
+		}
+	}
+	assert.Equal(t, []string{"13:62: JSON columns requires Oracle 21c+"}, messages)
+
+	v, err := semantic.ParseVersion("23ai")
+	assert.Nil(t, err)
+	assert.Equal(t, semantic.Version23, v)
+}
+
+// loadCorpus returns the scripts of the test cases in this file that parse
+// without errors.
+func loadCorpus(b *testing.B) (corpus []string, size int64) {
+	file, err := goparser.ParseFile(token.NewFileSet(), "parser_test.go", nil, 0)
+	require.Nil(b, err)
+	ast.Inspect(file, func(n ast.Node) bool {
+		kv, ok := n.(*ast.KeyValueExpr)
+		if !ok {
+			return true
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok || key.Name != "text" {
+			return true
+		}
+		lit, ok := kv.Value.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return false
+		}
+		text, err := strconv.Unquote(lit.Value)
+		require.Nil(b, err)
+		p := plsql.NewParser(text)
+		p.Sql_script()
+		if p.Error() == nil {
+			corpus = append(corpus, text)
+			size += int64(len(text))
+		}
+		p.Release()
+		return false
+	})
+	require.NotEmpty(b, corpus)
+	return corpus, size
+}
+
+func benchmarkParser(b *testing.B, ll bool) {
+	corpus, size := loadCorpus(b)
+	b.SetBytes(size)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, text := range corpus {
+			p := plsql.NewParser(text)
+			p.LL = ll
+			p.Sql_script()
+			if p.Error() != nil {
+				b.Fatal(p.Error())
+			}
+			p.Release()
+		}
+	}
+}
+
+// BenchmarkParseLL parses the corpus in full LL mode only, as the parser
+// used to.
+func BenchmarkParseLL(b *testing.B) {
+	benchmarkParser(b, true)
+}
+
+// BenchmarkParseTwoStage parses the corpus trying SLL first.
+func BenchmarkParseTwoStage(b *testing.B) {
+	benchmarkParser(b, false)
+}
+
+func BenchmarkParseScript(b *testing.B) {
+	corpus, size := loadCorpus(b)
+	b.SetBytes(size)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, text := range corpus {
+			_, _ = ParseScript(text)
+		}
+	}
+}
+
+func BenchmarkParseScriptParallel(b *testing.B) {
+	corpus, size := loadCorpus(b)
+	b.SetBytes(size)
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			for _, text := range corpus {
+				_, _ = ParseScript(text)
+			}
+		}
+	})
+}
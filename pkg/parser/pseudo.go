@@ -0,0 +1,111 @@
+package parser
+
+import (
+	"strings"
+
+	"procinspect/pkg/semantic"
+)
+
+// pseudoColumns are the names that stand for a pseudo-column, or for a
+// function called without arguments, when they are not qualified.
+var pseudoColumns = map[string]bool{
+	"ROWNUM":             true,
+	"ROWID":              true,
+	"ORA_ROWSCN":         true,
+	"LEVEL":              true,
+	"CONNECT_BY_ISLEAF":  true,
+	"CONNECT_BY_ISCYCLE": true,
+	"COLUMN_VALUE":       true,
+	"OBJECT_ID":          true,
+	"OBJECT_VALUE":       true,
+	"SYSDATE":            true,
+	"SYSTIMESTAMP":       true,
+	"CURRENT_DATE":       true,
+	"CURRENT_TIMESTAMP":  true,
+	"LOCALTIMESTAMP":     true,
+	"DBTIMEZONE":         true,
+	"SESSIONTIMEZONE":    true,
+	"USER":               true,
+	"UID":                true,
+}
+
+// rowPseudoColumns are the pseudo-columns that may be qualified by a table.
+var rowPseudoColumns = map[string]bool{
+	"ROWID":      true,
+	"ORA_ROWSCN": true,
+}
+
+// recognize returns the sequence reference, pseudo-column, correlation
+// name or implicit cursor attribute expr stands for, holding expr, or expr
+// itself when it is none of them.
+func recognize(expr semantic.Expr) semantic.Expr {
+	switch e := expr.(type) {
+	case *semantic.NameExpression:
+		if name := strings.ToUpper(e.Name); pseudoColumns[name] {
+			return &semantic.PseudoColumn{Name: name, Expr: e}
+		}
+	case *semantic.CursorAttribute:
+		if strings.EqualFold(e.Cursor, "SQL") {
+			return &semantic.SqlCursorAttribute{Attr: e.Attr, Expr: e}
+		}
+	case *semantic.DotExpression:
+		return recognizeDot(e)
+	}
+	return expr
+}
+
+func recognizeDot(expr *semantic.DotExpression) semantic.Expr {
+	parts := dotParts(expr)
+	last, ok := parts[len(parts)-1].(*semantic.NameExpression)
+	if !ok || len(parts) < 2 {
+		return expr
+	}
+	if bind, ok := parts[0].(*semantic.BindNameExpression); ok && len(parts) == 2 {
+		name, ok := bind.Name.(*semantic.NameExpression)
+		if !ok {
+			return expr
+		}
+		switch row := strings.ToUpper(strings.TrimPrefix(name.Name, ":")); row {
+		case "NEW", "OLD", "PARENT":
+			return &semantic.CorrelationReference{Row: row, Name: last.Name, Expr: expr}
+		}
+		return expr
+	}
+
+	qualifier := make([]string, 0, len(parts)-1)
+	for _, part := range parts[:len(parts)-1] {
+		name, ok := part.(*semantic.NameExpression)
+		if !ok {
+			return expr
+		}
+		qualifier = append(qualifier, name.Name)
+	}
+	switch name := strings.ToUpper(last.Name); {
+	case name == "NEXTVAL" || name == "CURRVAL":
+		return &semantic.SequenceReference{Sequence: strings.Join(qualifier, "."), Pseudo: name, Expr: expr}
+	case rowPseudoColumns[name]:
+		return &semantic.PseudoColumn{Name: name, Table: strings.Join(qualifier, "."), Expr: expr}
+	}
+	return expr
+}
+
+// dotParts returns the names of the chain of DotExpressions expr, from the
+// outermost qualifier in.
+func dotParts(expr *semantic.DotExpression) []semantic.Expr {
+	var parts []semantic.Expr
+	for {
+		parts = append(parts, expr.Name)
+		parent, ok := expr.Parent.(*semantic.DotExpression)
+		if !ok {
+			if expr.Parent != nil {
+				parts = append(parts, expr.Parent)
+			}
+			break
+		}
+		expr = parent
+	}
+	for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
+		parts[i], parts[j] = parts[j], parts[i]
+	}
+	return parts
+}
@@ -0,0 +1,216 @@
+package parser
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/antlr4-go/antlr/v4"
+
+	plsql "procinspect/pkg/parser/internal/plsql/parser"
+	"procinspect/pkg/semantic"
+)
+
+// tokenRange returns the range from the start of the start token to the end
+// of the stop token. Its offsets are character indexes until finishRanges
+// turns them into byte offsets.
+func tokenRange(start, stop antlr.Token) semantic.Range {
+	r := semantic.Range{Start: semantic.Position{
+		Line:   start.GetLine(),
+		Column: start.GetColumn() + 1,
+		Offset: start.GetStart(),
+	}}
+	if stop == nil || stop.GetStop() < start.GetStart() {
+		stop = start
+	}
+	r.End = tokenEnd(stop)
+	return r
+}
+
+// tokenEnd returns the position just past the last character of tok.
+func tokenEnd(tok antlr.Token) semantic.Position {
+	pos := semantic.Position{Line: tok.GetLine(), Column: tok.GetColumn() + 1, Offset: tok.GetStart()}
+	if tok.GetTokenType() == antlr.TokenEOF {
+		return pos
+	}
+	text := tok.GetText()
+	pos.Column += utf8.RuneCountInString(text)
+	pos.Offset = tok.GetStop() + 1
+	if i := strings.LastIndexByte(text, '\n'); i >= 0 {
+		pos.Line += strings.Count(text, "\n")
+		pos.Column = utf8.RuneCountInString(text[i+1:]) + 1
+	}
+	return pos
+}
+
+// finishRanges turns the character offsets the visitor recorded in the
+// ranges below root into byte offsets, and gives the nodes made up by the
+// visitor, which have no range, the range of their children or, failing
+// that, the range of their parent.
+func finishRanges(root semantic.AstNode, offsets *plsql.OffsetMap) {
+	seen := make(map[semantic.AstNode]bool)
+	var convert func(node semantic.AstNode) semantic.Range
+	convert = func(node semantic.AstNode) semantic.Range {
+		n, ok := node.(rangeNode)
+		if seen[node] {
+			if ok {
+				return n.Range()
+			}
+			return semantic.Range{}
+		}
+		seen[node] = true
+
+		var children semantic.Range
+		for _, child := range semantic.GetChildren(node) {
+			children = unionRange(children, convert(child))
+		}
+		if !ok {
+			return children
+		}
+		r := n.Range()
+		if r.IsZero() {
+			if !children.IsZero() {
+				setRange(n, children)
+			}
+			return children
+		}
+		r.Start.Offset = offsets.ByteOffset(r.Start.Offset)
+		r.End.Offset = offsets.ByteOffset(r.End.Offset)
+		n.SetRange(r)
+		return r
+	}
+	convert(root)
+
+	var inherit func(node semantic.AstNode, parent semantic.Range)
+	inherit = func(node semantic.AstNode, parent semantic.Range) {
+		if n, ok := node.(rangeNode); ok {
+			if n.Range().IsZero() && !parent.IsZero() {
+				setRange(n, parent)
+			}
+			parent = n.Range()
+		}
+		for _, child := range semantic.GetChildren(node) {
+			inherit(child, parent)
+		}
+	}
+	inherit(root, semantic.Range{})
+}
+
+type rangeNode interface {
+	semantic.Node
+	semantic.SetPosition
+}
+
+// setRange positions a node made up by the visitor at r.
+func setRange(n rangeNode, r semantic.Range) {
+	n.SetRange(r)
+	if n.Line() == 0 {
+		n.SetLine(r.Start.Line)
+		n.SetColumn(r.Start.Column)
+	}
+}
+
+func unionRange(a, b semantic.Range) semantic.Range {
+	switch {
+	case a.IsZero():
+		return b
+	case b.IsZero():
+		return a
+	}
+	if b.Start.Offset < a.Start.Offset {
+		a.Start = b.Start
+	}
+	if b.End.Offset > a.End.Offset {
+		a.End = b.End
+	}
+	return a
+}
+
+// shiftRanges moves the ranges below root, which were parsed from a text
+// starting at a different place than the source they belong to, by the
+// given number of bytes, and by the given number of columns on line.
+func shiftRanges(root semantic.AstNode, line, columns, bytes int) {
+	shift := func(p semantic.Position) semantic.Position {
+		if p.Line == line {
+			p.Column += columns
+		}
+		p.Offset += bytes
+		return p
+	}
+	eachRangeNode(root, func(n rangeNode) {
+		r := n.Range()
+		n.SetRange(semantic.Range{Start: shift(r.Start), End: shift(r.End)})
+		if n.Line() == line {
+			n.SetColumn(n.Column() + columns)
+		}
+	})
+}
+
+// moveRanges moves the nodes below root, which were parsed from a piece of
+// a larger source, to their place in it. The piece starts at a line start,
+// after the given number of lines, characters and bytes.
+func moveRanges(root semantic.AstNode, lines, runes, bytes int) {
+	move := func(p semantic.Position) semantic.Position {
+		p.Line += lines
+		p.Offset += bytes
+		return p
+	}
+	eachRangeNode(root, func(n rangeNode) {
+		r := n.Range()
+		n.SetRange(semantic.Range{Start: move(r.Start), End: move(r.End)})
+		n.SetLine(n.Line() + lines)
+		span := n.Span()
+		n.SetSpan(semantic.Span{Start: span.Start + runes, End: span.End + runes})
+	})
+}
+
+// eachRangeNode calls fn once for each node below root that has a range.
+func eachRangeNode(root semantic.AstNode, fn func(rangeNode)) {
+	seen := make(map[semantic.AstNode]bool)
+	var walk func(node semantic.AstNode)
+	walk = func(node semantic.AstNode) {
+		if seen[node] {
+			return
+		}
+		seen[node] = true
+		if n, ok := node.(rangeNode); ok && !n.Range().IsZero() {
+			fn(n)
+		}
+		for _, child := range semantic.GetChildren(node) {
+			walk(child)
+		}
+	}
+	walk(root)
+}
+
+// positionAt returns the position of the byte offset in src.
+func positionAt(src string, offset int) semantic.Position {
+	if offset > len(src) {
+		offset = len(src)
+	}
+	lineStart := strings.LastIndexByte(src[:offset], '\n') + 1
+	return semantic.Position{
+		Line:   strings.Count(src[:offset], "\n") + 1,
+		Column: utf8.RuneCountInString(src[lineStart:offset]) + 1,
+		Offset: offset,
+	}
+}
+
+// subRange returns the range of src[from:to], where src is the source text
+// starting at start. Like tokenRange, its offsets are character indexes.
+func subRange(start antlr.Token, src string, from, to int) semantic.Range {
+	at := func(i int) semantic.Position {
+		p := semantic.Position{
+			Line:   start.GetLine(),
+			Column: start.GetColumn() + 1,
+			Offset: start.GetStart() + utf8.RuneCountInString(src[:i]),
+		}
+		if nl := strings.LastIndexByte(src[:i], '\n'); nl >= 0 {
+			p.Line += strings.Count(src[:i], "\n")
+			p.Column = utf8.RuneCountInString(src[nl+1:i]) + 1
+		} else {
+			p.Column += utf8.RuneCountInString(src[:i])
+		}
+		return p
+	}
+	return semantic.Range{Start: at(from), End: at(to)}
+}
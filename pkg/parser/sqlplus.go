@@ -0,0 +1,483 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	plsql "procinspect/pkg/parser/internal/plsql/parser"
+	"procinspect/pkg/semantic"
+)
+
+// SqlPlusOptions controls the SQL*Plus handling of ParseScriptFile and, as
+// Options.SqlPlus, of ParseScriptOptions.
+type SqlPlusOptions struct {
+	// Substitute expands &name and &&name references with DEFINEd values
+	// before parsing, unless the script turns it off with SET DEFINE OFF.
+	Substitute bool
+	// Defines holds the variables defined before the script starts.
+	Defines map[string]string
+}
+
+// sqlplusCommands maps the SQL*Plus command words, abbreviations
+// included, to the name recorded in semantic.SqlPlusCommand.
+var sqlplusCommands = map[string]string{
+	"ACCEPT":     "ACCEPT",
+	"ACC":        "ACCEPT",
+	"BREAK":      "BREAK",
+	"BTITLE":     "BTITLE",
+	"CLEAR":      "CLEAR",
+	"COLUMN":     "COLUMN",
+	"COL":        "COLUMN",
+	"COMPUTE":    "COMPUTE",
+	"CONNECT":    "CONNECT",
+	"CONN":       "CONNECT",
+	"DEFINE":     "DEFINE",
+	"DEF":        "DEFINE",
+	"DESCRIBE":   "DESCRIBE",
+	"DESC":       "DESCRIBE",
+	"DISCONNECT": "DISCONNECT",
+	"EXECUTE":    "EXECUTE",
+	"EXEC":       "EXECUTE",
+	"EXIT":       "EXIT",
+	"QUIT":       "EXIT",
+	"HOST":       "HOST",
+	"PAUSE":      "PAUSE",
+	"PRINT":      "PRINT",
+	"PROMPT":     "PROMPT",
+	"PRO":        "PROMPT",
+	"SET":        "SET",
+	"SHOW":       "SHOW",
+	"SHO":        "SHOW",
+	"SPOOL":      "SPOOL",
+	"SPO":        "SPOOL",
+	"START":      "START",
+	"STA":        "START",
+	"TIMING":     "TIMING",
+	"TTITLE":     "TTITLE",
+	"UNDEFINE":   "UNDEFINE",
+	"UNDEF":      "UNDEFINE",
+	"VARIABLE":   "VARIABLE",
+	"VAR":        "VARIABLE",
+	"WHENEVER":   "WHENEVER",
+}
+
+// sqlSetStatements are the SQL statements starting with SET, which must not
+// be taken for the SQL*Plus SET command.
+var sqlSetStatements = map[string]bool{
+	"TRANSACTION": true,
+	"ROLE":        true,
+	"CONSTRAINT":  true,
+	"CONSTRAINTS": true,
+}
+
+type sqlplusState struct {
+	substitute bool
+	prefix     byte
+	defines    map[string]string
+}
+
+func newSqlPlusState(opts SqlPlusOptions) *sqlplusState {
+	st := &sqlplusState{
+		substitute: opts.Substitute,
+		prefix:     '&',
+		defines:    make(map[string]string, len(opts.Defines)),
+	}
+	for name, value := range opts.Defines {
+		st.defines[strings.ToUpper(name)] = value
+	}
+	return st
+}
+
+// preprocess takes the SQL*Plus commands out of src, leaving blank lines in
+// their place so that the positions of the remaining statements are kept.
+// It expands substitution variables on the way when enabled.
+func (st *sqlplusState) preprocess(src string) (string, []*semantic.SqlPlusCommand, error) {
+	var (
+		cmds      []*semantic.SqlPlusCommand
+		err       error
+		inStmt    bool
+		inBlock   bool
+		inComment bool
+		// header holds the text of the statement until blockStart
+		// knows whether it is a PL/SQL unit.
+		header string
+		known  bool
+	)
+	lines := strings.Split(src, "\n")
+	// starts holds the byte offset of each line in the preprocessed text
+	starts := make([]int, len(lines)+1)
+	for i := 0; i < len(lines); i++ {
+		if st.substitute && st.prefix != 0 {
+			lines[i] = st.expand(lines[i])
+		}
+		starts[i+1] = starts[i] + len(lines[i]) + 1
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if inComment {
+			inComment = !strings.Contains(trimmed, "*/")
+			continue
+		}
+		if !inStmt {
+			switch {
+			case trimmed == "", strings.HasPrefix(trimmed, "--"):
+				continue
+			case strings.HasPrefix(trimmed, "/*"):
+				inComment = !strings.Contains(trimmed[2:], "*/")
+				continue
+			case trimmed == "/":
+				continue
+			}
+			if name, ok := commandName(trimmed); ok {
+				cmd := &semantic.SqlPlusCommand{Name: name}
+				indent := len(line) - len(strings.TrimLeft(line, " \t"))
+				cmd.SetLine(i + 1)
+				cmd.SetColumn(indent + 1)
+				r := semantic.Range{Start: semantic.Position{Line: i + 1, Column: indent + 1, Offset: starts[i] + indent}}
+				text, first := trimmed, line
+				lines[i] = blankLine(line)
+				// a trailing hyphen continues the command on the next line
+				for strings.HasSuffix(text, "-") && i+1 < len(lines) {
+					i++
+					starts[i+1] = starts[i] + len(lines[i]) + 1
+					line = lines[i]
+					text = strings.TrimSuffix(text, "-") + " " + strings.TrimSpace(line)
+					lines[i] = blankLine(line)
+				}
+				end := strings.TrimRight(line, " \t\r")
+				r.End = semantic.Position{Line: i + 1, Column: utf8.RuneCountInString(end) + 1, Offset: starts[i] + len(end)}
+				cmd.SetRange(r)
+				if name == "@" || name == "@@" {
+					cmd.Args = strings.TrimSpace(strings.TrimLeft(text, "@"))
+				} else {
+					cmd.Args = commandArgs(text)
+				}
+				if e := st.apply(cmd, first); e != nil {
+					err = errors.Join(err, e)
+				}
+				cmds = append(cmds, cmd)
+				continue
+			}
+			inStmt = true
+			header, known = "", false
+		}
+		if !known {
+			header += " " + stripLineComment(trimmed)
+			inBlock, known = blockStart(header)
+		}
+		if trimmed == "/" {
+			inStmt = false
+			continue
+		}
+		if !inBlock && strings.HasSuffix(stripLineComment(trimmed), ";") {
+			inStmt = false
+		}
+	}
+	return strings.Join(lines, "\n"), cmds, err
+}
+
+// apply updates the state for the commands that affect the rest of the
+// script and parses the procedure call of EXEC, whose first line is line.
+func (st *sqlplusState) apply(cmd *semantic.SqlPlusCommand, line string) error {
+	switch cmd.Name {
+	case "DEFINE":
+		name, value, ok := strings.Cut(cmd.Args, "=")
+		if !ok {
+			return nil
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '\'' || value[0] == '"') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		st.defines[strings.ToUpper(strings.TrimSpace(name))] = value
+	case "UNDEFINE":
+		for _, name := range strings.Fields(cmd.Args) {
+			delete(st.defines, strings.ToUpper(name))
+		}
+	case "SET":
+		fields := strings.Fields(cmd.Args)
+		if len(fields) != 2 {
+			return nil
+		}
+		if word := strings.ToUpper(fields[0]); word != "DEFINE" && word != "DEF" {
+			return nil
+		}
+		switch value := strings.Trim(fields[1], `'"`); strings.ToUpper(value) {
+		case "OFF":
+			st.prefix = 0
+		case "ON":
+			st.prefix = '&'
+		default:
+			if len(value) == 1 {
+				st.prefix = value[0]
+			}
+		}
+	case "EXECUTE":
+		call := strings.TrimSuffix(strings.TrimSpace(cmd.Args), ";")
+		// pad with newlines so that the call keeps the line of the command
+		src := strings.Repeat("\n", cmd.Line()-1) + "BEGIN " + call + "; END;"
+		p := plsql.NewParser(src)
+		defer p.Release()
+		root := p.Block()
+		if p.Error() != nil {
+			return p.Error()
+		}
+		visitor := newPlSqlVisitor()
+		block, ok := visitor.VisitBlock(root.(*plsql.BlockContext)).(*semantic.BlockStatement)
+		if ok && block.Body != nil && len(block.Body.Statements) == 1 {
+			cmd.Call = block.Body.Statements[0]
+			node, ok := cmd.Call.(semantic.AstNode)
+			if !ok {
+				return visitor.Error()
+			}
+			// move the call from the padded block to its place in the line
+			finishRanges(node, plsql.NewOffsetMap(src))
+			at := strings.Index(line, call)
+			if at < 0 {
+				at = cmd.Column() - 1
+			}
+			lineStart := cmd.Range().Start.Offset - (cmd.Column() - 1)
+			parsed := cmd.Line() - 1 + len("BEGIN ")
+			shiftRanges(node, cmd.Line(),
+				utf8.RuneCountInString(line[:at])-len("BEGIN "), lineStart+at-parsed)
+		}
+		return visitor.Error()
+	}
+	return nil
+}
+
+// expand replaces the &name, &&name and &name. references in line with
+// their defined values. Undefined variables are left as they are.
+func (st *sqlplusState) expand(line string) string {
+	if strings.IndexByte(line, st.prefix) < 0 {
+		return line
+	}
+	var sb strings.Builder
+	for i := 0; i < len(line); {
+		if line[i] != st.prefix {
+			sb.WriteByte(line[i])
+			i++
+			continue
+		}
+		start := i
+		i++
+		if i < len(line) && line[i] == st.prefix {
+			i++
+		}
+		nameStart := i
+		for i < len(line) && isNameChar(line[i]) {
+			i++
+		}
+		value, ok := st.defines[strings.ToUpper(line[nameStart:i])]
+		if nameStart == i || !ok {
+			sb.WriteString(line[start:i])
+			continue
+		}
+		if i < len(line) && line[i] == '.' {
+			i++
+		}
+		sb.WriteString(value)
+	}
+	return sb.String()
+}
+
+func isNameChar(c byte) bool {
+	return c == '_' || c == '$' || c == '#' ||
+		'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+// commandName returns the SQL*Plus command that line starts with, if any.
+func commandName(line string) (string, bool) {
+	if strings.HasPrefix(line, "@@") {
+		return "@@", true
+	}
+	if strings.HasPrefix(line, "@") {
+		return "@", true
+	}
+	fields := strings.Fields(line)
+	name, ok := sqlplusCommands[strings.ToUpper(strings.TrimSuffix(fields[0], ";"))]
+	if ok && name == "SET" && len(fields) > 1 && sqlSetStatements[strings.ToUpper(fields[1])] {
+		return "", false
+	}
+	return name, ok
+}
+
+// commandArgs returns the text following the command word, with runs of
+// whitespace collapsed.
+func commandArgs(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) < 2 {
+		return ""
+	}
+	return strings.Join(fields[1:], " ")
+}
+
+// blockStart reports whether a statement starting with text is a PL/SQL
+// unit, which is terminated by a slash rather than by a semicolon. known is
+// false while text holds no more than the start of a CREATE OR REPLACE
+// header, whose unit keyword may be on a later line; the caller then adds
+// the next line to text and asks again.
+func blockStart(text string) (block, known bool) {
+	fields := strings.Fields(strings.ToUpper(text))
+	if len(fields) == 0 {
+		return false, false
+	}
+	switch fields[0] {
+	case "DECLARE", "BEGIN":
+		return true, true
+	case "CREATE":
+	default:
+		return strings.HasPrefix(fields[0], "<<"), true
+	}
+	for _, word := range fields[1:] {
+		switch word {
+		case "OR", "REPLACE", "EDITIONABLE", "NONEDITIONABLE":
+			continue
+		case "PROCEDURE", "FUNCTION", "PACKAGE", "TRIGGER", "TYPE", "LIBRARY":
+			return true, true
+		}
+		return false, true
+	}
+	return false, false
+}
+
+func stripLineComment(line string) string {
+	if i := strings.Index(line, "--"); i >= 0 && strings.Count(line[:i], "'")%2 == 0 {
+		return strings.TrimSpace(line[:i])
+	}
+	return line
+}
+
+func blankLine(line string) string {
+	// one space per byte keeps the byte offsets of the lines that follow
+	b := []byte(line)
+	for i, c := range b {
+		if c != '\r' && c != '\t' {
+			b[i] = ' '
+		}
+	}
+	return string(b)
+}
+
+// mergeCommands adds the preprocessed commands to script in line order.
+func mergeCommands(script *semantic.Script, cmds []*semantic.SqlPlusCommand) {
+	if len(cmds) == 0 {
+		return
+	}
+	for _, cmd := range cmds {
+		script.Statements = append(script.Statements, cmd)
+	}
+	sort.SliceStable(script.Statements, func(i, j int) bool {
+		return script.Statements[i].Line() < script.Statements[j].Line()
+	})
+}
+
+// ParseScriptFile parses a SQL*Plus script file. The scripts run by @, @@
+// and START are parsed as well and attached to their commands: @@ is
+// resolved against the directory of the calling script, @ and START against
+// the directory of the top-level script.
+func ParseScriptFile(path string, opts SqlPlusOptions) (*semantic.Script, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	r := &includeResolver{
+		root:  filepath.Dir(abs),
+		state: newSqlPlusState(opts),
+	}
+	return r.parseFile(abs)
+}
+
+type includeResolver struct {
+	root  string
+	state *sqlplusState
+	stack []string
+}
+
+func (r *includeResolver) parseFile(path string) (*semantic.Script, error) {
+	for _, p := range r.stack {
+		if p == path {
+			chain := append(r.stack, path)
+			return nil, fmt.Errorf("include cycle: %s", strings.Join(chain, " -> "))
+		}
+	}
+	r.stack = append(r.stack, path)
+	defer func() { r.stack = r.stack[:len(r.stack)-1] }()
+
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	src, cmds, cmdErr := r.state.preprocess(string(text))
+	p := plsql.NewParser(src)
+	defer p.Release()
+	root := p.Sql_script()
+	visitor := newPlSqlVisitor()
+	visitor.syntaxErrors = p.Diagnostics()
+	visitor.failedRules = p.FailedRules()
+	script := visitor.VisitSql_script(root.(*plsql.Sql_scriptContext)).(*semantic.Script)
+	mergeCommands(script, cmds)
+	for _, stmt := range script.Statements {
+		if e, ok := stmt.(*semantic.ErrorStatement); ok {
+			e.Diagnostic.File = path
+		}
+	}
+	err = withFile(errors.Join(cmdErr, p.Error(), visitor.Error()), path)
+
+	for _, stmt := range script.Statements {
+		cmd, ok := stmt.(*semantic.SqlPlusCommand)
+		if !ok || !cmd.IsInclude() {
+			continue
+		}
+		include, e := r.parseFile(r.resolve(path, cmd))
+		if e != nil {
+			err = errors.Join(err, e)
+			continue
+		}
+		cmd.Include = include
+	}
+	return script, err
+}
+
+// resolve returns the path of the script run by cmd from the script at path.
+func (r *includeResolver) resolve(path string, cmd *semantic.SqlPlusCommand) string {
+	name := cmd.Args
+	if fields := strings.Fields(name); len(fields) > 0 {
+		// the remaining words are the script arguments
+		name = fields[0]
+	}
+	if filepath.Ext(name) == "" {
+		name += ".sql"
+	}
+	if filepath.IsAbs(name) {
+		return name
+	}
+	dir := r.root
+	if cmd.Name == "@@" {
+		dir = filepath.Dir(path)
+	}
+	return filepath.Join(dir, name)
+}
+
+// withFile sets the file of the diagnostics in err and prefixes the other
+// errors with it.
+func withFile(err error, file string) error {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case semantic.Diagnostic:
+		e.File = file
+		return e
+	case interface{ Unwrap() []error }:
+		var errs []error
+		for _, inner := range e.Unwrap() {
+			errs = append(errs, withFile(inner, file))
+		}
+		return errors.Join(errs...)
+	}
+	return fmt.Errorf("%s: %w", file, err)
+}
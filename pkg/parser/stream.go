@@ -0,0 +1,329 @@
+package parser
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"procinspect/pkg/parser/internal/plsql/parser"
+	"procinspect/pkg/semantic"
+)
+
+// chunkSize is the size from which the splitter ends a chunk at the next
+// line between two statements.
+const chunkSize = 64 << 10
+
+type (
+	// ScriptSplitter reads a script in chunks of whole lines, each ending
+	// between two statements, so that the chunks can be parsed on their
+	// own. It follows strings, quoted identifiers, q-quotes and comments
+	// across lines.
+	ScriptSplitter struct {
+		r *bufio.Reader
+		// lines, runes and offset count what has been read so far.
+		lines, runes, offset int
+
+		inComment bool
+		// quote is the closing character of the open string or quoted
+		// identifier; for a q-quote it is followed by a single quote.
+		quote  byte
+		qquote bool
+		inStmt bool
+		// inBlock is set for the PL/SQL units, which end with a slash.
+		inBlock bool
+		// header holds the text of the statement until blockStart
+		// knows whether it is a PL/SQL unit.
+		header string
+		known  bool
+		// continued is set when a SQL*Plus command ends with a hyphen.
+		continued bool
+	}
+
+	// ScriptChunk is a piece of a script ending between two statements.
+	// It starts at a line start, after the given number of lines,
+	// characters and bytes of the script.
+	ScriptChunk struct {
+		Text                 string
+		Lines, Runes, Offset int
+	}
+
+	// StatementStream returns the statements of a script parsed by
+	// ParseStream one at a time.
+	StatementStream struct {
+		splitter *ScriptSplitter
+		state    *sqlplusState
+		// items are the statements and diagnostics of the last chunk
+		// that have not been returned yet.
+		items []streamItem
+		done  bool
+	}
+
+	streamItem struct {
+		stmt semantic.Statement
+		err  error
+	}
+)
+
+// SplitScript returns a splitter that reads the script from r in chunks
+// that can be parsed on their own, each ending between two statements.
+func SplitScript(r io.Reader) *ScriptSplitter {
+	return &ScriptSplitter{r: bufio.NewReader(r)}
+}
+
+// Next returns the next chunk of the script, or io.EOF after the last one.
+func (s *ScriptSplitter) Next() (ScriptChunk, error) {
+	chunk := ScriptChunk{Lines: s.lines, Runes: s.runes, Offset: s.offset}
+	var sb strings.Builder
+	for {
+		text, err := s.r.ReadString('\n')
+		if text != "" {
+			sb.WriteString(text)
+			s.lines += strings.Count(text, "\n")
+			s.runes += utf8.RuneCountInString(text)
+			s.offset += len(text)
+			s.scan(strings.TrimRight(text, "\r\n"))
+		}
+		switch {
+		case err == io.EOF:
+			if sb.Len() == 0 {
+				return chunk, io.EOF
+			}
+			chunk.Text = sb.String()
+			return chunk, nil
+		case err != nil:
+			return chunk, err
+		case sb.Len() >= chunkSize && s.between():
+			chunk.Text = sb.String()
+			return chunk, nil
+		}
+	}
+}
+
+// between reports whether the lines read so far end between statements.
+func (s *ScriptSplitter) between() bool {
+	return !s.inStmt && !s.inComment && s.quote == 0 && !s.continued
+}
+
+// scan follows the state of the script through line.
+func (s *ScriptSplitter) scan(line string) {
+	if s.continued {
+		s.continued = strings.HasSuffix(strings.TrimSpace(line), "-")
+		return
+	}
+	if !s.inComment && s.quote == 0 {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "/" {
+			// a slash runs the buffered statement, whatever it is
+			s.inStmt, s.inBlock = false, false
+			return
+		}
+		if !s.inStmt && trimmed != "" && !strings.HasPrefix(trimmed, "--") && !strings.HasPrefix(trimmed, "/*") {
+			if _, ok := commandName(trimmed); ok {
+				s.continued = strings.HasSuffix(trimmed, "-")
+				return
+			}
+		}
+		if s.inStmt && !s.known {
+			s.header += " " + stripLineComment(trimmed)
+			s.inBlock, s.known = blockStart(s.header)
+		}
+	}
+
+	for i := 0; i < len(line); {
+		c := line[i]
+		switch {
+		case s.inComment:
+			end := strings.Index(line[i:], "*/")
+			if end < 0 {
+				return
+			}
+			i += end + 2
+			s.inComment = false
+		case s.qquote:
+			end := strings.Index(line[i:], string(s.quote)+"'")
+			if end < 0 {
+				return
+			}
+			i += end + 2
+			s.quote, s.qquote = 0, false
+		case s.quote != 0:
+			end := strings.IndexByte(line[i:], s.quote)
+			if end < 0 {
+				return
+			}
+			i += end + 1
+			s.quote = 0
+		case strings.HasPrefix(line[i:], "--"):
+			return
+		case strings.HasPrefix(line[i:], "/*"):
+			s.inComment = true
+			i += 2
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		default:
+			if !s.inStmt {
+				s.inStmt = true
+				s.header = stripLineComment(strings.TrimSpace(line[i:]))
+				s.inBlock, s.known = blockStart(s.header)
+			}
+			i += s.token(line, i)
+		}
+	}
+}
+
+// token handles the character of line at i, which is part of a statement,
+// and returns the number of bytes it takes.
+func (s *ScriptSplitter) token(line string, i int) int {
+	c := line[i]
+	switch c {
+	case '\'', '"':
+		s.quote = c
+		return 1
+	case ';':
+		if !s.inBlock {
+			s.inStmt = false
+		}
+		return 1
+	}
+	if i > 0 && isNameChar(line[i-1]) {
+		return 1
+	}
+	// q'[...]' and nq'[...]'
+	q := i
+	if (c == 'n' || c == 'N') && q+1 < len(line) {
+		q++
+	}
+	if (line[q] == 'q' || line[q] == 'Q') && q+2 < len(line) && line[q+1] == '\'' {
+		s.quote, s.qquote = quoteClose(line[q+2]), true
+		return q + 3 - i
+	}
+	return 1
+}
+
+// quoteClose returns the character closing a q-quote opened with open.
+func quoteClose(open byte) byte {
+	switch open {
+	case '[':
+		return ']'
+	case '{':
+		return '}'
+	case '(':
+		return ')'
+	case '<':
+		return '>'
+	}
+	return open
+}
+
+// ParseStream parses the script read from r and returns its statements one
+// at a time, keeping the memory used bounded however large the script is.
+// The script is split between statements, minding strings, comments and
+// q-quotes, and the pieces are parsed on their own; the positions of the
+// statements are those in the whole script.
+func ParseStream(r io.Reader) *StatementStream {
+	return &StatementStream{
+		splitter: SplitScript(r),
+		state:    newSqlPlusState(SqlPlusOptions{}),
+	}
+}
+
+// Next returns the next statement of the script, or io.EOF after the last
+// one. A statement the parser failed on is returned as an ErrorStatement
+// along with its diagnostic. The other diagnostics are returned with a nil
+// statement, and so is an error reading the script, after which Next
+// returns io.EOF.
+func (s *StatementStream) Next() (semantic.Statement, error) {
+	for len(s.items) == 0 {
+		if s.done {
+			return nil, io.EOF
+		}
+		chunk, err := s.splitter.Next()
+		if err != nil {
+			s.done = true
+			return nil, err
+		}
+		s.items = parseChunk(chunk, s.state)
+	}
+	item := s.items[0]
+	s.items = s.items[1:]
+	return item.stmt, item.err
+}
+
+// parseChunk parses chunk and returns its statements and diagnostics in
+// line order.
+func parseChunk(chunk ScriptChunk, state *sqlplusState) []streamItem {
+	src, cmds, cmdErr := state.preprocess(chunk.Text)
+	p := parser.NewParser(src)
+	defer p.Release()
+	root := p.Sql_script()
+	visitor := newPlSqlVisitor()
+	visitor.syntaxErrors = p.Diagnostics()
+	visitor.failedRules = p.FailedRules()
+	script := visitor.VisitSql_script(root.(*parser.Sql_scriptContext)).(*semantic.Script)
+	mergeCommands(script, cmds)
+	moveRanges(script, chunk.Lines, chunk.Runes, chunk.Offset)
+
+	diags := semantic.Diagnostics(errors.Join(cmdErr, otherSyntaxErrors(script, p.Diagnostics()), visitor.Error()))
+	for i := range diags {
+		diags[i] = moveDiagnostic(diags[i], chunk)
+	}
+	sort.SliceStable(diags, func(i, j int) bool {
+		return diags[i].Start.Line < diags[j].Start.Line
+	})
+
+	items := make([]streamItem, 0, len(script.Statements)+len(diags))
+	for _, stmt := range script.Statements {
+		for len(diags) > 0 && diags[0].Start.Line < stmt.Line() {
+			items = append(items, streamItem{err: diags[0]})
+			diags = diags[1:]
+		}
+		item := streamItem{stmt: stmt}
+		if e, ok := stmt.(*semantic.ErrorStatement); ok {
+			e.Diagnostic = moveDiagnostic(e.Diagnostic, chunk)
+			item.err = e.Diagnostic
+		}
+		items = append(items, item)
+	}
+	for _, d := range diags {
+		items = append(items, streamItem{err: d})
+	}
+	return items
+}
+
+// otherSyntaxErrors joins the syntax errors of all that no error statement
+// of script holds, such as the second error of a statement or an error the
+// parser recovered from without giving up on the statement.
+func otherSyntaxErrors(script *semantic.Script, all []semantic.Diagnostic) (err error) {
+	type key struct {
+		start   semantic.Position
+		message string
+	}
+	held := make(map[key]bool)
+	semantic.Inspect(script, func(node semantic.AstNode) bool {
+		if e, ok := node.(*semantic.ErrorStatement); ok {
+			held[key{e.Diagnostic.Start, e.Diagnostic.Message}] = true
+		}
+		return true
+	})
+	for _, d := range all {
+		if !held[key{d.Start, d.Message}] {
+			err = errors.Join(err, d)
+		}
+	}
+	return err
+}
+
+// moveDiagnostic moves d, reported for chunk, to its place in the script.
+// Only the syntax errors know their offsets.
+func moveDiagnostic(d semantic.Diagnostic, chunk ScriptChunk) semantic.Diagnostic {
+	d.Start.Line += chunk.Lines
+	d.End.Line += chunk.Lines
+	if d.Code == semantic.CodeSyntax {
+		d.Start.Offset += chunk.Offset
+		d.End.Offset += chunk.Offset
+	}
+	return d
+}
@@ -0,0 +1,167 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+
+	plsql "procinspect/pkg/parser/internal/plsql/parser"
+	"procinspect/pkg/semantic"
+)
+
+// Options controls the dialect the parser accepts.
+type Options struct {
+	// Version is the release the script is written for. The syntax the
+	// grammar ties to a release follows it, and syntax introduced after
+	// it is reported with code semantic.CodeVersion. The zero Version
+	// keeps the default grammar and accepts the syntax of all releases.
+	// The check goes one way only: identifiers of an older script that a
+	// later release reserves, such as a column named like a keyword
+	// added in 12c, are not reported.
+	Version semantic.Version
+	// SqlPlus controls the handling of the SQL*Plus commands of the
+	// script.
+	SqlPlus SqlPlusOptions
+}
+
+// versionUse is a piece of syntax that needs a release of Oracle.
+type versionUse struct {
+	feature string
+	version semantic.Version
+	token   antlr.Token
+}
+
+// versionScanner finds the syntax that needs a recent release in the
+// tokens of a script. The grammar accepts most of it as plain identifiers
+// or not at all, so the tokens are followed rather than the tree.
+type versionScanner struct {
+	tokens []antlr.Token
+	uses   []versionUse
+
+	// first is the index of the first token of the statement.
+	first int
+	// table is set in CREATE TABLE and ALTER TABLE statements.
+	table bool
+}
+
+// scanVersions returns the uses of syntax newer than 11g in tokens.
+func scanVersions(tokens []antlr.Token) []versionUse {
+	s := &versionScanner{}
+	for _, tok := range tokens {
+		if tok.GetChannel() == antlr.TokenDefaultChannel && tok.GetTokenType() != antlr.TokenEOF {
+			s.tokens = append(s.tokens, tok)
+		}
+	}
+	for i := range s.tokens {
+		s.scan(i)
+	}
+	return s.uses
+}
+
+func (s *versionScanner) use(feature string, version semantic.Version, tok antlr.Token) {
+	s.uses = append(s.uses, versionUse{feature: feature, version: version, token: tok})
+}
+
+// is reports whether the token at i has one of the types.
+func (s *versionScanner) is(i int, types ...int) bool {
+	if i < 0 || i >= len(s.tokens) {
+		return false
+	}
+	for _, t := range types {
+		if s.tokens[i].GetTokenType() == t {
+			return true
+		}
+	}
+	return false
+}
+
+// word reports whether the token at i is the keyword the lexer has no
+// token for.
+func (s *versionScanner) word(i int, word string) bool {
+	return i >= 0 && i < len(s.tokens) && strings.EqualFold(s.tokens[i].GetText(), word)
+}
+
+func (s *versionScanner) scan(i int) {
+	tok := s.tokens[i]
+	switch tok.GetTokenType() {
+	case plsql.PlSqlLexerSEMICOLON, plsql.PlSqlLexerSOLIDUS:
+		s.first, s.table = i+1, false
+		return
+	case plsql.PlSqlLexerTABLE:
+		s.table = s.table || s.is(s.first, plsql.PlSqlLexerCREATE, plsql.PlSqlLexerALTER)
+	case plsql.PlSqlLexerIF:
+		if s.is(i+1, plsql.PlSqlLexerEXISTS) || s.is(i+1, plsql.PlSqlLexerNOT) && s.is(i+2, plsql.PlSqlLexerEXISTS) {
+			s.use("IF [NOT] EXISTS", semantic.Version23, tok)
+		}
+	case plsql.PlSqlLexerSELECT:
+		if !s.hasFrom(i) {
+			s.use("SELECT without FROM", semantic.Version23, tok)
+		}
+	case plsql.PlSqlLexerFETCH:
+		if s.is(i+1, plsql.PlSqlLexerFIRST, plsql.PlSqlLexerNEXT) {
+			s.use("FETCH FIRST", semantic.Version12, tok)
+		}
+	case plsql.PlSqlLexerIDENTITY:
+		if s.is(i-1, plsql.PlSqlLexerAS) {
+			s.use("identity columns", semantic.Version12, tok)
+		}
+	case plsql.PlSqlLexerBOOLEAN:
+		if s.table {
+			s.use("BOOLEAN columns", semantic.Version23, tok)
+		}
+	case plsql.PlSqlLexerJSON:
+		if s.table && !s.is(i-1, plsql.PlSqlLexerIS, plsql.PlSqlLexerNOT) && !s.is(i+1, plsql.PlSqlLexerLEFT_PAREN) {
+			s.use("JSON columns", semantic.Version21, tok)
+		}
+	}
+	switch {
+	case s.word(i, "ANNOTATIONS") && s.is(i+1, plsql.PlSqlLexerLEFT_PAREN):
+		s.use("annotations", semantic.Version23, tok)
+	case s.word(i, "DOMAIN") && (s.table || s.is(s.first, plsql.PlSqlLexerCREATE, plsql.PlSqlLexerALTER, plsql.PlSqlLexerDROP) && i-s.first <= 4):
+		s.use("domains", semantic.Version23, tok)
+	}
+}
+
+// hasFrom reports whether the query starting with the SELECT at i has a
+// FROM clause.
+func (s *versionScanner) hasFrom(i int) bool {
+	depth := 0
+	for j := i + 1; j < len(s.tokens); j++ {
+		switch s.tokens[j].GetTokenType() {
+		case plsql.PlSqlLexerLEFT_PAREN:
+			depth++
+		case plsql.PlSqlLexerRIGHT_PAREN:
+			if depth--; depth < 0 {
+				return false
+			}
+		case plsql.PlSqlLexerFROM:
+			if depth == 0 {
+				return true
+			}
+		case plsql.PlSqlLexerSEMICOLON, plsql.PlSqlLexerSOLIDUS,
+			plsql.PlSqlLexerUNION, plsql.PlSqlLexerINTERSECT, plsql.PlSqlLexerMINUS:
+			if depth == 0 {
+				return false
+			}
+		}
+	}
+	return false
+}
+
+// checkVersions records the release the script needs and reports the syntax
+// newer than the one in opts.
+func checkVersions(script *semantic.Script, tokens []antlr.Token, opts Options, startLine int) (err error) {
+	for _, u := range scanVersions(tokens) {
+		if u.version > script.MinVersion {
+			script.MinVersion = u.version
+		}
+		if opts.Version != 0 && u.version > opts.Version {
+			err = errors.Join(err, newDiagnostic(semantic.CodeVersion,
+				fmt.Sprintf("%s requires Oracle %s+", u.feature, u.version),
+				u.token.GetLine()+startLine, u.token.GetColumn()))
+		}
+	}
+	return err
+}
@@ -1,8 +1,10 @@
 package parser
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/antlr4-go/antlr/v4"
 
@@ -15,7 +17,19 @@ type (
 		plsql.BasePlSqlParserVisitor
 		StartLine int
 
-		errors []ParseError
+		errors []semantic.Diagnostic
+		// syntaxErrors are the errors of the parse being visited, handed
+		// out to the error statements in order.
+		syntaxErrors []semantic.Diagnostic
+		// failedRules are the rule contexts the parser reported an error in.
+		failedRules map[antlr.Tree]bool
+
+		// ctx is checked between the statements of a script.
+		ctx context.Context
+		// interrupted is the error the parse was interrupted with, if any.
+		interrupted error
+		// cutoff is the line the statements stopped at after a timeout.
+		cutoff int
 	}
 )
 
@@ -28,11 +42,12 @@ func newAstNode[T any](ctx antlr.ParserRuleContext) *T {
 
 func setAstSpan(ctx antlr.ParserRuleContext, stmt semantic.SetPosition) {
 	stmt.SetLine(ctx.GetStart().GetLine())
-	stmt.SetColumn(ctx.GetStart().GetColumn())
+	stmt.SetColumn(ctx.GetStart().GetColumn() + 1)
 	stmt.SetSpan(semantic.Span{
 		Start: ctx.GetStart().GetStart(),
 		End:   ctx.GetStop().GetStop(),
 	})
+	stmt.SetRange(tokenRange(ctx.GetStart(), ctx.GetStop()))
 }
 
 func newPlSqlVisitor(startLineNo ...int) *plsqlVisitor {
@@ -54,17 +69,41 @@ func (v *plsqlVisitor) Error() (err error) {
 	return
 }
 
-func (v *plsqlVisitor) Errors() []ParseError {
+func (v *plsqlVisitor) Errors() []semantic.Diagnostic {
 	return v.errors
 }
 
 func (v *plsqlVisitor) ReportError(msg string, line, column int) {
-	v.errors = append(v.errors, ParseError{
-		Kind:   ErrSemantic,
-		Line:   line + v.StartLine,
-		Column: column,
-		Msg:    msg,
-	})
+	v.errors = append(v.errors, newDiagnostic(semantic.CodeSemantic, msg, line+v.StartLine, column))
+}
+
+// reportTimeout records that the statements from tok on are missing because
+// parsing stopped with err.
+func (v *plsqlVisitor) reportTimeout(err error, tok antlr.Token) {
+	v.cutoff = tok.GetLine() + v.StartLine
+	v.errors = append(v.errors, timeoutDiagnostic(err, v.cutoff, tok.GetColumn()))
+}
+
+// completeChildren returns the children of the script that were parsed in
+// full. When the parse was interrupted, the last statement may be cut short
+// and is left out.
+func (v *plsqlVisitor) completeChildren(ctx *plsql.Sql_scriptContext) []antlr.Tree {
+	children := ctx.GetChildren()
+	if v.interrupted == nil {
+		return children
+	}
+	for i := len(children) - 1; i >= 0; i-- {
+		switch child := children[i].(type) {
+		case antlr.ErrorNode:
+			v.reportTimeout(v.interrupted, child.GetSymbol())
+			return children[:i]
+		case antlr.ParserRuleContext:
+			v.reportTimeout(v.interrupted, child.GetStart())
+			return children[:i]
+		}
+	}
+	v.reportTimeout(v.interrupted, ctx.GetStart())
+	return nil
 }
 
 func (v *plsqlVisitor) Visit(tree antlr.ParseTree) interface{} {
@@ -72,13 +111,79 @@ func (v *plsqlVisitor) Visit(tree antlr.ParseTree) interface{} {
 }
 
 func (v *plsqlVisitor) VisitTerminal(node antlr.TerminalNode) interface{} {
-	// TODO implement me
-	panic("implement me")
+	return nil
 }
 
 func (v *plsqlVisitor) VisitErrorNode(node antlr.ErrorNode) interface{} {
-	// TODO implement me
-	panic("implement me")
+	return v.newErrorStatement(node.GetSymbol(), node.GetSymbol())
+}
+
+// newErrorStatement returns the placeholder for the statement the parser
+// failed on, which runs from the start token to the stop token.
+func (v *plsqlVisitor) newErrorStatement(start, stop antlr.Token) *semantic.ErrorStatement {
+	stmt := errorStatement(start, stop)
+	for i, e := range v.syntaxErrors {
+		if e.Start.Line < start.GetLine() {
+			// left over from a statement that is already done
+			continue
+		}
+		e.Start.Line += v.StartLine
+		e.End.Line += v.StartLine
+		stmt.Diagnostic = e
+		v.syntaxErrors = v.syntaxErrors[i+1:]
+		break
+	}
+	return stmt
+}
+
+func errorStatement(start, stop antlr.Token) *semantic.ErrorStatement {
+	stmt := &semantic.ErrorStatement{}
+	if stop == nil || stop.GetStop() < start.GetStart() {
+		stop = start
+	}
+	stmt.SetLine(start.GetLine())
+	stmt.SetColumn(start.GetColumn() + 1)
+	stmt.SetSpan(semantic.Span{Start: start.GetStart(), End: stop.GetStop()})
+	stmt.SetRange(tokenRange(start, stop))
+	if start.GetTokenType() != antlr.TokenEOF {
+		stmt.Text = start.GetInputStream().GetText(start.GetStart(), stop.GetStop())
+	}
+	return stmt
+}
+
+// hasSyntaxError reports whether the parser reported an error within tree,
+// either by leaving error nodes for the tokens it skipped or made up or by
+// giving up on a rule.
+func (v *plsqlVisitor) hasSyntaxError(tree antlr.Tree) bool {
+	if _, ok := tree.(antlr.ErrorNode); ok {
+		return true
+	}
+	if v.failedRules[tree] {
+		return true
+	}
+	for _, child := range tree.GetChildren() {
+		if v.hasSyntaxError(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// visitUnitStatement visits a statement that parsed without errors. A panic
+// in the visitor is reported as an error and leaves an error statement in
+// place of the statement, so that the rest of the script is still visited.
+func (v *plsqlVisitor) visitUnitStatement(ctx *plsql.Unit_statementContext) (result interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			line, column := ctx.GetStart().GetLine(), ctx.GetStart().GetColumn()
+			msg := fmt.Sprintf("failed to build %T: %v", ctx.GetChild(0), r)
+			v.ReportError(msg, line, column)
+			stmt := errorStatement(ctx.GetStart(), ctx.GetStop())
+			stmt.Diagnostic = v.errors[len(v.errors)-1]
+			result = stmt
+		}
+	}()
+	return ctx.Accept(v)
 }
 
 func GeneralScript(root plsql.ISql_scriptContext) (script *semantic.Script, err error) {
@@ -213,8 +318,32 @@ func (v *plsqlVisitor) VisitChildren(node antlr.RuleNode) interface{} {
 
 func (v *plsqlVisitor) VisitSql_script(ctx *plsql.Sql_scriptContext) interface{} {
 	script := newAstNode[semantic.Script](ctx)
-	for _, stmt := range ctx.AllUnit_statement() {
-		o := stmt.Accept(v)
+	for _, child := range v.completeChildren(ctx) {
+		if v.cutoff == 0 && v.ctx != nil && v.ctx.Err() != nil {
+			if rule, ok := child.(antlr.ParserRuleContext); ok {
+				v.reportTimeout(v.ctx.Err(), rule.GetStart())
+				break
+			}
+		}
+		if node, ok := child.(antlr.ErrorNode); ok {
+			script.Statements = append(script.Statements, v.VisitErrorNode(node).(semantic.Statement))
+			continue
+		}
+		if rule, ok := child.(antlr.ParserRuleContext); ok && v.hasSyntaxError(rule) {
+			script.Statements = append(script.Statements, v.newErrorStatement(rule.GetStart(), rule.GetStop()))
+			continue
+		}
+		if cmd, ok := child.(*plsql.Sql_plus_commandContext); ok {
+			if o, ok := v.VisitSql_plus_command(cmd).(*semantic.SqlPlusCommand); ok {
+				script.Statements = append(script.Statements, o)
+			}
+			continue
+		}
+		stmt, ok := child.(*plsql.Unit_statementContext)
+		if !ok {
+			continue
+		}
+		o := v.visitUnitStatement(stmt)
 		switch o.(type) {
 		case semantic.Statement:
 			break
@@ -226,10 +355,57 @@ func (v *plsqlVisitor) VisitSql_script(ctx *plsql.Sql_scriptContext) interface{}
 		}
 		script.Statements = append(script.Statements, o.(semantic.Statement))
 	}
+	finishRanges(script, plsql.NewStreamOffsetMap(ctx.GetStart().GetInputStream()))
 
 	return script
 }
 
+func (v *plsqlVisitor) VisitSql_plus_command(ctx *plsql.Sql_plus_commandContext) interface{} {
+	cmd := newAstNode[semantic.SqlPlusCommand](ctx)
+	switch {
+	case ctx.SOLIDUS() != nil:
+		// a lone slash only runs the buffered statement
+		return nil
+	case ctx.EXIT() != nil:
+		cmd.Name = "EXIT"
+	case ctx.PROMPT_MESSAGE() != nil:
+		cmd.Name = "PROMPT"
+		cmd.Args = commandArgs(ctx.PROMPT_MESSAGE().GetText())
+	case ctx.SHOW() != nil:
+		cmd.Name = "SHOW"
+		cmd.Args = strings.ToUpper(commandArgs(sourceText(ctx)))
+	case ctx.START_CMD() != nil:
+		text := strings.TrimSpace(ctx.START_CMD().GetText())
+		switch {
+		case strings.HasPrefix(text, "@@"):
+			cmd.Name, cmd.Args = "@@", strings.TrimSpace(text[2:])
+		case strings.HasPrefix(text, "@"):
+			cmd.Name, cmd.Args = "@", strings.TrimSpace(text[1:])
+		default:
+			cmd.Name, cmd.Args = "START", commandArgs(text)
+		}
+	case ctx.Whenever_command() != nil:
+		cmd.Name = "WHENEVER"
+		cmd.Args = strings.ToUpper(commandArgs(sourceText(ctx.Whenever_command())))
+	case ctx.Set_command() != nil:
+		cmd.Name = "SET"
+		cmd.Args = commandArgs(sourceText(ctx.Set_command()))
+	case ctx.Timing_command() != nil:
+		cmd.Name = "TIMING"
+		cmd.Args = commandArgs(sourceText(ctx.Timing_command()))
+	default:
+		v.ReportError(fmt.Sprintf("unsupported syntax %T", ctx.GetChild(0)),
+			ctx.GetStart().GetLine(), ctx.GetStart().GetColumn())
+		return nil
+	}
+	return cmd
+}
+
+// sourceText returns the original text of ctx, whitespace included.
+func sourceText(ctx antlr.ParserRuleContext) string {
+	return ctx.GetStart().GetInputStream().GetText(ctx.GetStart().GetStart(), ctx.GetStop().GetStop())
+}
+
 func (v *plsqlVisitor) VisitSelect_statement(ctx *plsql.Select_statementContext) interface{} {
 	object := ctx.Select_only_statement().Accept(v)
 	switch object.(type) {
@@ -332,6 +508,16 @@ func (v *plsqlVisitor) VisitQuery_block(ctx *plsql.Query_blockContext) interface
 			stmt.Where = visitor.VisitExpression(ctx.Where_clause().Expression().(*plsql.ExpressionContext)).(semantic.Expr)
 		}
 	}
+	if ctx.Model_clause() != nil {
+		model, ok := ctx.Model_clause().Accept(v).(*semantic.ModelClause)
+		if !ok {
+			v.ReportError(fmt.Sprintf("unsupported syntax %T", ctx.Model_clause()),
+				ctx.Model_clause().GetStart().GetLine(),
+				ctx.Model_clause().GetStart().GetColumn())
+		} else {
+			stmt.Model = model
+		}
+	}
 	return stmt
 }
 
@@ -367,15 +553,363 @@ func (v *plsqlVisitor) VisitSelected_list(ctx *plsql.Selected_listContext) inter
 
 func (v *plsqlVisitor) VisitTable_ref_list(ctx *plsql.Table_ref_listContext) interface{} {
 	from := newAstNode[semantic.FromClause](ctx)
-	// tables := make([]*semantic.TableRef, 0, len(ctx.AllTable_ref()))
 	for _, t := range ctx.AllTable_ref() {
-		from.TableRefs = append(from.TableRefs, &semantic.TableRef{
-			Table: t.GetText(),
-		})
+		from.TableRefs = append(from.TableRefs, v.tableRef(t))
 	}
 	return from
 }
 
+// tableRef builds the reference to a table or row source. A PIVOT or
+// UNPIVOT clause follows either the table expression or, after the joins,
+// the whole reference; the joins themselves are not modelled.
+func (v *plsqlVisitor) tableRef(ctx plsql.ITable_refContext) *semantic.TableRef {
+	ref := newAstNode[semantic.TableRef](ctx)
+	ref.Source = v.tableFunction(ctx)
+	for _, child := range ctx.GetChildren() {
+		switch child := child.(type) {
+		case *plsql.Table_ref_auxContext:
+			v.tableRefAux(ref, child)
+		case *plsql.Pivot_clauseContext, *plsql.Unpivot_clauseContext:
+			v.tableRefPivot(ref, child.(antlr.ParserRuleContext))
+		}
+	}
+	return ref
+}
+
+// tableRefAux fills in ref from the table expression and the alias. The
+// name is the text of the expression without its PIVOT or UNPIVOT clause.
+func (v *plsqlVisitor) tableRefAux(ref *semantic.TableRef, ctx *plsql.Table_ref_auxContext) {
+	for _, child := range ctx.GetChildren() {
+		switch child := child.(type) {
+		case *plsql.Table_ref_aux_internal_oneContext, *plsql.Table_ref_aux_internal_twoContext,
+			*plsql.Table_ref_aux_internal_threeContext:
+			for _, part := range child.GetChildren() {
+				switch part := part.(type) {
+				case *plsql.Pivot_clauseContext, *plsql.Unpivot_clauseContext:
+					v.tableRefPivot(ref, part.(antlr.ParserRuleContext))
+				case antlr.ParseTree:
+					ref.Table += part.GetText()
+				}
+			}
+		case *plsql.Table_aliasContext:
+			ref.Alias = child.GetText()
+		}
+	}
+}
+
+func (v *plsqlVisitor) tableRefPivot(ref *semantic.TableRef, ctx antlr.ParserRuleContext) {
+	switch o := ctx.Accept(v).(type) {
+	case *semantic.PivotClause:
+		ref.Pivot = o
+	case *semantic.UnpivotClause:
+		ref.Unpivot = o
+	default:
+		v.ReportError(fmt.Sprintf("unsupported syntax %T", ctx),
+			ctx.GetStart().GetLine(),
+			ctx.GetStart().GetColumn())
+	}
+}
+
+// tableFunction returns the JSON_TABLE or XMLTABLE row source of the table
+// reference, or nil when it reads from something else.
+func (v *plsqlVisitor) tableFunction(ref antlr.Tree) semantic.Expr {
+	for _, child := range ref.GetChildren() {
+		switch child := child.(type) {
+		case *plsql.Json_table_clauseContext:
+			return newExprVisitor(v).acceptExpr(child)
+		case *plsql.XmltableContext:
+			return newExprVisitor(v).acceptExpr(child)
+		case *plsql.Select_statementContext, *plsql.SubqueryContext,
+			*plsql.Pivot_clauseContext, *plsql.Unpivot_clauseContext:
+			// the row sources of subqueries belong to them
+		default:
+			if expr := v.tableFunction(child); expr != nil {
+				return expr
+			}
+		}
+	}
+	return nil
+}
+
+func (v *plsqlVisitor) VisitPivot_clause(ctx *plsql.Pivot_clauseContext) interface{} {
+	clause := newAstNode[semantic.PivotClause](ctx)
+	clause.IsXML = ctx.XML() != nil
+	for _, elem := range ctx.AllPivot_element() {
+		clause.Aggregates = append(clause.Aggregates, elem.Accept(v).(*semantic.PivotElement))
+	}
+	clause.For = ctx.Pivot_for_clause().Accept(v).([]semantic.Expr)
+
+	in := ctx.Pivot_in_clause()
+	if in.Subquery() != nil {
+		stmt, ok := in.Subquery().Accept(v).(semantic.Statement)
+		if !ok {
+			v.ReportError(fmt.Sprintf("unsupported syntax %T", in.Subquery()),
+				in.Subquery().GetStart().GetLine(),
+				in.Subquery().GetStart().GetColumn())
+		}
+		clause.InQuery = stmt
+	}
+	if len(in.AllANY()) > 0 {
+		clause.InAny = true
+	}
+	for _, elem := range in.AllPivot_in_clause_element() {
+		item := newAstNode[semantic.PivotInElement](elem)
+		visitor := newExprVisitor(v)
+		values := elem.Pivot_in_clause_elements()
+		if values.Expression() != nil {
+			expr, ok := values.Expression().Accept(visitor).(semantic.Expr)
+			if !ok {
+				v.ReportError(fmt.Sprintf("unsupported expression %T", values.Expression()),
+					values.GetStart().GetLine(),
+					values.GetStart().GetColumn())
+				continue
+			}
+			item.Values = append(item.Values, expr)
+		} else if values.Expressions() != nil {
+			exprs, ok := values.Expressions().Accept(visitor).([]semantic.Expr)
+			if !ok {
+				v.ReportError(fmt.Sprintf("unsupported expression %T", values.Expressions()),
+					values.GetStart().GetLine(),
+					values.GetStart().GetColumn())
+				continue
+			}
+			item.Values = exprs
+		}
+		item.Alias = columnAlias(elem.Column_alias())
+		clause.In = append(clause.In, item)
+	}
+	return clause
+}
+
+func (v *plsqlVisitor) VisitPivot_element(ctx *plsql.Pivot_elementContext) interface{} {
+	elem := newAstNode[semantic.PivotElement](ctx)
+	elem.Function = strings.ToUpper(ctx.Aggregate_function_name().GetText())
+	visitor := newExprVisitor(v)
+	expr, ok := ctx.Expression().Accept(visitor).(semantic.Expr)
+	if !ok {
+		v.ReportError(fmt.Sprintf("unsupported expression %T", ctx.Expression()),
+			ctx.Expression().GetStart().GetLine(),
+			ctx.Expression().GetStart().GetColumn())
+	}
+	elem.Expr = expr
+	elem.Alias = columnAlias(ctx.Column_alias())
+	return elem
+}
+
+func (v *plsqlVisitor) VisitPivot_for_clause(ctx *plsql.Pivot_for_clauseContext) interface{} {
+	return v.columnNameOrList(ctx.Column_name(), ctx.Paren_column_list())
+}
+
+func (v *plsqlVisitor) VisitUnpivot_clause(ctx *plsql.Unpivot_clauseContext) interface{} {
+	clause := newAstNode[semantic.UnpivotClause](ctx)
+	clause.IncludeNulls = ctx.INCLUDE() != nil
+	clause.Columns = v.columnNameOrList(ctx.Column_name(), ctx.Paren_column_list())
+	clause.For = ctx.Pivot_for_clause().Accept(v).([]semantic.Expr)
+	for _, elem := range ctx.Unpivot_in_clause().AllUnpivot_in_elements() {
+		clause.In = append(clause.In, elem.Accept(v).(*semantic.UnpivotInElement))
+	}
+	return clause
+}
+
+func (v *plsqlVisitor) VisitUnpivot_in_elements(ctx *plsql.Unpivot_in_elementsContext) interface{} {
+	elem := newAstNode[semantic.UnpivotInElement](ctx)
+	elem.Columns = v.columnNameOrList(ctx.Column_name(), ctx.Paren_column_list())
+	for _, c := range ctx.AllConstant() {
+		visitor := newExprVisitor(v)
+		expr, ok := c.Accept(visitor).(semantic.Expr)
+		if !ok {
+			v.ReportError(fmt.Sprintf("unsupported expression %T", c),
+				c.GetStart().GetLine(),
+				c.GetStart().GetColumn())
+			continue
+		}
+		elem.Values = append(elem.Values, expr)
+	}
+	return elem
+}
+
+func (v *plsqlVisitor) VisitModel_clause(ctx *plsql.Model_clauseContext) interface{} {
+	clause := newAstNode[semantic.ModelClause](ctx)
+	for _, opt := range ctx.AllCell_reference_options() {
+		clause.CellReferenceOptions = append(clause.CellReferenceOptions, joinTokens(opt))
+	}
+	if ctx.Return_rows_clause() != nil {
+		clause.ReturnRows = joinTokens(ctx.Return_rows_clause())
+	}
+	for _, ref := range ctx.AllReference_model() {
+		clause.ReferenceModels = append(clause.ReferenceModels, ref.Accept(v).(*semantic.ModelDefinition))
+	}
+	clause.MainModel = ctx.Main_model().Accept(v).(*semantic.ModelDefinition)
+	return clause
+}
+
+func (v *plsqlVisitor) VisitReference_model(ctx *plsql.Reference_modelContext) interface{} {
+	model := newAstNode[semantic.ModelDefinition](ctx)
+	model.Name = ctx.Reference_model_name().GetText()
+	stmt, ok := ctx.Subquery().Accept(v).(semantic.Statement)
+	if !ok {
+		v.ReportError(fmt.Sprintf("unsupported syntax %T", ctx.Subquery()),
+			ctx.Subquery().GetStart().GetLine(),
+			ctx.Subquery().GetStart().GetColumn())
+	}
+	model.Query = stmt
+	v.modelColumnClauses(model, ctx.Model_column_clauses())
+	for _, opt := range ctx.AllCell_reference_options() {
+		model.CellReferenceOptions = append(model.CellReferenceOptions, joinTokens(opt))
+	}
+	return model
+}
+
+func (v *plsqlVisitor) VisitMain_model(ctx *plsql.Main_modelContext) interface{} {
+	model := newAstNode[semantic.ModelDefinition](ctx)
+	if ctx.Main_model_name() != nil {
+		model.Name = ctx.Main_model_name().GetText()
+	}
+	v.modelColumnClauses(model, ctx.Model_column_clauses())
+	for _, opt := range ctx.AllCell_reference_options() {
+		model.CellReferenceOptions = append(model.CellReferenceOptions, joinTokens(opt))
+	}
+
+	rules := ctx.Model_rules_clause()
+	if rules.Model_rules_part() != nil {
+		part := rules.Model_rules_part()
+		model.RulesOption = joinTokens(part)
+		if part.Model_iterate_clause() != nil {
+			v.ReportError(fmt.Sprintf("unsupported syntax %T", part.Model_iterate_clause()),
+				part.Model_iterate_clause().GetStart().GetLine(),
+				part.Model_iterate_clause().GetStart().GetColumn())
+		}
+	}
+	for _, elem := range rules.AllModel_rules_element() {
+		model.Rules = append(model.Rules, elem.Accept(v).(*semantic.ModelRule))
+	}
+	return model
+}
+
+func (v *plsqlVisitor) VisitModel_rules_element(ctx *plsql.Model_rules_elementContext) interface{} {
+	rule := newAstNode[semantic.ModelRule](ctx)
+	if ctx.UPDATE() != nil {
+		rule.Option = "UPDATE"
+	} else if ctx.UPSERT() != nil {
+		rule.Option = "UPSERT"
+		if ctx.ALL() != nil {
+			rule.Option = "UPSERT ALL"
+		}
+	}
+
+	visitor := newExprVisitor(v)
+	cell, ok := ctx.Cell_assignment().Model_expression().Accept(visitor).(semantic.Expr)
+	if !ok {
+		v.ReportError(fmt.Sprintf("unsupported expression %T", ctx.Cell_assignment()),
+			ctx.Cell_assignment().GetStart().GetLine(),
+			ctx.Cell_assignment().GetStart().GetColumn())
+	}
+	rule.Cell = cell
+
+	if ctx.Order_by_clause() != nil {
+		order, ok := ctx.Order_by_clause().Accept(visitor).(semantic.Expr)
+		if !ok {
+			v.ReportError(fmt.Sprintf("unsupported expression %T", ctx.Order_by_clause()),
+				ctx.Order_by_clause().GetStart().GetLine(),
+				ctx.Order_by_clause().GetStart().GetColumn())
+		}
+		rule.OrderBy = order
+	}
+
+	expr, ok := ctx.Expression().Accept(visitor).(semantic.Expr)
+	if !ok {
+		v.ReportError(fmt.Sprintf("unsupported expression %T", ctx.Expression()),
+			ctx.Expression().GetStart().GetLine(),
+			ctx.Expression().GetStart().GetColumn())
+	}
+	rule.Expr = expr
+	return rule
+}
+
+func (v *plsqlVisitor) modelColumnClauses(model *semantic.ModelDefinition, ctx plsql.IModel_column_clausesContext) {
+	if ctx.Model_column_partition_part() != nil {
+		model.PartitionBy = v.modelColumnList(ctx.Model_column_partition_part().Model_column_list())
+	}
+	model.DimensionBy = v.modelColumnList(ctx.Model_column_list(0))
+	model.Measures = v.modelColumnList(ctx.Model_column_list(1))
+}
+
+func (v *plsqlVisitor) modelColumnList(ctx plsql.IModel_column_listContext) []semantic.Expr {
+	exprs := make([]semantic.Expr, 0, len(ctx.AllModel_column()))
+	for _, col := range ctx.AllModel_column() {
+		var expr semantic.Expr
+		var ok bool
+		if col.Expression() != nil {
+			visitor := newExprVisitor(v)
+			expr, ok = col.Expression().Accept(visitor).(semantic.Expr)
+		} else if col.Query_block() != nil {
+			var stmt semantic.Statement
+			stmt, ok = col.Query_block().Accept(v).(semantic.Statement)
+			query := newAstNode[semantic.StatementExpression](col.Query_block())
+			query.Stmt = stmt
+			expr = query
+		}
+		if !ok {
+			v.ReportError(fmt.Sprintf("unsupported expression %T", col),
+				col.GetStart().GetLine(),
+				col.GetStart().GetColumn())
+			continue
+		}
+		if col.Column_alias() != nil {
+			alias := newAstNode[semantic.AliasExpression](col)
+			alias.Expr = expr
+			alias.Alias = columnAlias(col.Column_alias())
+			expr = alias
+		}
+		exprs = append(exprs, expr)
+	}
+	return exprs
+}
+
+func (v *plsqlVisitor) columnNameOrList(name plsql.IColumn_nameContext, list plsql.IParen_column_listContext) []semantic.Expr {
+	visitor := newExprVisitor(v)
+	if name != nil {
+		return []semantic.Expr{visitor.parseDotExpr(name)}
+	}
+	if list != nil {
+		exprs, ok := list.Accept(visitor).([]semantic.Expr)
+		if !ok {
+			v.ReportError(fmt.Sprintf("unsupported expression %T", list),
+				list.GetStart().GetLine(),
+				list.GetStart().GetColumn())
+		}
+		return exprs
+	}
+	return nil
+}
+
+// columnAlias returns the alias name of a column_alias rule, or an empty
+// string when no alias is given.
+func columnAlias(ctx plsql.IColumn_aliasContext) string {
+	if ctx == nil {
+		return ""
+	}
+	if ctx.Quoted_string() != nil {
+		return ctx.Quoted_string().GetText()
+	}
+	if ctx.Identifier() != nil {
+		return ctx.Identifier().GetText()
+	}
+	return ""
+}
+
+// joinTokens joins the keyword tokens directly under ctx with a single
+// space, e.g. "IGNORE NAV" or "RETURN UPDATED ROWS".
+func joinTokens(ctx antlr.ParserRuleContext) string {
+	words := make([]string, 0, ctx.GetChildCount())
+	for _, child := range ctx.GetChildren() {
+		if token, ok := child.(antlr.TerminalNode); ok {
+			words = append(words, strings.ToUpper(token.GetText()))
+		}
+	}
+	return strings.Join(words, " ")
+}
+
 func (v *plsqlVisitor) VisitCreate_procedure_body(ctx *plsql.Create_procedure_bodyContext) interface{} {
 	stmt := newAstNode[semantic.CreateProcedureStatement](ctx)
 	stmt.Name = ctx.Procedure_name().GetText()
@@ -556,6 +1090,7 @@ func (v *plsqlVisitor) VisitIf_statement(ctx *plsql.If_statementContext) interfa
 
 func (v *plsqlVisitor) VisitOpen_statement(ctx *plsql.Open_statementContext) interface{} {
 	stmt := newAstNode[semantic.OpenStatement](ctx)
+	stmt.Name = ctx.Cursor_name().GetText()
 	return stmt
 }
 
@@ -589,6 +1124,7 @@ func (v *plsqlVisitor) VisitOpen_for_statement(ctx *plsql.Open_for_statementCont
 
 func (v *plsqlVisitor) VisitClose_statement(ctx *plsql.Close_statementContext) interface{} {
 	stmt := newAstNode[semantic.CloseStatement](ctx)
+	stmt.Name = ctx.Cursor_name().GetText()
 	return stmt
 }
 
@@ -734,7 +1270,11 @@ func (v *plsqlVisitor) VisitType_definition(ctx *plsql.Type_definitionContext) i
 
 func (v *plsqlVisitor) VisitNested_table_type_def(ctx *plsql.Nested_table_type_defContext) interface{} {
 	stmt := newAstNode[semantic.CreateNestTableStatement](ctx)
-
+	for _, child := range ctx.GetChildren() {
+		if spec, ok := child.(*plsql.Type_specContext); ok {
+			stmt.ElementType = spec.GetText()
+		}
+	}
 	return stmt
 }
 
@@ -756,6 +1296,18 @@ func (v *plsqlVisitor) VisitType_declaration(ctx *plsql.Type_declarationContext)
 
 func (v *plsqlVisitor) VisitTable_type_def(ctx *plsql.Table_type_defContext) interface{} {
 	stmt := newAstNode[semantic.NestTableTypeDeclaration](ctx)
+	for _, child := range ctx.GetChildren() {
+		switch child := child.(type) {
+		case *plsql.Type_specContext:
+			stmt.ElementType = child.GetText()
+		case *plsql.Table_indexed_by_partContext:
+			for _, part := range child.GetChildren() {
+				if spec, ok := part.(*plsql.Type_specContext); ok {
+					stmt.IndexBy = spec.GetText()
+				}
+			}
+		}
+	}
 	return stmt
 }
 
@@ -772,7 +1324,14 @@ func (v *plsqlVisitor) VisitFunction_spec(ctx *plsql.Function_specContext) inter
 	decl := newAstNode[semantic.FunctionDeclaration](ctx)
 
 	decl.Name = ctx.Identifier().GetText()
-
+	for _, child := range ctx.GetChildren() {
+		switch child := child.(type) {
+		case *plsql.ParameterContext:
+			decl.Parameters = append(decl.Parameters, v.VisitParameter(child).(*semantic.Parameter))
+		case *plsql.Type_specContext:
+			decl.Return = child.GetText()
+		}
+	}
 	return decl
 }
 
@@ -927,10 +1486,10 @@ func (v *plsqlVisitor) VisitCommit_statement(ctx *plsql.Commit_statementContext)
 
 func (v *plsqlVisitor) VisitRollback_statement(ctx *plsql.Rollback_statementContext) interface{} {
 	stmt := newAstNode[semantic.RollbackStatement](ctx)
-	if ctx.TO() != nil {
-		v.ReportError(fmt.Sprintf("unsupported syntax %T", ctx.TO()),
-			ctx.TO().GetSymbol().GetLine(),
-			ctx.TO().GetSymbol().GetColumn())
+	for _, child := range ctx.GetChildren() {
+		if name, ok := child.(*plsql.Savepoint_nameContext); ok {
+			stmt.Savepoint = name.GetText()
+		}
 	}
 	if ctx.FORCE() != nil {
 		v.ReportError(fmt.Sprintf("unsupported syntax %T", ctx.FORCE()),
@@ -940,6 +1499,113 @@ func (v *plsqlVisitor) VisitRollback_statement(ctx *plsql.Rollback_statementCont
 	return stmt
 }
 
+func (v *plsqlVisitor) VisitSavepoint_statement(ctx *plsql.Savepoint_statementContext) interface{} {
+	stmt := newAstNode[semantic.SavepointStatement](ctx)
+	for _, child := range ctx.GetChildren() {
+		if name, ok := child.(*plsql.Savepoint_nameContext); ok {
+			stmt.Name = name.GetText()
+		}
+	}
+	return stmt
+}
+
+func (v *plsqlVisitor) VisitSet_transaction_command(ctx *plsql.Set_transaction_commandContext) interface{} {
+	stmt := newAstNode[semantic.SetTransactionStatement](ctx)
+	for _, child := range ctx.GetChildren() {
+		switch child := child.(type) {
+		case antlr.TerminalNode:
+			switch child.GetSymbol().GetTokenType() {
+			case plsql.PlSqlParserONLY:
+				stmt.ReadOnly = true
+			case plsql.PlSqlParserWRITE:
+				stmt.ReadWrite = true
+			case plsql.PlSqlParserSERIALIZABLE:
+				stmt.IsolationLevel = "SERIALIZABLE"
+			case plsql.PlSqlParserCOMMITTED:
+				stmt.IsolationLevel = "READ COMMITTED"
+			}
+		case *plsql.Rollback_segment_nameContext:
+			stmt.RollbackSegment = child.GetText()
+		case *plsql.Quoted_stringContext:
+			stmt.Name = child.GetText()
+		}
+	}
+	return stmt
+}
+
+func (v *plsqlVisitor) VisitLock_table_statement(ctx *plsql.Lock_table_statementContext) interface{} {
+	stmt := newAstNode[semantic.LockTableStatement](ctx)
+	for _, child := range ctx.GetChildren() {
+		switch child := child.(type) {
+		case *plsql.Lock_table_elementContext:
+			for _, part := range child.GetChildren() {
+				if name, ok := part.(*plsql.Tableview_nameContext); ok {
+					stmt.Tables = append(stmt.Tables, &semantic.TableRef{Table: name.GetText()})
+				}
+			}
+			if child.GetChildCount() > 1 {
+				v.ReportError(fmt.Sprintf("unsupported syntax %T", child.GetChild(1)),
+					child.GetStart().GetLine(),
+					child.GetStart().GetColumn())
+			}
+		case *plsql.Lock_modeContext:
+			var words []string
+			for _, part := range child.GetChildren() {
+				words = append(words, strings.ToUpper(treeText(part, part)))
+			}
+			stmt.Mode = strings.Join(words, " ")
+		case *plsql.Wait_nowait_partContext:
+			for _, part := range child.GetChildren() {
+				switch part := part.(type) {
+				case antlr.TerminalNode:
+					if part.GetSymbol().GetTokenType() == plsql.PlSqlParserNOWAIT {
+						stmt.NoWait = true
+					}
+				case antlr.ParserRuleContext:
+					stmt.Wait = newExprVisitor(v).acceptExpr(part)
+				}
+			}
+		}
+	}
+	return stmt
+}
+
+func (v *plsqlVisitor) VisitAlter_session(ctx *plsql.Alter_sessionContext) interface{} {
+	stmt := newAstNode[semantic.AlterSessionStatement](ctx)
+	children := ctx.GetChildren()
+	for _, child := range children {
+		if set, ok := child.(*plsql.Alter_session_set_clauseContext); ok {
+			stmt.Parameters = sessionParameters(set)
+			return stmt
+		}
+	}
+	// the forms other than SET, as written after ALTER SESSION
+	if len(children) > 2 {
+		stmt.Clause = treeText(children[2], children[len(children)-1])
+	}
+	return stmt
+}
+
+// sessionParameters returns the name = value pairs of ALTER SESSION SET.
+func sessionParameters(ctx *plsql.Alter_session_set_clauseContext) []*semantic.SessionParameter {
+	var params []*semantic.SessionParameter
+	children := ctx.GetChildren()
+	start := 0
+	for i, child := range children {
+		if tokenType(child) != plsql.PlSqlParserEQUALS_OP || i == start || i+1 >= len(children) {
+			continue
+		}
+		param := &semantic.SessionParameter{
+			Name:  treeText(children[start], children[i-1]),
+			Value: treeText(children[i+1], children[i+1]),
+		}
+		setRange(param, tokenRange(treeStart(children[start]), treeStop(children[i+1])))
+		params = append(params, param)
+		start = i + 2
+	}
+	return params
+}
+
 func (v *plsqlVisitor) VisitDelete_statement(ctx *plsql.Delete_statementContext) interface{} {
 	stmt := newAstNode[semantic.DeleteStatement](ctx)
 	if ctx.General_table_ref() != nil {
@@ -1220,9 +1886,7 @@ func (v *plsqlVisitor) VisitMulti_table_insert(ctx *plsql.Multi_table_insertCont
 		}
 	}
 	if ctx.Conditional_insert_clause() != nil {
-		v.ReportError(fmt.Sprintf("unsupported syntax %T", ctx.Conditional_insert_clause()),
-			ctx.Conditional_insert_clause().GetStart().GetLine(),
-			ctx.Conditional_insert_clause().GetStart().GetColumn())
+		v.conditionalInsert(ctx.Conditional_insert_clause().(*plsql.Conditional_insert_clauseContext), stmt)
 	}
 	selStmt, ok := ctx.Select_statement().Accept(v).(*semantic.SelectStatement)
 	if !ok {
@@ -1235,12 +1899,49 @@ func (v *plsqlVisitor) VisitMulti_table_insert(ctx *plsql.Multi_table_insertCont
 	return stmt
 }
 
+// conditionalInsert sets the mode, the WHEN parts and the ELSE part of a
+// conditional multi-table insert.
+func (v *plsqlVisitor) conditionalInsert(ctx *plsql.Conditional_insert_clauseContext, stmt *semantic.InsertStatement) {
+	for _, child := range ctx.GetChildren() {
+		switch child := child.(type) {
+		case antlr.TerminalNode:
+			if child.GetSymbol().GetTokenType() == plsql.PlSqlParserFIRST {
+				stmt.IsFirst = true
+			}
+		case *plsql.Conditional_insert_when_partContext:
+			clause := newAstNode[semantic.ConditionalInsertClause](child)
+			for _, part := range child.GetChildren() {
+				switch part := part.(type) {
+				case *plsql.ConditionContext:
+					clause.Condition = newExprVisitor(v).acceptExpr(part)
+				case *plsql.Multi_table_elementContext:
+					if into, ok := part.Accept(v).(*semantic.InsertIntoClause); ok && into != nil {
+						clause.Into = append(clause.Into, into)
+					}
+				}
+			}
+			stmt.Conditions = append(stmt.Conditions, clause)
+		case *plsql.Conditional_insert_else_partContext:
+			for _, part := range child.GetChildren() {
+				part, ok := part.(*plsql.Multi_table_elementContext)
+				if !ok {
+					continue
+				}
+				if into, ok := part.Accept(v).(*semantic.InsertIntoClause); ok && into != nil {
+					stmt.Else = append(stmt.Else, into)
+				}
+			}
+		}
+	}
+}
+
 func (v *plsqlVisitor) VisitMulti_table_element(ctx *plsql.Multi_table_elementContext) interface{} {
 	into, ok := ctx.Insert_into_clause().Accept(v).(*semantic.InsertIntoClause)
 	if !ok {
 		v.ReportError(fmt.Sprintf("unsupported syntax %T", ctx.Insert_into_clause()),
 			ctx.Insert_into_clause().GetStart().GetLine(),
 			ctx.Insert_into_clause().GetStart().GetColumn())
+		return nil
 	}
 	if ctx.Values_clause() != nil {
 		values, ok := ctx.Values_clause().Accept(v).([]semantic.Expr)
@@ -1356,6 +2057,11 @@ func (v *plsqlVisitor) VisitLabel_declaration(ctx *plsql.Label_declarationContex
 func (v *plsqlVisitor) VisitMerge_statement(ctx *plsql.Merge_statementContext) interface{} {
 	stmt := newAstNode[semantic.MergeStatement](ctx)
 	stmt.Table = &semantic.TableRef{Table: ctx.Tableview_name().GetText()}
+	for _, child := range ctx.GetChildren() {
+		if alias, ok := child.(*plsql.Table_aliasContext); ok {
+			stmt.Table.Alias = alias.GetText()
+		}
+	}
 
 	// Using
 	visitor := newExprVisitor(v)
@@ -1425,52 +2131,114 @@ func (v *plsqlVisitor) VisitMerge_update_clause(ctx *plsql.Merge_update_clauseCo
 
 func (v *plsqlVisitor) VisitMerge_insert_clause(ctx *plsql.Merge_insert_clauseContext) interface{} {
 	stmt := newAstNode[semantic.MergeInsertStatement](ctx)
+	visitor := newExprVisitor(v)
+	for _, child := range ctx.GetChildren() {
+		switch child := child.(type) {
+		case *plsql.Paren_column_listContext:
+			columns, ok := child.Accept(visitor).([]semantic.Expr)
+			if !ok {
+				v.ReportError(fmt.Sprintf("unsupported syntax %T", child.GetChild(0)),
+					child.GetStart().GetLine(),
+					child.GetStart().GetColumn())
+			}
+			stmt.Columns = columns
+		case *plsql.Values_clauseContext:
+			stmt.Values, _ = child.Accept(v).([]semantic.Expr)
+		case *plsql.ExpressionsContext:
+			// VALUES (...) without the values clause rule
+			stmt.Values, _ = child.Accept(visitor).([]semantic.Expr)
+		case *plsql.Where_clauseContext:
+			if child.Expression() != nil {
+				stmt.Where = visitor.acceptExpr(child.Expression())
+			}
+		}
+	}
 	return stmt
 }
 
 func (v *plsqlVisitor) VisitCreate_trigger(ctx *plsql.Create_triggerContext) interface{} {
+	var stmt semantic.Statement
 	var trigger *semantic.CreateTriggerStatement
-	if ctx.Simple_dml_trigger() != nil {
-		stmt, ok := ctx.Simple_dml_trigger().Accept(v).(*semantic.CreateSimpleDmlTriggerStatement)
-		if !ok {
-			v.ReportError(fmt.Sprintf("unsupported syntax %T", ctx.Simple_dml_trigger()),
-				ctx.Simple_dml_trigger().GetStart().GetLine(),
-				ctx.Simple_dml_trigger().GetStart().GetColumn())
-		}
-		trigger = &stmt.CreateTriggerStatement
-		trigger.Name = ctx.Trigger_name().GetText()
-		if ctx.Trigger_body() != nil {
-			triggerBody := ctx.Trigger_body().Accept(v)
-			switch triggerBody.(type) {
-			case *semantic.TriggerBlock:
-				trigger.TriggerBody = triggerBody.(*semantic.TriggerBlock)
+	var kind antlr.ParserRuleContext
+	switch {
+	case ctx.Simple_dml_trigger() != nil:
+		kind = ctx.Simple_dml_trigger()
+		if s, ok := kind.Accept(v).(*semantic.CreateSimpleDmlTriggerStatement); ok {
+			stmt, trigger = s, &s.CreateTriggerStatement
+		}
+	case ctx.Compound_dml_trigger() != nil:
+		kind = ctx.Compound_dml_trigger()
+		if s, ok := kind.Accept(v).(*semantic.CreateCompoundDmlTriggerStatement); ok {
+			stmt, trigger = s, &s.CreateTriggerStatement
+		}
+	default:
+		for _, child := range ctx.GetChildren() {
+			if c, ok := child.(*plsql.Non_dml_triggerContext); ok {
+				kind = c
+				s := v.nonDmlTrigger(c)
+				stmt, trigger = s, &s.CreateTriggerStatement
 			}
 		}
-		setAstSpan(ctx, trigger)
-		return stmt
 	}
-	if ctx.Compound_dml_trigger() != nil {
-		stmt, ok := ctx.Compound_dml_trigger().Accept(v).(*semantic.CreateCompoundDmlTriggerStatement)
-		if !ok {
-			v.ReportError(fmt.Sprintf("unsupported syntax %T", ctx.Compound_dml_trigger()),
-				ctx.Compound_dml_trigger().GetStart().GetLine(),
-				ctx.Compound_dml_trigger().GetStart().GetColumn())
-		}
-		trigger = &stmt.CreateTriggerStatement
-		trigger.Name = ctx.Trigger_name().GetText()
-		if ctx.Trigger_body() != nil {
-			triggerBody := ctx.Trigger_body().Accept(v)
-			switch triggerBody.(type) {
-			case *semantic.TriggerBlock:
-				trigger.TriggerBody = triggerBody.(*semantic.TriggerBlock)
-			case *semantic.CompoundTriggerBlock:
-				trigger.TriggerBody = triggerBody.(*semantic.CompoundTriggerBlock)
+	if kind == nil {
+		return nil
+	}
+	if trigger == nil {
+		v.ReportError(fmt.Sprintf("unsupported syntax %T", kind),
+			kind.GetStart().GetLine(), kind.GetStart().GetColumn())
+		return nil
+	}
+	trigger.Name = ctx.Trigger_name().GetText()
+	v.triggerClauses(ctx, trigger)
+	if ctx.Trigger_body() != nil {
+		switch body := ctx.Trigger_body().Accept(v).(type) {
+		case *semantic.TriggerBlock:
+			trigger.TriggerBody = body
+		case *semantic.CompoundTriggerBlock:
+			trigger.TriggerBody = body
+		}
+	}
+	setAstSpan(ctx, trigger)
+	return stmt
+}
+
+// triggerClauses sets the parts of CREATE TRIGGER common to all kinds of
+// triggers: OR REPLACE, FOLLOWS and PRECEDES, ENABLE or DISABLE and WHEN.
+func (v *plsqlVisitor) triggerClauses(ctx *plsql.Create_triggerContext, trigger *semantic.CreateTriggerStatement) {
+	for _, child := range ctx.GetChildren() {
+		switch child := child.(type) {
+		case antlr.TerminalNode:
+			switch child.GetSymbol().GetTokenType() {
+			case plsql.PlSqlParserREPLACE:
+				trigger.IsReplace = true
+			case plsql.PlSqlParserDISABLE:
+				trigger.Disabled = true
+			}
+		case *plsql.Trigger_follows_clauseContext:
+			precedes := false
+			for _, part := range child.GetChildren() {
+				switch tokenType(part) {
+				case plsql.PlSqlParserFOLLOWS:
+					precedes = false
+				case plsql.PlSqlParserPRECEDES:
+					precedes = true
+				}
+				if name, ok := part.(*plsql.Trigger_nameContext); ok {
+					if precedes {
+						trigger.Precedes = append(trigger.Precedes, name.GetText())
+					} else {
+						trigger.Follows = append(trigger.Follows, name.GetText())
+					}
+				}
+			}
+		case *plsql.Trigger_when_clauseContext:
+			for _, part := range child.GetChildren() {
+				if condition, ok := part.(*plsql.ConditionContext); ok {
+					trigger.When = newExprVisitor(v).acceptExpr(condition)
+				}
 			}
 		}
-		setAstSpan(ctx, trigger)
-		return stmt
 	}
-	return nil
 }
 
 func (v *plsqlVisitor) VisitSimple_dml_trigger(ctx *plsql.Simple_dml_triggerContext) interface{} {
@@ -1480,31 +2248,110 @@ func (v *plsqlVisitor) VisitSimple_dml_trigger(ctx *plsql.Simple_dml_triggerCont
 	} else if ctx.AFTER() != nil {
 		stmt.IsBefore = false
 	}
-	if ctx.Dml_event_clause() != nil {
-		events := ctx.Dml_event_clause().Accept(v).(map[string]interface{})
-		if es, ok := events["events"]; ok {
-			stmt.Events = es.([]string)
-		}
-		if ss, ok := events["tableview"]; ok {
-			stmt.TableView = ss.(string)
+	for _, child := range ctx.GetChildren() {
+		switch child := child.(type) {
+		case antlr.TerminalNode:
+			if child.GetSymbol().GetTokenType() == plsql.PlSqlParserINSTEAD {
+				stmt.IsInsteadOf = true
+			}
+		case *plsql.Referencing_clauseContext:
+			stmt.Referencing = triggerReferencing(child)
 		}
 	}
+	if ctx.Dml_event_clause() != nil {
+		events := ctx.Dml_event_clause().Accept(v).(*dmlEvents)
+		stmt.Events, stmt.UpdateColumns = events.events, events.columns
+		stmt.TableView, stmt.NestedTable = events.table, events.nestedTable
+	}
 	if ctx.For_each_row() != nil {
 		stmt.ForEachRow = true
 	}
 	return stmt
 }
 
+// dmlEvents is the DML event clause of a trigger.
+type dmlEvents struct {
+	events, columns    []string
+	table, nestedTable string
+}
+
 func (v *plsqlVisitor) VisitDml_event_clause(ctx *plsql.Dml_event_clauseContext) interface{} {
-	result := make(map[string]interface{})
-	result["events"] = make([]string, 0)
+	result := &dmlEvents{events: make([]string, 0), table: ctx.Tableview_name().GetText()}
 	for _, event := range ctx.AllDml_event_element() {
-		result["events"] = append(result["events"].([]string), event.GetText())
+		result.events = append(result.events, event.GetStart().GetText())
+		for _, child := range event.GetChildren() {
+			list, ok := child.(*plsql.Column_listContext)
+			if !ok {
+				continue
+			}
+			for _, column := range list.GetChildren() {
+				if column, ok := column.(antlr.ParserRuleContext); ok {
+					result.columns = append(result.columns, column.GetText())
+				}
+			}
+		}
+	}
+	for _, child := range ctx.GetChildren() {
+		nested, ok := child.(*plsql.Dml_event_nested_clauseContext)
+		if !ok {
+			continue
+		}
+		for _, part := range nested.GetChildren() {
+			if name, ok := part.(*plsql.Tableview_nameContext); ok {
+				result.nestedTable = name.GetText()
+			}
+		}
 	}
-	result["tableview"] = ctx.Tableview_name().GetText()
 	return result
 }
 
+// triggerReferencing returns the correlation names of a REFERENCING clause.
+func triggerReferencing(ctx *plsql.Referencing_clauseContext) *semantic.TriggerReferencing {
+	ref := newAstNode[semantic.TriggerReferencing](ctx)
+	for _, child := range ctx.GetChildren() {
+		element, ok := child.(*plsql.Referencing_elementContext)
+		if !ok {
+			continue
+		}
+		var alias string
+		for _, part := range element.GetChildren() {
+			if part, ok := part.(*plsql.Column_aliasContext); ok {
+				alias = columnAlias(part)
+			}
+		}
+		switch element.GetStart().GetTokenType() {
+		case plsql.PlSqlParserOLD:
+			ref.Old = alias
+		case plsql.PlSqlParserNEW:
+			ref.New = alias
+		case plsql.PlSqlParserPARENT:
+			ref.Parent = alias
+		}
+	}
+	return ref
+}
+
+// nonDmlTrigger returns a trigger on DDL or database events.
+func (v *plsqlVisitor) nonDmlTrigger(ctx *plsql.Non_dml_triggerContext) *semantic.CreateNonDmlTriggerStatement {
+	stmt := newAstNode[semantic.CreateNonDmlTriggerStatement](ctx)
+	for _, child := range ctx.GetChildren() {
+		switch child := child.(type) {
+		case antlr.TerminalNode:
+			switch child.GetSymbol().GetTokenType() {
+			case plsql.PlSqlParserBEFORE:
+				stmt.IsBefore = true
+			case plsql.PlSqlParserDATABASE:
+				stmt.OnDatabase = true
+			}
+		case *plsql.Non_dml_eventContext:
+			stmt.Events = append(stmt.Events, treeText(child, child))
+		case *plsql.Schema_nameContext:
+			stmt.Schema = child.GetText()
+		}
+	}
+	return stmt
+}
+
 func (v *plsqlVisitor) VisitTrigger_body(ctx *plsql.Trigger_bodyContext) interface{} {
 	if ctx.Trigger_block() != nil {
 		return ctx.Trigger_block().Accept(v)
@@ -1535,12 +2382,13 @@ func (v *plsqlVisitor) VisitTrigger_block(ctx *plsql.Trigger_blockContext) inter
 func (v *plsqlVisitor) VisitCompound_dml_trigger(ctx *plsql.Compound_dml_triggerContext) interface{} {
 	stmt := newAstNode[semantic.CreateCompoundDmlTriggerStatement](ctx)
 	if ctx.Dml_event_clause() != nil {
-		events := ctx.Dml_event_clause().Accept(v).(map[string]interface{})
-		if es, ok := events["events"]; ok {
-			stmt.Events = es.([]string)
-		}
-		if ss, ok := events["tableview"]; ok {
-			stmt.TableView = ss.(string)
+		events := ctx.Dml_event_clause().Accept(v).(*dmlEvents)
+		stmt.Events, stmt.UpdateColumns = events.events, events.columns
+		stmt.TableView, stmt.NestedTable = events.table, events.nestedTable
+	}
+	for _, child := range ctx.GetChildren() {
+		if ref, ok := child.(*plsql.Referencing_clauseContext); ok {
+			stmt.Referencing = triggerReferencing(ref)
 		}
 	}
 	return stmt
@@ -0,0 +1,170 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+
+	plsql "procinspect/pkg/parser/internal/plsql/parser"
+	"procinspect/pkg/semantic"
+)
+
+// xmlMethod applies the method call that may follow an XML function, as in
+// XMLAGG(...).getClobVal(), to expr.
+func (v *exprVisitor) xmlMethod(ctx antlr.ParserRuleContext, expr semantic.Expr) semantic.Expr {
+	for _, child := range ctx.GetChildren() {
+		if part, ok := child.(*plsql.General_element_partContext); ok {
+			dot := newAstNode[semantic.DotExpression](ctx)
+			dot.Parent, dot.Name = expr, v.acceptExpr(part)
+			return dot
+		}
+	}
+	return expr
+}
+
+// aliasedExprs returns the expressions among children, each optionally
+// followed by a column alias, which makes it an AliasExpression.
+func (v *exprVisitor) aliasedExprs(children []antlr.Tree) []semantic.Expr {
+	var exprs []semantic.Expr
+	for _, child := range children {
+		switch child := child.(type) {
+		case *plsql.Column_aliasContext:
+			if len(exprs) > 0 {
+				exprs[len(exprs)-1] = &semantic.AliasExpression{
+					Expr:  exprs[len(exprs)-1],
+					Alias: columnAlias(child),
+				}
+			}
+		case *plsql.Xml_multiuse_expression_elementContext:
+			exprs = append(exprs, v.xmlMultiuseElement(child))
+		case *plsql.Xml_general_default_partContext:
+			exprs = append(exprs, v.xmlDefault(child))
+		case antlr.ParserRuleContext:
+			exprs = append(exprs, v.acceptExpr(child))
+		}
+	}
+	return exprs
+}
+
+// xmlMultiuseElement returns the element of XMLATTRIBUTES or XMLFOREST,
+// expr [AS name].
+func (v *exprVisitor) xmlMultiuseElement(ctx *plsql.Xml_multiuse_expression_elementContext) semantic.Expr {
+	var expr semantic.Expr
+	children := ctx.GetChildren()
+	for i, child := range children {
+		if tokenType(child) == plsql.PlSqlParserAS && i+1 < len(children) {
+			return &semantic.AliasExpression{Expr: expr, Alias: treeText(children[i+1], children[len(children)-1])}
+		}
+		if rule, ok := child.(antlr.ParserRuleContext); ok && expr == nil {
+			expr = v.acceptExpr(rule)
+		}
+	}
+	return expr
+}
+
+// xmlDefault returns the value of a DEFAULT clause.
+func (v *exprVisitor) xmlDefault(ctx *plsql.Xml_general_default_partContext) semantic.Expr {
+	for _, child := range ctx.GetChildren() {
+		if rule, ok := child.(antlr.ParserRuleContext); ok {
+			return v.acceptExpr(rule)
+		}
+	}
+	return nil
+}
+
+// xmlAgg returns XMLAGG(expr [ORDER BY ...]).
+func (v *exprVisitor) xmlAgg(ctx *plsql.Other_functionContext) semantic.Expr {
+	expr := newAstNode[semantic.XmlAggExpression](ctx)
+	for _, child := range ctx.GetChildren() {
+		switch child := child.(type) {
+		case *plsql.Order_by_clauseContext:
+			expr.OrderBy, _ = child.Accept(v).(*semantic.OrderByClause)
+		case *plsql.General_element_partContext:
+		case antlr.ParserRuleContext:
+			if expr.Expr == nil {
+				expr.Expr = v.acceptExpr(child)
+			}
+		}
+	}
+	return v.xmlMethod(ctx, expr)
+}
+
+// xmlElement returns XMLELEMENT([EVALNAME] name, [XMLATTRIBUTES(...),]
+// content [AS alias], ...).
+func (v *exprVisitor) xmlElement(ctx *plsql.Other_functionContext) semantic.Expr {
+	expr := newAstNode[semantic.XmlElementExpression](ctx)
+	var content []antlr.Tree
+	for _, child := range ctx.GetChildren() {
+		switch child := child.(type) {
+		case antlr.TerminalNode:
+			if child.GetSymbol().GetTokenType() == plsql.PlSqlParserEVALNAME {
+				expr.EvalName = true
+			}
+		case *plsql.Xml_attributes_clauseContext:
+			expr.Attributes = v.aliasedExprs(child.GetChildren())
+		case *plsql.General_element_partContext:
+		case antlr.ParserRuleContext:
+			if expr.Name == nil && !expr.EvalName {
+				name := newAstNode[semantic.NameExpression](child)
+				name.Name = treeText(child, child)
+				expr.Name = name
+				continue
+			}
+			if expr.Name == nil {
+				expr.Name = v.acceptExpr(child)
+				continue
+			}
+			content = append(content, child)
+		}
+	}
+	expr.Content = v.aliasedExprs(content)
+	return v.xmlMethod(ctx, expr)
+}
+
+func (v *exprVisitor) VisitXmltable(ctx *plsql.XmltableContext) interface{} {
+	expr := newAstNode[semantic.XmlTableExpression](ctx)
+	for _, child := range ctx.GetChildren() {
+		switch child := child.(type) {
+		case *plsql.Xml_namespaces_clauseContext:
+			expr.Namespaces = v.aliasedExprs(child.GetChildren())
+		case *plsql.Xml_passing_clauseContext:
+			expr.Passing = v.aliasedExprs(child.GetChildren())
+		case *plsql.Xml_table_columnContext:
+			expr.Columns = append(expr.Columns, v.xmlTableColumn(child))
+		case *plsql.General_element_partContext:
+		case antlr.ParserRuleContext:
+			if expr.Query == nil {
+				expr.Query = v.acceptExpr(child)
+			}
+		}
+	}
+	return v.xmlMethod(ctx, expr)
+}
+
+func (v *exprVisitor) xmlTableColumn(ctx *plsql.Xml_table_columnContext) *semantic.XmlTableColumn {
+	column := newAstNode[semantic.XmlTableColumn](ctx)
+	children := ctx.GetChildren()
+	for i := 0; i < len(children); i++ {
+		switch child := children[i].(type) {
+		case antlr.TerminalNode:
+			switch child.GetSymbol().GetTokenType() {
+			case plsql.PlSqlParserORDINALITY:
+				column.ForOrdinality = true
+			case plsql.PlSqlParserXMLTYPE:
+				column.DataType = "XMLTYPE"
+			case plsql.PlSqlParserPATH:
+				if i+1 < len(children) {
+					i++
+					column.Path = unquote(treeText(children[i], children[i]))
+				}
+			}
+		case *plsql.Xml_column_nameContext:
+			column.Name = strings.TrimSpace(treeText(child, child))
+		case *plsql.Type_specContext:
+			column.DataType = treeText(child, child)
+		case *plsql.Xml_general_default_partContext:
+			column.Default = v.xmlDefault(child)
+		}
+	}
+	return column
+}
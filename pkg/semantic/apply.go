@@ -0,0 +1,242 @@
+package semantic
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// An ApplyFunc is called by Apply for each node, with a Cursor on it. Its
+// result tells Apply whether to go on, see Apply.
+type ApplyFunc func(*Cursor) bool
+
+// Apply traverses the tree rooted at root in depth-first order, calling pre
+// before the children of each node and post after them, when they are not
+// nil. If pre returns false, the children of the node and post are skipped;
+// if post returns false, the traversal stops. The functions may change the
+// tree through the Cursor. The children of a replacement node are visited;
+// inserted nodes are not.
+//
+// Apply returns the root, which may have been replaced. The nodes it added
+// that have no source range are marked as synthetic; the others, moved
+// from elsewhere, keep their positions, as do the nodes left in place.
+func Apply(root AstNode, pre, post ApplyFunc) (result AstNode) {
+	holder := &struct{ Node AstNode }{root}
+	defer func() {
+		if r := recover(); r != nil && r != errAbort {
+			panic(r)
+		}
+		result = holder.Node
+	}()
+	a := &application{pre: pre, post: post}
+	a.apply(nil, "Node", nil, reflect.ValueOf(holder).Elem().Field(0), root)
+	return holder.Node
+}
+
+var errAbort = new(int)
+
+// A Cursor describes a node met by Apply: the node, its parent, and the
+// field of the parent holding it.
+type Cursor struct {
+	parent AstNode
+	name   string
+	// field is the field holding the node, the whole slice for the
+	// elements of slices.
+	field   reflect.Value
+	iter    *iterator
+	node    AstNode
+	deleted bool
+}
+
+// iterator is the position of Apply in a slice.
+type iterator struct {
+	index, step int
+}
+
+// Node returns the current node.
+func (c *Cursor) Node() AstNode { return c.node }
+
+// Parent returns the parent of the current node, nil for the root.
+func (c *Cursor) Parent() AstNode { return c.parent }
+
+// Name returns the name of the field of the parent that holds the node.
+func (c *Cursor) Name() string { return c.name }
+
+// Index returns the index of the node in the slice holding it, or -1 when
+// it is not in a slice.
+func (c *Cursor) Index() int {
+	if c.iter == nil {
+		return -1
+	}
+	return c.iter.index
+}
+
+// slot returns the value holding the current node.
+func (c *Cursor) slot() reflect.Value {
+	if c.iter == nil {
+		return c.field
+	}
+	return c.field.Index(c.iter.index)
+}
+
+// Replace replaces the current node with n. The children of n are visited
+// in place of those of the node.
+func (c *Cursor) Replace(n AstNode) {
+	if c.deleted {
+		panic("Replace of a deleted node")
+	}
+	assign(c.slot(), n)
+	markSynthetic(n)
+	c.node = n
+}
+
+// Delete deletes the current node from the slice holding it. Its children
+// are not visited.
+func (c *Cursor) Delete() {
+	if c.iter == nil {
+		panic(fmt.Sprintf("Delete of a node not in a slice: %s", c.name))
+	}
+	i, v := c.iter.index, c.field
+	l := v.Len()
+	reflect.Copy(v.Slice(i, l), v.Slice(i+1, l))
+	v.Index(l - 1).Set(reflect.Zero(v.Type().Elem()))
+	v.SetLen(l - 1)
+	c.iter.step--
+	c.deleted = true
+}
+
+// InsertAfter inserts n after the current node in the slice holding it.
+// Apply does not visit n.
+func (c *Cursor) InsertAfter(n AstNode) {
+	c.insert(1, n)
+	c.iter.step++
+}
+
+// InsertBefore inserts n before the current node in the slice holding it.
+// Apply does not visit n.
+func (c *Cursor) InsertBefore(n AstNode) {
+	c.insert(0, n)
+	c.iter.index++
+}
+
+// insert inserts n at the index of the current node plus offset, or at the
+// index of the deleted node.
+func (c *Cursor) insert(offset int, n AstNode) {
+	if c.iter == nil {
+		panic(fmt.Sprintf("insertion next to a node not in a slice: %s", c.name))
+	}
+	i := c.iter.index + offset
+	if c.deleted {
+		i = c.iter.index
+	}
+	v := c.field
+	v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+	l := v.Len()
+	reflect.Copy(v.Slice(i+1, l), v.Slice(i, l))
+	assign(v.Index(i), n)
+	markSynthetic(n)
+}
+
+// assign stores n in v, failing when the field cannot hold it.
+func assign(v reflect.Value, n AstNode) {
+	if n == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return
+	}
+	nv := reflect.ValueOf(n)
+	if !nv.Type().AssignableTo(v.Type()) {
+		panic(fmt.Sprintf("cannot use %T as %s", n, v.Type()))
+	}
+	v.Set(nv)
+}
+
+// markSynthetic marks n and the nodes below it that have no source range
+// as synthetic. The subtrees with a range were moved and are left as they
+// are.
+func markSynthetic(n AstNode) {
+	if n == nil {
+		return
+	}
+	Inspect(n, func(node AstNode) bool {
+		s, ok := node.(interface {
+			Range() Range
+			SetSynthetic(bool)
+		})
+		if !ok {
+			return node != nil
+		}
+		if !s.Range().IsZero() {
+			return false
+		}
+		s.SetSynthetic(true)
+		return true
+	})
+}
+
+type application struct {
+	pre, post ApplyFunc
+	cursor    Cursor
+}
+
+func (a *application) apply(parent AstNode, name string, iter *iterator, field reflect.Value, node AstNode) {
+	saved := a.cursor
+	a.cursor = Cursor{parent: parent, name: name, field: field, iter: iter, node: node}
+	defer func() { a.cursor = saved }()
+
+	if a.pre != nil && !a.pre(&a.cursor) {
+		return
+	}
+	if a.cursor.deleted {
+		return
+	}
+	a.applyChildren(a.cursor.node)
+	if a.post != nil && !a.post(&a.cursor) {
+		panic(errAbort)
+	}
+}
+
+// applyChildren applies to the children of node, in the order of the fields
+// of its type, as GetChildren lists them.
+func (a *application) applyChildren(node AstNode) {
+	rv := reflect.ValueOf(node)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return
+	}
+	a.applyFields(node, rv.Elem())
+}
+
+func (a *application) applyFields(parent AstNode, s reflect.Value) {
+	t := s.Type()
+	for i := 0; i < s.NumField(); i++ {
+		f, field := t.Field(i), s.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			a.applyFields(parent, field)
+			continue
+		}
+		switch field.Kind() {
+		case reflect.Ptr, reflect.Interface:
+			if child, ok := nodeOf(field); ok {
+				a.apply(parent, f.Name, nil, field, child)
+			}
+		case reflect.Slice:
+			iter := &iterator{}
+			for iter.index = 0; iter.index < field.Len(); iter.index += iter.step {
+				iter.step = 1
+				if child, ok := nodeOf(field.Index(iter.index)); ok {
+					a.apply(parent, f.Name, iter, field, child)
+				}
+			}
+		}
+	}
+}
+
+// nodeOf returns the node held by v, a pointer or an interface.
+func nodeOf(v reflect.Value) (AstNode, bool) {
+	if (v.Kind() != reflect.Ptr && v.Kind() != reflect.Interface) || v.IsNil() {
+		return nil, false
+	}
+	node, ok := v.Interface().(AstNode)
+	return node, ok
+}
@@ -0,0 +1,201 @@
+package semantic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPosition returns the position of a node spanning line from column 2,
+// as the parser would record it.
+func testPosition(line int) SyntaxNode {
+	start := Position{Line: line, Column: 2, Offset: line * 100}
+	end := Position{Line: line, Column: 20, Offset: line*100 + 18}
+	return SyntaxNode{SourceLine: line, SourceCol: 2, SourceRange: Range{Start: start, End: end}}
+}
+
+// applyProcedure returns the tree of
+//
+//	create or replace procedure archive is
+//	begin
+//		total := 1 + 2;
+//		null;
+//		delete from orders;
+//	end;
+func applyProcedure() *Script {
+	return &Script{Statements: []Statement{
+		&CreateProcedureStatement{
+			SyntaxNode: testPosition(1),
+			Name:       "archive",
+			IsReplace:  true,
+			Body: &Body{SyntaxNode: testPosition(2), Statements: []Statement{
+				&AssignmentStatement{
+					SyntaxNode: testPosition(3),
+					Left:       "total",
+					Right: &BinaryExpression{
+						ExprNode: ExprNode{testPosition(3)},
+						Left:     &NumericLiteral{ExprNode: ExprNode{testPosition(3)}, Value: 1},
+						Operator: "+",
+						Right:    &NumericLiteral{ExprNode: ExprNode{testPosition(3)}, Value: 2},
+					},
+				},
+				&NullStatement{SyntaxNode: testPosition(4)},
+				&DeleteStatement{
+					SyntaxNode: testPosition(5),
+					Table:      &NameExpression{ExprNode: ExprNode{testPosition(5)}, Name: "orders"},
+				},
+			}},
+		},
+	}}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name      string
+		pre, post ApplyFunc
+		check     func(t *testing.T, script *Script, stmts []Statement)
+	}{
+		{
+			name: "replace",
+			pre: func(c *Cursor) bool {
+				if n, ok := c.Node().(*NumericLiteral); ok && n.Value == 2 {
+					c.Replace(&NameExpression{Name: "bonus"})
+				}
+				return true
+			},
+			check: func(t *testing.T, script *Script, stmts []Statement) {
+				binary := stmts[0].(*AssignmentStatement).Right.(*BinaryExpression)
+				bonus, ok := binary.Right.(*NameExpression)
+				require.True(t, ok)
+				assert.Equal(t, "bonus", bonus.Name)
+				assert.True(t, bonus.IsSynthetic())
+				assert.True(t, bonus.Range().IsZero())
+				assert.False(t, binary.Left.(*NumericLiteral).IsSynthetic())
+			},
+		},
+		{
+			name: "delete",
+			pre: func(c *Cursor) bool {
+				if _, ok := c.Node().(*NullStatement); ok {
+					c.Delete()
+				}
+				return true
+			},
+			check: func(t *testing.T, script *Script, stmts []Statement) {
+				require.Len(t, stmts, 2)
+				assert.IsType(t, &AssignmentStatement{}, stmts[0])
+				assert.IsType(t, &DeleteStatement{}, stmts[1])
+			},
+		},
+		{
+			name: "insert",
+			pre: func(c *Cursor) bool {
+				if _, ok := c.Node().(*DeleteStatement); ok {
+					if c.Name() != "Statements" || c.Index() != 2 {
+						panic("wrong cursor on the DELETE")
+					}
+					c.InsertBefore(&CommitStatement{})
+					c.InsertAfter(&CommitStatement{})
+				}
+				if _, ok := c.Node().(*CommitStatement); ok {
+					panic("inserted node visited")
+				}
+				return true
+			},
+			check: func(t *testing.T, script *Script, stmts []Statement) {
+				require.Len(t, stmts, 5)
+				assert.IsType(t, &CommitStatement{}, stmts[2])
+				assert.IsType(t, &DeleteStatement{}, stmts[3])
+				assert.IsType(t, &CommitStatement{}, stmts[4])
+				assert.True(t, stmts[2].(*CommitStatement).IsSynthetic())
+				del := stmts[3].(*DeleteStatement)
+				assert.False(t, del.IsSynthetic())
+				assert.Equal(t, testPosition(5).SourceRange, del.Range())
+				assert.Equal(t, 5, del.Line())
+			},
+		},
+		{
+			name: "delete and insert",
+			pre: func(c *Cursor) bool {
+				if _, ok := c.Node().(*NullStatement); ok {
+					c.Delete()
+					c.InsertBefore(&CommitStatement{})
+				}
+				return true
+			},
+			check: func(t *testing.T, script *Script, stmts []Statement) {
+				require.Len(t, stmts, 3)
+				assert.IsType(t, &CommitStatement{}, stmts[1])
+				assert.IsType(t, &DeleteStatement{}, stmts[2])
+			},
+		},
+		{
+			name: "move",
+			pre: func(c *Cursor) bool {
+				if _, ok := c.Node().(*NullStatement); ok {
+					c.Replace(&LoopStatement{Statements: []Statement{c.Node().(Statement)}})
+					return false
+				}
+				return true
+			},
+			check: func(t *testing.T, script *Script, stmts []Statement) {
+				loop, ok := stmts[1].(*LoopStatement)
+				require.True(t, ok)
+				assert.True(t, loop.IsSynthetic())
+				assert.False(t, loop.Statements[0].(*NullStatement).IsSynthetic())
+			},
+		},
+		{
+			name: "stop",
+			post: func(c *Cursor) bool {
+				_, ok := c.Node().(*NullStatement)
+				if ok {
+					c.Delete()
+				}
+				return !ok
+			},
+			check: func(t *testing.T, script *Script, stmts []Statement) {
+				// the traversal stopped before the DELETE was met
+				require.Len(t, stmts, 2)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script := applyProcedure()
+			result := Apply(script, tt.pre, tt.post)
+			assert.Same(t, script, result)
+			proc := script.Statements[0].(*CreateProcedureStatement)
+			tt.check(t, script, proc.Body.Statements)
+		})
+	}
+}
+
+func TestApplyRoot(t *testing.T) {
+	root := &Script{}
+	result := Apply(applyProcedure(), func(c *Cursor) bool {
+		assert.Nil(t, c.Parent())
+		assert.Equal(t, -1, c.Index())
+		c.Replace(root)
+		return false
+	}, nil)
+	assert.Same(t, root, result)
+
+	assert.Panics(t, func() {
+		Apply(applyProcedure(), func(c *Cursor) bool {
+			if _, ok := c.Node().(*Body); ok {
+				c.Delete()
+			}
+			return true
+		}, nil)
+	})
+	assert.Panics(t, func() {
+		Apply(applyProcedure(), func(c *Cursor) bool {
+			if _, ok := c.Node().(*Body); ok {
+				c.Replace(&NullStatement{})
+			}
+			return true
+		}, nil)
+	})
+}
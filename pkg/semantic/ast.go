@@ -17,10 +17,22 @@ type AstNode interface {
 	Accept(visitor NodeVisitor) (err error)
 }
 
+// GetChildren returns the children of node, in the order of the fields of
+// its type.
 func GetChildren(node AstNode) []AstNode {
-	// 创建一个 AstNode 类型的 slice，作为返回值
-	var children []AstNode
+	return appendChildren(nil, node)
+}
+
+// appendChild appends x to children when it holds a node.
+func appendChild(children []AstNode, x any) []AstNode {
+	if child, ok := x.(AstNode); ok {
+		children = append(children, child)
+	}
+	return children
+}
 
+// reflectChildren appends the children of node, found by reflection.
+func reflectChildren(children []AstNode, node AstNode) []AstNode {
 	// 获取 node（你的 struct） 的 Value
 	rv := reflect.ValueOf(node)
 
@@ -33,6 +45,11 @@ func GetChildren(node AstNode) []AstNode {
 		return children
 	}
 
+	return appendFields(children, rv)
+}
+
+// appendFields appends the children held by the fields of the struct rv.
+func appendFields(children []AstNode, rv reflect.Value) []AstNode {
 	// 现在 rv 应该是一个 struct，我们遍历它的所有字段
 	for i := 0; i < rv.NumField(); i++ {
 		field := rv.Field(i)
@@ -41,36 +58,38 @@ func GetChildren(node AstNode) []AstNode {
 			continue
 		}
 
+		if rv.Type().Field(i).Anonymous && field.Kind() == reflect.Struct {
+			// the fields of an embedded statement, such as the
+			// CreateTriggerStatement of the kinds of triggers
+			children = appendFields(children, field)
+			continue
+		}
+
 		if field.Kind() == reflect.Slice { // 如果字段是一个 slice
-			// 遍历 slice 的每个元素
+			// 遍历 slice 的每个元素，元素是 AstNode 时添加到 children 中
 			for j := 0; j < field.Len(); j++ {
-				// 如果元素是一个 AstNode，我们添加它到 children 中
-				if field.Index(j).Type().Implements(reflect.TypeOf((*AstNode)(nil)).Elem()) {
-					a := field.Index(j).Interface()
-					if a != nil {
-						children = append(children, a.(AstNode))
-					}
-				}
-			}
-		} else if field.Kind() == reflect.Ptr { // 如果字段是一个指针
-			if field.IsNil() {
-				continue
-			}
-			field = field.Elem() // 获取指针指向的实际对象
-		}
-		if field.Kind() == reflect.Struct || field.Kind() == reflect.Interface { // 如果字段是一个 struct
-			if field.IsValid() { // 字段是否有效
-				// 如果字段是一个 AstNode，我们添加它到 children 中
-				if field.Type().Implements(reflect.TypeOf((*AstNode)(nil)).Elem()) {
-					e := field.Interface()
-					if e != nil {
-						children = append(children, e.(AstNode))
-					}
+				if child, ok := astNodeOf(field.Index(j)); ok {
+					children = append(children, child)
 				}
 			}
+		} else if child, ok := astNodeOf(field); ok {
+			children = append(children, child)
 		}
 	}
 
-	// 返回所有的 children
 	return children
 }
+
+// astNodeOf returns the AstNode held by v. The check is made on the dynamic
+// value, since interface fields such as Expr and pointer fields do not
+// implement AstNode by their static type.
+func astNodeOf(v reflect.Value) (AstNode, bool) {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil, false
+	}
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return nil, false
+	}
+	node, ok := v.Interface().(AstNode)
+	return node, ok
+}
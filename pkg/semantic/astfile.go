@@ -0,0 +1,389 @@
+package semantic
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// An .ast file starts with astFileMagic, followed by its AstFileHeader as
+// a line of JSON. Then come the entries, each the gzip of the gob of a
+// Script prefixed by its length as 4 bytes: first the script without its
+// statements, then a script for each of its statements, and an entry of
+// length 0 at the end. The index of the statements, a JSON array of
+// AstFileEntry, follows, and the file ends with the offset of the index as
+// 8 bytes. Both lengths are big endian.
+//
+// Files of Format 1 hold the gzip of the gob of the whole script after the
+// header, and the files written before the header was added only the
+// gzip; they are read as Format 0.
+
+const (
+	astFileMagic = "PROCAST\n"
+	gzipMagic    = "\x1f\x8b"
+
+	// AstFormatVersion is the version of the layout of .ast files.
+	AstFormatVersion = 2
+	// AstSchemaVersion is the version of the node structs. Bump it when a
+	// change to them makes the trees of older files decode wrongly, and
+	// add a migration to astMigrations when such trees can be fixed up.
+	AstSchemaVersion = 1
+)
+
+// ErrIncompatibleAstFile is returned for .ast files this build cannot
+// read; parsing their source again makes a readable one.
+var ErrIncompatibleAstFile = errors.New("incompatible .ast file")
+
+// astMigrations maps a schema version to the function bringing a tree of
+// that version to the next one.
+var astMigrations = map[int]func(*Script) error{}
+
+// AstFileHeader describes the tree an .ast file holds.
+type AstFileHeader struct {
+	Format int
+	Schema int
+	// Source is the path of the parsed file, and SourceHash the hex
+	// SHA-256 of its text.
+	Source        string
+	SourceHash    string
+	ParserVersion string
+	Created       time.Time
+}
+
+// AstFileEntry is the index entry of a statement of an .ast file. Kind
+// and Name are the type and name of the object a program unit creates,
+// such as PACKAGE BODY and its name as written, and empty for the other
+// statements. Offset is where the statement is stored.
+type AstFileEntry struct {
+	Kind   string
+	Name   string
+	Line   int
+	Offset int64
+}
+
+// WriteAstFile writes script to w as an .ast file. Format and Schema of
+// header are set to the current versions, and Created to now when zero.
+func WriteAstFile(w io.Writer, header AstFileHeader, script *Script) error {
+	header.Format = AstFormatVersion
+	header.Schema = AstSchemaVersion
+	if header.Created.IsZero() {
+		header.Created = time.Now()
+	}
+	line, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+
+	cw := &countWriter{w: w}
+	if _, err = io.WriteString(cw, astFileMagic); err != nil {
+		return err
+	}
+	if _, err = cw.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	shell := *script
+	shell.Statements = nil
+	if err = writeAstEntry(cw, &shell); err != nil {
+		return err
+	}
+	index := make([]AstFileEntry, 0, len(script.Statements))
+	for _, s := range script.Statements {
+		entry := AstFileEntry{Offset: cw.n}
+		if s != nil {
+			entry.Kind, entry.Name = UnitObject(s)
+			entry.Line = s.Line()
+		}
+		if err = writeAstEntry(cw, &Script{Statements: []Statement{s}}); err != nil {
+			return err
+		}
+		index = append(index, entry)
+	}
+	if err = binary.Write(cw, binary.BigEndian, uint32(0)); err != nil {
+		return err
+	}
+
+	offset := cw.n
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	if _, err = cw.Write(data); err != nil {
+		return err
+	}
+	return binary.Write(cw, binary.BigEndian, uint64(offset))
+}
+
+// ReadAstFile reads an .ast file, migrating the trees of older compatible
+// schemas. Files it cannot read give an error wrapping
+// ErrIncompatibleAstFile.
+func ReadAstFile(r io.Reader) (*AstFileHeader, *Script, error) {
+	br := bufio.NewReader(r)
+	header, err := readAstFileHeader(br)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if header.Format < 2 {
+		script, err := readAstScript(br, header)
+		return header, script, err
+	}
+	script, err := readAstEntry(br, header)
+	if err != nil {
+		return header, nil, err
+	}
+	for {
+		s, err := readAstEntry(br, header)
+		if err == errEndOfEntries {
+			break
+		}
+		if err != nil {
+			return header, nil, err
+		}
+		script.Statements = append(script.Statements, s.Statements...)
+	}
+	return header, script, nil
+}
+
+// AstFile reads the statements of an .ast file one at a time, through
+// its index.
+type AstFile struct {
+	Header *AstFileHeader
+	Index  []AstFileEntry
+
+	r    io.ReaderAt
+	size int64
+	// script is the whole tree of the files without an index, which are
+	// read at once.
+	script *Script
+}
+
+// OpenAstFile reads the header and the index of the .ast file of size
+// bytes r reads. The files of older formats have no index, and are
+// decoded whole.
+func OpenAstFile(r io.ReaderAt, size int64) (*AstFile, error) {
+	br := bufio.NewReader(io.NewSectionReader(r, 0, size))
+	header, err := readAstFileHeader(br)
+	if err != nil {
+		return nil, err
+	}
+	f := &AstFile{Header: header, r: r, size: size}
+
+	if header.Format < 2 {
+		f.script, err = readAstScript(br, header)
+		if err != nil {
+			return nil, err
+		}
+		f.Index = make([]AstFileEntry, len(f.script.Statements))
+		for i, s := range f.script.Statements {
+			if s != nil {
+				f.Index[i].Kind, f.Index[i].Name = UnitObject(s)
+				f.Index[i].Line = s.Line()
+			}
+		}
+		return f, nil
+	}
+
+	var trailer [8]byte
+	if size < int64(len(trailer)) {
+		return nil, fmt.Errorf("reading .ast index: %w", io.ErrUnexpectedEOF)
+	}
+	if _, err := r.ReadAt(trailer[:], size-int64(len(trailer))); err != nil {
+		return nil, fmt.Errorf("reading .ast index: %w", err)
+	}
+	offset := int64(binary.BigEndian.Uint64(trailer[:]))
+	if offset < 0 || offset > size-int64(len(trailer)) {
+		return nil, fmt.Errorf("reading .ast index: offset %d out of range", offset)
+	}
+	data := make([]byte, size-int64(len(trailer))-offset)
+	if _, err := r.ReadAt(data, offset); err != nil {
+		return nil, fmt.Errorf("reading .ast index: %w", err)
+	}
+	if err := json.Unmarshal(data, &f.Index); err != nil {
+		return nil, fmt.Errorf("reading .ast index: %w", err)
+	}
+	return f, nil
+}
+
+// Statement decodes the statement of the i-th entry of the index.
+func (f *AstFile) Statement(i int) (Statement, error) {
+	if i < 0 || i >= len(f.Index) {
+		return nil, fmt.Errorf("no statement %d in .ast file of %d", i, len(f.Index))
+	}
+	if f.script != nil {
+		return f.script.Statements[i], nil
+	}
+	offset := f.Index[i].Offset
+	script, err := readAstEntry(io.NewSectionReader(f.r, offset, f.size-offset), f.Header)
+	if err != nil {
+		return nil, err
+	}
+	if len(script.Statements) != 1 {
+		return nil, fmt.Errorf("entry at %d holds %d statements", offset, len(script.Statements))
+	}
+	return script.Statements[0], nil
+}
+
+// Script decodes the whole tree of the file.
+func (f *AstFile) Script() (*Script, error) {
+	if f.script != nil {
+		return f.script, nil
+	}
+	_, script, err := ReadAstFile(io.NewSectionReader(f.r, 0, f.size))
+	return script, err
+}
+
+// readAstFileHeader reads the header of an .ast file and checks that its
+// versions can be read.
+func readAstFileHeader(br *bufio.Reader) (*AstFileHeader, error) {
+	magic, err := br.Peek(len(astFileMagic))
+	if bytes.HasPrefix(magic, []byte(gzipMagic)) {
+		return &AstFileHeader{}, nil
+	}
+	if err != nil || string(magic) != astFileMagic {
+		return nil, fmt.Errorf("%w: not an .ast file", ErrIncompatibleAstFile)
+	}
+	_, _ = br.Discard(len(astFileMagic))
+
+	line, err := br.ReadBytes('\n')
+	if err != nil {
+		return nil, fmt.Errorf("reading .ast header: %w", err)
+	}
+	header := &AstFileHeader{}
+	if err := json.Unmarshal(line, header); err != nil {
+		return nil, fmt.Errorf("reading .ast header: %w", err)
+	}
+
+	switch {
+	case header.Format > AstFormatVersion:
+		return header, fmt.Errorf("%w: format %d is newer than %d, which this build reads",
+			ErrIncompatibleAstFile, header.Format, AstFormatVersion)
+	case header.Format < 1:
+		return header, fmt.Errorf("%w: unknown format %d", ErrIncompatibleAstFile, header.Format)
+	case header.Schema > AstSchemaVersion:
+		return header, fmt.Errorf("%w: schema %d is newer than %d, which this build reads",
+			ErrIncompatibleAstFile, header.Schema, AstSchemaVersion)
+	}
+	for v := header.Schema; v < AstSchemaVersion; v++ {
+		if astMigrations[v] == nil {
+			return header, fmt.Errorf("%w: schema %d cannot be migrated to %d",
+				ErrIncompatibleAstFile, header.Schema, AstSchemaVersion)
+		}
+	}
+	return header, nil
+}
+
+// readAstScript reads the whole script following the header of the files
+// of Format 0 and 1.
+func readAstScript(r io.Reader, header *AstFileHeader) (*Script, error) {
+	script, err := decodeAstScript(r, header)
+	if err != nil && header.Format == 0 {
+		return nil, fmt.Errorf("%w: file without a header, written by an older build: %v",
+			ErrIncompatibleAstFile, err)
+	}
+	return script, err
+}
+
+// errEndOfEntries is returned by readAstEntry for the entry ending the
+// statements.
+var errEndOfEntries = errors.New("end of .ast entries")
+
+func readAstEntry(r io.Reader, header *AstFileHeader) (*Script, error) {
+	var n uint32
+	if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+		return nil, fmt.Errorf("reading .ast entry: %w", err)
+	}
+	if n == 0 {
+		return nil, errEndOfEntries
+	}
+	return decodeAstScript(io.LimitReader(r, int64(n)), header)
+}
+
+// decodeAstScript decodes the gzip of the gob of a script, and migrates it
+// to the current schema.
+func decodeAstScript(r io.Reader, header *AstFileHeader) (*Script, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+	data, err := io.ReadAll(gr)
+	if err != nil {
+		return nil, err
+	}
+	script, err := NewNodeDecoder[*Script]().Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	for v := header.Schema; header.Format > 0 && v < AstSchemaVersion; v++ {
+		if err := astMigrations[v](script); err != nil {
+			return nil, fmt.Errorf("migrating schema %d: %w", v, err)
+		}
+	}
+	return script, nil
+}
+
+func writeAstEntry(w io.Writer, script *Script) error {
+	data, err := NewNodeEncoder().Encode(script)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	if _, err = gw.Write(data); err != nil {
+		return err
+	}
+	if err = gw.Close(); err != nil {
+		return err
+	}
+	if err = binary.Write(w, binary.BigEndian, uint32(buf.Len())); err != nil {
+		return err
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// UnitObject returns the type and the name of the object s creates, such
+// as PACKAGE BODY and its name as written, or empty strings when s is not
+// a program unit.
+func UnitObject(s Statement) (kind, name string) {
+	switch s := s.(type) {
+	case *CreatePackageStatement:
+		return "PACKAGE", s.Name
+	case *CreatePackageBodyStatement:
+		return "PACKAGE BODY", s.Name
+	case *CreateProcedureStatement:
+		return "PROCEDURE", s.Name
+	case *CreateFunctionStatement:
+		return "FUNCTION", s.Name
+	case *CreateSimpleDmlTriggerStatement:
+		return "TRIGGER", s.Name
+	case *CreateCompoundDmlTriggerStatement:
+		return "TRIGGER", s.Name
+	case *CreateNonDmlTriggerStatement:
+		return "TRIGGER", s.Name
+	case *CreateTypeStatement:
+		return "TYPE", s.Name
+	case *CreateNestTableStatement:
+		return "TYPE", s.Name
+	}
+	return "", ""
+}
+
+// countWriter counts the bytes written through it.
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,115 @@
+package semantic
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// legacyAstFile returns script as written before .ast files had a header.
+func legacyAstFile(t *testing.T, script *Script) []byte {
+	data, err := NewNodeEncoder().Encode(script)
+	require.Nil(t, err)
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, _ = gw.Write(data)
+	require.Nil(t, gw.Close())
+	return buf.Bytes()
+}
+
+func TestAstFile(t *testing.T) {
+	script := testProcedure()
+	script.Statements = append(script.Statements, &CommitStatement{SyntaxNode: testPosition(9)})
+
+	var buf bytes.Buffer
+	err := WriteAstFile(&buf, AstFileHeader{Source: "archive.sql", SourceHash: "ab12"}, script)
+	require.Nil(t, err)
+	assert.True(t, strings.HasPrefix(buf.String(), "PROCAST\n{"))
+	current := buf.Bytes()
+	legacy := legacyAstFile(t, script)
+	// format 1 has the header but no index
+	v1 := append([]byte("PROCAST\n{\"Format\":1,\"Schema\":1}\n"), legacy...)
+
+	tests := []struct {
+		name   string
+		data   []byte
+		format int
+		// index lists the kinds of the entries of the index.
+		index []string
+	}{
+		{"current", current, AstFormatVersion, []string{"PROCEDURE", ""}},
+		{"format 1", v1, 1, []string{"PROCEDURE", ""}},
+		{"legacy", legacy, 0, []string{"PROCEDURE", ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header, decoded, err := ReadAstFile(bytes.NewReader(tt.data))
+			require.Nil(t, err)
+			assert.Equal(t, tt.format, header.Format)
+			assert.Equal(t, script, decoded)
+
+			f, err := OpenAstFile(bytes.NewReader(tt.data), int64(len(tt.data)))
+			require.Nil(t, err)
+			var kinds []string
+			for _, e := range f.Index {
+				kinds = append(kinds, e.Kind)
+			}
+			assert.Equal(t, tt.index, kinds)
+			for i, want := range script.Statements {
+				stmt, err := f.Statement(i)
+				require.Nil(t, err)
+				assert.Equal(t, want, stmt)
+			}
+			all, err := f.Script()
+			require.Nil(t, err)
+			assert.Equal(t, script, all)
+		})
+	}
+
+	header, _, err := ReadAstFile(bytes.NewReader(current))
+	require.Nil(t, err)
+	assert.Equal(t, AstSchemaVersion, header.Schema)
+	assert.Equal(t, "archive.sql", header.Source)
+	assert.Equal(t, "ab12", header.SourceHash)
+	assert.False(t, header.Created.IsZero())
+
+	f, err := OpenAstFile(bytes.NewReader(current), int64(len(current)))
+	require.Nil(t, err)
+	assert.Equal(t, "archive", f.Index[0].Name)
+	assert.Equal(t, 9, f.Index[1].Line)
+	assert.Less(t, f.Index[0].Offset, f.Index[1].Offset)
+}
+
+func TestAstFileIncompatible(t *testing.T) {
+	for _, data := range []string{
+		"PROCAST\n{\"Format\":99,\"Schema\":1}\n",
+		fmt.Sprintf("PROCAST\n{\"Format\":1,\"Schema\":%d}\n", AstSchemaVersion+1),
+		"not an ast file",
+	} {
+		_, _, err := ReadAstFile(strings.NewReader(data))
+		assert.ErrorIs(t, err, ErrIncompatibleAstFile, data)
+	}
+}
+
+func TestUnitObject(t *testing.T) {
+	tests := []struct {
+		stmt       Statement
+		kind, name string
+	}{
+		{&CreatePackageStatement{Name: "pkg"}, "PACKAGE", "pkg"},
+		{&CreatePackageBodyStatement{Name: "billing.pkg"}, "PACKAGE BODY", "billing.pkg"},
+		{&CreateFunctionStatement{Name: `"Total"`}, "FUNCTION", `"Total"`},
+		{&CreateSimpleDmlTriggerStatement{CreateTriggerStatement: CreateTriggerStatement{Name: "audit"}}, "TRIGGER", "audit"},
+		{&CommitStatement{}, "", ""},
+	}
+	for _, tt := range tests {
+		kind, name := UnitObject(tt.stmt)
+		assert.Equal(t, tt.kind, kind, "%T", tt.stmt)
+		assert.Equal(t, tt.name, name, "%T", tt.stmt)
+	}
+}
@@ -0,0 +1,23 @@
+package semantic
+
+type (
+	// ConditionalBlock is a conditional compilation $IF ... $END directive.
+	ConditionalBlock struct {
+		SyntaxNode
+		Branches []*ConditionalBranch
+	}
+
+	// ConditionalBranch is one $IF, $ELSIF or $ELSE branch of a
+	// ConditionalBlock.
+	ConditionalBranch struct {
+		SyntaxNode
+		// Condition is the boolean static expression, empty for $ELSE.
+		Condition string
+		// Selected reports whether the branch is the one picked by the
+		// evaluation of the conditions.
+		Selected bool
+		// Nodes holds the parsed content of a branch that is not selected.
+		// The content of the selected branch is part of the script itself.
+		Nodes []AstNode
+	}
+)
@@ -42,10 +42,27 @@ type (
 
 	CreateTriggerStatement struct {
 		SyntaxNode
-		Name        string
+		Name      string
+		IsReplace bool
+		// Follows and Precedes name the triggers this one fires after and
+		// before.
+		Follows  []string
+		Precedes []string
+		Disabled bool
+		// When is the condition of the WHEN clause.
+		When        Expr
 		TriggerBody TriggerBody
 	}
 
+	// TriggerReferencing holds the correlation names given by a
+	// REFERENCING clause, empty for the default ones.
+	TriggerReferencing struct {
+		SyntaxNode
+		Old    string
+		New    string
+		Parent string
+	}
+
 	TriggerBody interface {
 		triggerBody()
 	}
@@ -58,16 +75,37 @@ type (
 
 	CreateSimpleDmlTriggerStatement struct {
 		CreateTriggerStatement
-		IsBefore   bool
-		ForEachRow bool
-		Events     []string
-		TableView  string
+		IsBefore    bool
+		IsInsteadOf bool
+		ForEachRow  bool
+		Events      []string
+		// UpdateColumns lists the columns of UPDATE OF.
+		UpdateColumns []string
+		TableView     string
+		// NestedTable is the column of ON NESTED TABLE column OF view.
+		NestedTable string
+		Referencing *TriggerReferencing
 	}
 
 	CreateCompoundDmlTriggerStatement struct {
 		CreateTriggerStatement
-		Events    []string
-		TableView string
+		Events        []string
+		UpdateColumns []string
+		TableView     string
+		NestedTable   string
+		Referencing   *TriggerReferencing
+	}
+
+	// CreateNonDmlTriggerStatement is a trigger on system or DDL events,
+	// such as AFTER LOGON ON DATABASE or BEFORE DROP ON SCHEMA.
+	CreateNonDmlTriggerStatement struct {
+		CreateTriggerStatement
+		IsBefore bool
+		Events   []string
+		// OnDatabase is set for ON DATABASE; the others fire on Schema, or
+		// on the schema of the trigger when it is empty.
+		OnDatabase bool
+		Schema     string
 	}
 
 	CompoundTriggerBlock struct {
@@ -138,6 +176,8 @@ func (s *TriggerBlock) triggerBody() {}
 
 func (s *CreateCompoundDmlTriggerStatement) statement() {}
 
+func (s *CreateNonDmlTriggerStatement) statement() {}
+
 func (s *CompoundTriggerBlock) statement() {}
 
 func (s *CompoundTriggerBlock) triggerBody() {}
@@ -0,0 +1,125 @@
+package semantic
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+	SeverityInfo
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	default:
+		return "unknown"
+	}
+}
+
+// Diagnostic codes.
+const (
+	// CodeSyntax is reported by the lexer and the parser.
+	CodeSyntax = "syntax"
+	// CodeSemantic is reported for the syntax the AST cannot represent.
+	CodeSemantic = "semantic"
+	// CodeConditional is reported by conditional compilation.
+	CodeConditional = "conditional"
+	// CodeTimeout is reported when parsing stops because its context is
+	// done; the statements after it are missing.
+	CodeTimeout = "timeout"
+	// CodeVersion is reported for syntax newer than the release the script
+	// is parsed for.
+	CodeVersion = "version"
+)
+
+type (
+	// Diagnostic is an error or warning found in a source file. The range
+	// runs from Start up to, but not including, End.
+	Diagnostic struct {
+		File     string
+		Start    Position
+		End      Position
+		Severity Severity
+		Code     string
+		Message  string
+		// Expected lists the tokens the parser would have accepted.
+		Expected []string
+	}
+)
+
+func (d Diagnostic) Error() string {
+	if d.File != "" {
+		return fmt.Sprintf("%s:%d:%d: %s", d.File, d.Start.Line, d.Start.Column, d.Message)
+	}
+	return fmt.Sprintf("%d:%d: %s", d.Start.Line, d.Start.Column, d.Message)
+}
+
+// Excerpt renders the source line of the diagnostic taken from src, with a
+// caret under the start and the rest of the range underlined when it ends
+// on the same line:
+//
+//	2 | select * from test.1;
+//	  |                   ^~
+func (d Diagnostic) Excerpt(src string) string {
+	lines := strings.Split(src, "\n")
+	if d.Start.Line < 1 || d.Start.Line > len(lines) {
+		return ""
+	}
+	line := []rune(strings.TrimRight(lines[d.Start.Line-1], "\r"))
+	col := d.Start.Column - 1
+	if col < 0 {
+		col = 0
+	}
+	if col > len(line) {
+		col = len(line)
+	}
+	width := 1
+	if d.End.Line == d.Start.Line && d.End.Column-1 > col+1 {
+		width = d.End.Column - 1 - col
+	}
+
+	var marker strings.Builder
+	for _, r := range line[:col] {
+		// keep tabs so that the caret lines up with the source
+		if r == '\t' {
+			marker.WriteRune('\t')
+		} else {
+			marker.WriteByte(' ')
+		}
+	}
+	marker.WriteByte('^')
+	marker.WriteString(strings.Repeat("~", width-1))
+
+	number := fmt.Sprint(d.Start.Line)
+	pad := strings.Repeat(" ", len(number))
+	return fmt.Sprintf("%s | %s\n%s | %s", number, string(line), pad, marker.String())
+}
+
+// Diagnostics returns the diagnostics found in err, unwrapping joined and
+// wrapped errors. Errors that are not diagnostics are left out.
+func Diagnostics(err error) []Diagnostic {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case Diagnostic:
+		return []Diagnostic{e}
+	case interface{ Unwrap() []error }:
+		var diags []Diagnostic
+		for _, inner := range e.Unwrap() {
+			diags = append(diags, Diagnostics(inner)...)
+		}
+		return diags
+	}
+	return Diagnostics(errors.Unwrap(err))
+}
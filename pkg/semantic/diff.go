@@ -0,0 +1,573 @@
+package semantic
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// EditKind is the kind of an Edit.
+type EditKind int
+
+const (
+	// EditInsert adds New at Path.
+	EditInsert EditKind = iota + 1
+	// EditDelete removes Old from Path.
+	EditDelete
+	// EditUpdate replaces Old at Path with New: a changed field, or a
+	// node of another type.
+	EditUpdate
+	// EditMove moves the element at From to Path. Changes to the element
+	// itself are reported by the edits under Path that follow.
+	EditMove
+)
+
+func (k EditKind) String() string {
+	switch k {
+	case EditInsert:
+		return "insert"
+	case EditDelete:
+		return "delete"
+	case EditUpdate:
+		return "update"
+	case EditMove:
+		return "move"
+	}
+	return fmt.Sprintf("EditKind(%d)", int(k))
+}
+
+// Edit is a change between two trees. Path names the field it applies to
+// from the root, as in Statements[2].Body.Statements[0].Where, indexing
+// the lists of the new tree but for the last index of deletes, which is
+// the one in the old list. Old and New are the nodes or the field values
+// before and after the change.
+type Edit struct {
+	Kind     EditKind
+	Path     string
+	From     string
+	Old, New interface{}
+}
+
+func (e Edit) String() string {
+	switch e.Kind {
+	case EditInsert:
+		return fmt.Sprintf("insert %s: %s", e.Path, describe(e.New))
+	case EditDelete:
+		return fmt.Sprintf("delete %s: %s", e.Path, describe(e.Old))
+	case EditMove:
+		return fmt.Sprintf("move %s to %s: %s", e.From, e.Path, describe(e.New))
+	}
+	return fmt.Sprintf("update %s: %s -> %s", e.Path, describe(e.Old), describe(e.New))
+}
+
+// describe names a node by its type and its name, if any, and quotes the
+// other values.
+func describe(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return "nil"
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return "nil"
+	case reflect.String:
+		return fmt.Sprintf("%q", rv.String())
+	case reflect.Struct:
+		if name := nameOf(rv); name != "" {
+			return rv.Type().Name() + " " + name
+		}
+		return rv.Type().Name()
+	case reflect.Slice:
+		return fmt.Sprintf("%d %s", rv.Len(), rv.Type().Elem())
+	}
+	return fmt.Sprint(rv.Interface())
+}
+
+// Diff returns the edits that turn the tree a into b. Positions are
+// ignored, as are differences in whitespace outside of string literals.
+// The elements of lists are matched by content first, then named ones,
+// such as procedures and parameters, by type and name, which reports
+// their reordering as moves.
+func Diff(a, b Node) []Edit {
+	var d differ
+	d.diff("", reflect.ValueOf(a), reflect.ValueOf(b))
+	return d.edits
+}
+
+var (
+	positionTypes = map[reflect.Type]bool{
+		reflect.TypeOf(SyntaxNode{}): true,
+		reflect.TypeOf(Position{}):   true,
+		reflect.TypeOf(Span{}):       true,
+		reflect.TypeOf(Range{}):      true,
+	}
+	stringLiteralType = reflect.TypeOf(StringLiteral{})
+)
+
+type differ struct {
+	edits []Edit
+}
+
+func (d *differ) add(kind EditKind, path string, old, new reflect.Value) {
+	d.edits = append(d.edits, Edit{Kind: kind, Path: path, Old: valueOf(old), New: valueOf(new)})
+}
+
+func valueOf(v reflect.Value) interface{} {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func (d *differ) diff(path string, a, b reflect.Value) {
+	a, b = indirectNil(a), indirectNil(b)
+	switch {
+	case !a.IsValid() && !b.IsValid():
+		return
+	case !a.IsValid():
+		d.add(EditInsert, path, a, b)
+		return
+	case !b.IsValid():
+		d.add(EditDelete, path, a, b)
+		return
+	}
+	if a.Kind() == reflect.Interface {
+		a, b = a.Elem(), b.Elem()
+	}
+	if a.Type() != b.Type() {
+		d.add(EditUpdate, path, a, b)
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr:
+		d.diffStruct(path, a.Elem(), b.Elem())
+	case reflect.Struct:
+		d.diffStruct(path, a, b)
+	case reflect.Slice:
+		d.diffSlice(path, a, b)
+	case reflect.String:
+		if normalizeSpace(a.String()) != normalizeSpace(b.String()) {
+			d.add(EditUpdate, path, a, b)
+		}
+	default:
+		if a.CanInterface() && a.Interface() != b.Interface() {
+			d.add(EditUpdate, path, a, b)
+		}
+	}
+}
+
+// indirectNil returns the zero Value for nil pointers and interfaces, and
+// for empty slices, which the parser leaves nil or not depending on the
+// syntax.
+func indirectNil(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+	case reflect.Slice:
+		if v.Len() == 0 {
+			return reflect.Value{}
+		}
+	}
+	return v
+}
+
+// diffStruct compares the fields of the structs a and b.
+func (d *differ) diffStruct(path string, a, b reflect.Value) {
+	if a.Kind() != reflect.Struct {
+		d.diff(path, a, b)
+		return
+	}
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || positionTypes[f.Type] {
+			continue
+		}
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			d.diffStruct(path, a.Field(i), b.Field(i))
+			continue
+		}
+		fa, fb := a.Field(i), b.Field(i)
+		if t == stringLiteralType && f.Type.Kind() == reflect.String {
+			if fa.String() != fb.String() {
+				d.add(EditUpdate, join(path, f.Name), fa, fb)
+			}
+			continue
+		}
+		d.diff(join(path, f.Name), fa, fb)
+	}
+}
+
+func join(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// diffSlice compares the lists a and b. A longest common subsequence of
+// equal elements is kept in place. Of the others, elements of the same key
+// are paired, and the rest of each gap between kept elements is paired by
+// type when they have no name. Paired elements out of the order of the
+// others are reported as moved.
+func (d *differ) diffSlice(path string, a, b reflect.Value) {
+	ha, hb := make([]uint64, a.Len()), make([]uint64, b.Len())
+	for i := range ha {
+		ha[i] = fingerprint(a.Index(i))
+	}
+	for j := range hb {
+		hb[j] = fingerprint(b.Index(j))
+	}
+
+	// pairA[i] is the element of b paired with a[i], or -1
+	pairA, pairB := make([]int, len(ha)), make([]int, len(hb))
+	for i := range pairA {
+		pairA[i] = -1
+	}
+	for j := range pairB {
+		pairB[j] = -1
+	}
+	equal := make([]bool, len(hb))
+	anchorA, anchorB := make([]bool, len(ha)), make([]bool, len(hb))
+	for _, p := range commonElements(ha, hb) {
+		pairA[p[0]], pairB[p[1]] = p[1], p[0]
+		anchorA[p[0]], anchorB[p[1]] = true, true
+		equal[p[1]] = true
+	}
+	for i := range ha {
+		key := keyOf(a.Index(i))
+		if pairA[i] >= 0 || key == "" {
+			continue
+		}
+		for j := range hb {
+			if pairB[j] < 0 && keyOf(b.Index(j)) == key {
+				pairA[i], pairB[j] = j, i
+				equal[j] = ha[i] == hb[j]
+				break
+			}
+		}
+	}
+	moved := movedElements(pairA, len(hb))
+
+	// gapA[i] is the number of kept elements before a[i]
+	gapA, gapB := gaps(anchorA), gaps(anchorB)
+	for i := range ha {
+		if pairA[i] >= 0 || keyOf(a.Index(i)) != "" {
+			continue
+		}
+		for j := range hb {
+			if pairB[j] < 0 && gapB[j] == gapA[i] && keyOf(b.Index(j)) == "" &&
+				elemType(a.Index(i)) == elemType(b.Index(j)) {
+				pairA[i], pairB[j] = j, i
+				break
+			}
+		}
+	}
+
+	for i := range ha {
+		if pairA[i] < 0 {
+			d.add(EditDelete, fmt.Sprintf("%s[%d]", path, i), a.Index(i), reflect.Value{})
+		}
+	}
+	for j := range hb {
+		elem := fmt.Sprintf("%s[%d]", path, j)
+		i := pairB[j]
+		if i < 0 {
+			d.add(EditInsert, elem, reflect.Value{}, b.Index(j))
+			continue
+		}
+		if moved[j] {
+			d.edits = append(d.edits, Edit{Kind: EditMove, Path: elem,
+				From: fmt.Sprintf("%s[%d]", path, i),
+				Old:  valueOf(a.Index(i)), New: valueOf(b.Index(j))})
+		}
+		if !equal[j] {
+			d.diff(elem, a.Index(i), b.Index(j))
+		}
+	}
+}
+
+// maxLCSCells bounds the size of the table of commonElements, past which
+// equal elements are matched greedily.
+const maxLCSCells = 1 << 22
+
+// commonElements returns the index pairs of a common subsequence of the
+// fingerprints a and b: the longest one, after the common prefix and
+// suffix, unless the rest is too long.
+func commonElements(a, b []uint64) [][2]int {
+	var pairs [][2]int
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		pairs = append(pairs, [2]int{prefix, prefix})
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ma, mb := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	var middle [][2]int
+	if len(ma)*len(mb) <= maxLCSCells {
+		middle = lcs(ma, mb)
+	} else {
+		middle = greedyCommon(ma, mb)
+	}
+	for _, p := range middle {
+		pairs = append(pairs, [2]int{p[0] + prefix, p[1] + prefix})
+	}
+	for k := suffix; k > 0; k-- {
+		pairs = append(pairs, [2]int{len(a) - k, len(b) - k})
+	}
+	return pairs
+}
+
+// lcs returns the index pairs of a longest common subsequence of a and b.
+func lcs(a, b []uint64) [][2]int {
+	n, m := len(a), len(b)
+	table := make([][]int, n+1)
+	for i := range table {
+		table[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				table[i][j] = table[i+1][j+1] + 1
+			} else {
+				table[i][j] = table[i+1][j]
+				if table[i][j+1] > table[i][j] {
+					table[i][j] = table[i][j+1]
+				}
+			}
+		}
+	}
+	var pairs [][2]int
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case a[i] == b[j]:
+			pairs = append(pairs, [2]int{i, j})
+			i++
+			j++
+		case table[i+1][j] >= table[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return pairs
+}
+
+// greedyCommon matches each element of a with the first equal element of
+// b after the last match.
+func greedyCommon(a, b []uint64) [][2]int {
+	positions := make(map[uint64][]int)
+	for j, h := range b {
+		positions[h] = append(positions[h], j)
+	}
+	var pairs [][2]int
+	last := -1
+	for i, h := range a {
+		js := positions[h]
+		for len(js) > 0 && js[0] <= last {
+			js = js[1:]
+		}
+		positions[h] = js
+		if len(js) > 0 {
+			last = js[0]
+			pairs = append(pairs, [2]int{i, last})
+			positions[h] = js[1:]
+		}
+	}
+	return pairs
+}
+
+// movedElements returns, by index in the new list, the paired elements
+// outside of a longest run of pairs kept in order. pairA maps the old
+// indexes to the new ones, or to -1.
+func movedElements(pairA []int, n int) []bool {
+	// longest increasing subsequence of the new indexes, in the old order
+	var seq []int
+	for _, j := range pairA {
+		if j >= 0 {
+			seq = append(seq, j)
+		}
+	}
+	tails := []int{}              // index in seq of the smallest tail of each length
+	prev := make([]int, len(seq)) // index in seq of the previous element
+	for k, j := range seq {
+		lo, hi := 0, len(tails)
+		for lo < hi {
+			mid := (lo + hi) / 2
+			if seq[tails[mid]] < j {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+		prev[k] = -1
+		if lo > 0 {
+			prev[k] = tails[lo-1]
+		}
+		if lo == len(tails) {
+			tails = append(tails, k)
+		} else {
+			tails[lo] = k
+		}
+	}
+
+	moved := make([]bool, n)
+	for _, j := range seq {
+		moved[j] = true
+	}
+	if len(tails) > 0 {
+		for k := tails[len(tails)-1]; k >= 0; k = prev[k] {
+			moved[seq[k]] = false
+		}
+	}
+	return moved
+}
+
+func gaps(anchors []bool) []int {
+	gap := make([]int, len(anchors))
+	n := 0
+	for i, anchor := range anchors {
+		gap[i] = n
+		if anchor {
+			n++
+		}
+	}
+	return gap
+}
+
+// elemType returns the dynamic type of a list element.
+func elemType(v reflect.Value) reflect.Type {
+	if v = indirectNil(v); !v.IsValid() {
+		return nil
+	}
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	return v.Type()
+}
+
+// keyOf returns what identifies a list element apart from its content:
+// the type and name of the nodes with a Name, and the value of strings.
+func keyOf(v reflect.Value) string {
+	t := elemType(v)
+	if t == nil {
+		return ""
+	}
+	v = indirectNil(v)
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.String {
+		return normalizeSpace(v.String())
+	}
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if name := nameOf(v); name != "" {
+		return t.String() + " " + NormalizeName(name)
+	}
+	return ""
+}
+
+// nameOf returns the Name field of the struct v, if it has one.
+func nameOf(v reflect.Value) string {
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	f := v.FieldByName("Name")
+	if !f.IsValid() || f.Kind() != reflect.String {
+		return ""
+	}
+	return f.String()
+}
+
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// fingerprint hashes the content of v the way diff compares it, so that
+// elements with the same fingerprint have no edits between them.
+func fingerprint(v reflect.Value) uint64 {
+	h := fnv.New64a()
+	hashValue(h, v, false)
+	return h.Sum64()
+}
+
+func hashValue(h hash.Hash64, v reflect.Value, literal bool) {
+	var buf [8]byte
+	v = indirectNil(v)
+	if !v.IsValid() {
+		h.Write([]byte{0})
+		return
+	}
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		hashValue(h, v.Elem(), false)
+	case reflect.Struct:
+		h.Write([]byte(v.Type().String()))
+		h.Write([]byte{'{'})
+		hashFields(h, v, v.Type() == stringLiteralType)
+		h.Write([]byte{'}'})
+	case reflect.Slice:
+		binary.BigEndian.PutUint64(buf[:], uint64(v.Len()))
+		h.Write(buf[:])
+		for i := 0; i < v.Len(); i++ {
+			hashValue(h, v.Index(i), false)
+		}
+	case reflect.String:
+		s := v.String()
+		if !literal {
+			s = normalizeSpace(s)
+		}
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	case reflect.Bool:
+		if v.Bool() {
+			h.Write([]byte{1})
+		} else {
+			h.Write([]byte{2})
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		binary.BigEndian.PutUint64(buf[:], uint64(v.Int()))
+		h.Write(buf[:])
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		binary.BigEndian.PutUint64(buf[:], v.Uint())
+		h.Write(buf[:])
+	case reflect.Float32, reflect.Float64:
+		binary.BigEndian.PutUint64(buf[:], math.Float64bits(v.Float()))
+		h.Write(buf[:])
+	default:
+		fmt.Fprint(h, v.Kind())
+	}
+}
+
+func hashFields(h hash.Hash64, v reflect.Value, literal bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || positionTypes[f.Type] {
+			continue
+		}
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			hashFields(h, v.Field(i), literal)
+			continue
+		}
+		h.Write([]byte(f.Name))
+		hashValue(h, v.Field(i), literal && f.Type.Kind() == reflect.String)
+	}
+}
@@ -0,0 +1,154 @@
+package semantic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	param := func(name, dataType string) *Parameter {
+		return &Parameter{Name: name, DataType: dataType}
+	}
+	proc := func(name string, params []*Parameter, stmts ...Statement) *CreateProcedureStatement {
+		return &CreateProcedureStatement{Name: name, Parameters: params, Body: &Body{Statements: stmts}}
+	}
+	update := func(where Expr) Statement {
+		return &UpdateStatement{
+			Table: &NameExpression{Name: "accounts"},
+			SetExprs: []Expr{&BinaryExpression{
+				Left:     &NameExpression{Name: "balance"},
+				Operator: "=",
+				Right:    &NumericLiteral{Value: 0},
+			}},
+			Where: &BinaryExpression{Left: &NameExpression{Name: "id"}, Operator: "=", Right: where},
+		}
+	}
+	pkg := func(procs ...*CreateProcedureStatement) *Script {
+		return &Script{Statements: []Statement{&CreatePackageBodyStatement{Name: "pkg_billing", Procedures: procs}}}
+	}
+	// before is
+	//
+	//	create or replace package body pkg_billing is
+	//		procedure charge(id number) is
+	//		begin
+	//			update accounts set balance = 0 where id = id;
+	//		end;
+	//		procedure refund(id number) is begin null; end;
+	//		procedure close_all is begin commit; end;
+	//	end;
+	before := func() *Script {
+		return pkg(
+			proc("charge", []*Parameter{param("id", "number")}, update(&NameExpression{Name: "id"})),
+			proc("refund", []*Parameter{param("id", "number")}, &NullStatement{}),
+			proc("close_all", nil, &CommitStatement{}),
+		)
+	}
+	moved := func() *Script {
+		script := before()
+		script.Statements[0].(*CreatePackageBodyStatement).SetLine(3)
+		return script
+	}
+
+	tests := []struct {
+		name string
+		a, b Node
+		want []string
+	}{
+		{
+			name: "same",
+			a:    before(),
+			b:    before(),
+		},
+		{
+			name: "positions",
+			a:    before(),
+			b:    moved(),
+		},
+		{
+			name: "whitespace",
+			a:    param("since", "timestamp with time zone"),
+			b:    param("since", "timestamp  with\ttime zone"),
+		},
+		{
+			name: "whitespace in a literal",
+			a:    &AssignmentStatement{Left: "note", Right: &StringLiteral{Value: "'a b'"}},
+			b:    &AssignmentStatement{Left: "note", Right: &StringLiteral{Value: "'a  b'"}},
+			want: []string{"update Right.Value"},
+		},
+		{
+			name: "node of another type",
+			a:    &ExitStatement{Condition: &NameExpression{Name: "done"}},
+			b:    &ExitStatement{Condition: &NumericLiteral{Value: 1}},
+			want: []string{"update Condition"},
+		},
+		{
+			name: "package",
+			a:    before(),
+			// after is
+			//
+			//	create or replace package body pkg_billing is
+			//		procedure refund(id number) is begin null; end;
+			//		procedure charge(id varchar2, amount number) is
+			//		begin
+			//			update accounts set balance = 0 where id = 1;
+			//		end;
+			//		procedure open_all is begin commit; end;
+			//	end;
+			b: pkg(
+				proc("refund", []*Parameter{param("id", "number")}, &NullStatement{}),
+				proc("charge", []*Parameter{param("id", "varchar2"), param("amount", "number")}, update(&NumericLiteral{Value: 1})),
+				proc("open_all", nil, &CommitStatement{}),
+			),
+			want: []string{
+				"delete Statements[0].Procedures[2]",
+				"move Statements[0].Procedures[1]",
+				"update Statements[0].Procedures[1].Parameters[0].DataType",
+				"insert Statements[0].Procedures[1].Parameters[1]",
+				"update Statements[0].Procedures[1].Body.Statements[0].Where.Right",
+				"insert Statements[0].Procedures[2]",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, e := range Diff(tt.a, tt.b) {
+				got = append(got, e.Kind.String()+" "+e.Path)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEditString(t *testing.T) {
+	tests := []struct {
+		edit Edit
+		want string
+	}{
+		{
+			Edit{Kind: EditDelete, Path: "Statements[0].Procedures[2]", Old: &CreateProcedureStatement{Name: "close_all"}},
+			"delete Statements[0].Procedures[2]: CreateProcedureStatement close_all",
+		},
+		{
+			Edit{Kind: EditInsert, Path: "Statements[1]", New: &CommitStatement{}},
+			"insert Statements[1]: CommitStatement",
+		},
+		{
+			Edit{Kind: EditMove, From: "Procedures[0]", Path: "Procedures[1]", New: &CreateProcedureStatement{Name: "charge"}},
+			"move Procedures[0] to Procedures[1]: CreateProcedureStatement charge",
+		},
+		{
+			Edit{Kind: EditUpdate, Path: "Parameters[0].DataType", Old: "number", New: "varchar2"},
+			`update Parameters[0].DataType: "number" -> "varchar2"`,
+		},
+		{
+			Edit{Kind: EditUpdate, Path: "Where", Old: nil, New: &NumericLiteral{Value: 1}},
+			"update Where: nil -> NumericLiteral",
+		},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.edit.String())
+	}
+	assert.Equal(t, "EditKind(9)", EditKind(9).String())
+}
@@ -21,6 +21,46 @@ type (
 		Attr   string
 	}
 
+	// SqlCursorAttribute is an attribute of the implicit cursor, such as
+	// SQL%ROWCOUNT. Expr is the CursorAttribute it was parsed as.
+	SqlCursorAttribute struct {
+		ExprNode
+		Attr string
+		Expr Expr
+	}
+
+	// SequenceReference is seq.NEXTVAL or seq.CURRVAL. Sequence is the
+	// name of the sequence as written, with its schema if any, and Expr the
+	// DotExpression it was parsed as.
+	SequenceReference struct {
+		ExprNode
+		Sequence string
+		// Pseudo is NEXTVAL or CURRVAL.
+		Pseudo string
+		Expr   Expr
+	}
+
+	// PseudoColumn is a pseudo-column such as ROWNUM, ROWID or LEVEL, or a
+	// function called without arguments such as SYSDATE or USER. Name is
+	// in upper case; Table is the qualifier of t.ROWID. Expr is the
+	// expression it was parsed as.
+	PseudoColumn struct {
+		ExprNode
+		Name  string
+		Table string
+		Expr  Expr
+	}
+
+	// CorrelationReference is a column of the :NEW, :OLD or :PARENT row
+	// of a trigger. Expr is the DotExpression it was parsed as.
+	CorrelationReference struct {
+		ExprNode
+		// Row is NEW, OLD or PARENT.
+		Row  string
+		Name string
+		Expr Expr
+	}
+
 	UnaryLogicalExpression struct {
 		ExprNode
 		Expr     Expr
@@ -173,6 +213,12 @@ type (
 		Elem  Expr
 	}
 
+	ModelCellExpression struct {
+		ExprNode
+		Measure    Expr
+		Dimensions []Expr
+	}
+
 	CommonTableExpression struct {
 		ExprNode
 		Name        Expr
@@ -180,6 +226,127 @@ type (
 		ColNameList []Expr
 		IsRecursive bool
 	}
+
+	// JsonValueExpression is a JSON_VALUE or JSON_QUERY call, Function
+	// telling which. Path is the SQL/JSON path expression without quotes.
+	JsonValueExpression struct {
+		ExprNode
+		Function   string
+		Expr       Expr
+		FormatJson bool
+		Path       string
+		// Returning is the data type of the RETURNING clause, as written.
+		Returning string
+		// Wrapper is the wrapper clause of JSON_QUERY, such as WITH
+		// CONDITIONAL ARRAY WRAPPER.
+		Wrapper    string
+		OnError    *JsonOnClause
+		OnEmpty    *JsonOnClause
+		OnMismatch *JsonOnClause
+	}
+
+	// JsonOnClause is an ON ERROR, ON EMPTY, ON MISMATCH or ON NULL clause.
+	// Action is the behavior, such as ERROR, NULL, EMPTY ARRAY or DEFAULT,
+	// with Default holding the value of the latter.
+	JsonOnClause struct {
+		ExprNode
+		Action  string
+		Default Expr
+	}
+
+	// JsonObjectExpression is a JSON_OBJECT or JSON_OBJECTAGG call. A
+	// JSON_OBJECT(*) call has a single entry with a wildcard key.
+	JsonObjectExpression struct {
+		ExprNode
+		Function   string
+		Entries    []*JsonObjectEntry
+		OnNull     *JsonOnClause
+		Returning  string
+		Strict     bool
+		UniqueKeys bool
+	}
+
+	JsonObjectEntry struct {
+		ExprNode
+		Key        Expr
+		Value      Expr
+		FormatJson bool
+	}
+
+	// JsonArrayExpression is a JSON_ARRAY or JSON_ARRAYAGG call.
+	JsonArrayExpression struct {
+		ExprNode
+		Function  string
+		Elements  []Expr
+		OrderBy   *OrderByClause
+		OnNull    *JsonOnClause
+		Returning string
+		Strict    bool
+	}
+
+	// JsonTableExpression is a JSON_TABLE row source.
+	JsonTableExpression struct {
+		ExprNode
+		Expr       Expr
+		FormatJson bool
+		Path       string
+		OnError    *JsonOnClause
+		OnEmpty    *JsonOnClause
+		Columns    []*JsonTableColumn
+	}
+
+	// JsonTableColumn is a column of a COLUMNS clause. A NESTED PATH
+	// column has no name and its own columns.
+	JsonTableColumn struct {
+		ExprNode
+		Name          string
+		DataType      string
+		FormatJson    bool
+		Exists        bool
+		ForOrdinality bool
+		Path          string
+		Wrapper       string
+		OnError       *JsonOnClause
+		OnEmpty       *JsonOnClause
+		Nested        []*JsonTableColumn
+	}
+
+	// XmlTableExpression is an XMLTABLE row source. Query is the XQuery
+	// expression, usually a string literal.
+	XmlTableExpression struct {
+		ExprNode
+		Namespaces []Expr
+		Query      Expr
+		Passing    []Expr
+		Columns    []*XmlTableColumn
+	}
+
+	XmlTableColumn struct {
+		ExprNode
+		Name          string
+		DataType      string
+		ForOrdinality bool
+		Path          string
+		Default       Expr
+	}
+
+	// XmlAggExpression is an XMLAGG call.
+	XmlAggExpression struct {
+		ExprNode
+		Expr    Expr
+		OrderBy *OrderByClause
+	}
+
+	// XmlElementExpression is an XMLELEMENT call. Name is the element name
+	// as written, or the expression computing it with EVALNAME. Named
+	// attributes and content are AliasExpressions.
+	XmlElementExpression struct {
+		ExprNode
+		Name       Expr
+		EvalName   bool
+		Attributes []Expr
+		Content    []Expr
+	}
 )
 
 func (n *ExprNode) expr() {}
@@ -0,0 +1,2078 @@
+package semantic
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// KeywordCase is the case Format writes keywords in.
+type KeywordCase int
+
+const (
+	UpperKeywords KeywordCase = iota
+	LowerKeywords
+)
+
+// FormatOptions control the layout of Format. The zero value writes upper
+// case keywords and indents by four spaces.
+type FormatOptions struct {
+	Keywords KeywordCase
+	// Indent is the text of one level of indentation, four spaces when
+	// empty.
+	Indent string
+}
+
+// Format writes node to w as PL/SQL source, nil opts meaning the default
+// options. Parsing the source of a script gives a tree equal to it but for
+// the positions, as far as the tree holds what the parser keeps: the
+// parentheses the precedence of the operators needs are written back, and
+// PL/SQL units are followed by a slash.
+//
+// Keywords are written in the case of opts, and so are the words the parser
+// normalizes to upper case, such as operators and cursor attributes. Names,
+// types and literals are written as they are held.
+func Format(w io.Writer, node AstNode, opts *FormatOptions) error {
+	p := &printer{}
+	if opts != nil {
+		p.opts = *opts
+	}
+	if p.opts.Indent == "" {
+		p.opts.Indent = "    "
+	}
+	p.node(node)
+	if p.err != nil {
+		return p.err
+	}
+	_, err := io.WriteString(w, p.buf.String())
+	return err
+}
+
+// The precedence of expressions, from the loosest binding.
+const (
+	precLowest = iota
+	precOr
+	precAnd
+	precNot
+	precCompare
+	precConcat
+	precAdd
+	precMul
+	precPow
+	precSign
+	precAtom
+)
+
+var setOperators = map[SetOperator]string{
+	Union:     "UNION",
+	UnionAll:  "UNION ALL",
+	Intersect: "INTERSECT",
+	Minus:     "MINUS",
+}
+
+type printer struct {
+	opts  FormatOptions
+	buf   strings.Builder
+	depth int
+	err   error
+}
+
+func (p *printer) write(s string) {
+	p.buf.WriteString(s)
+}
+
+func (p *printer) keyword(s string) {
+	if p.opts.Keywords == LowerKeywords {
+		s = strings.ToLower(s)
+	}
+	p.buf.WriteString(s)
+}
+
+// newline starts a line indented to the current depth.
+func (p *printer) newline() {
+	p.buf.WriteByte('\n')
+	p.buf.WriteString(strings.Repeat(p.opts.Indent, p.depth))
+}
+
+func (p *printer) fail(node interface{}) {
+	if p.err == nil {
+		p.err = fmt.Errorf("cannot format %T", node)
+	}
+}
+
+// isNil reports whether node is nil or a nil pointer.
+func isNil(node interface{}) bool {
+	v := reflect.ValueOf(node)
+	return !v.IsValid() || v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+func (p *printer) node(node AstNode) {
+	switch n := node.(type) {
+	case *Script:
+		p.script(n)
+	case Statement:
+		p.statement(n)
+	case Declaration:
+		p.declaration(n)
+	case Expr:
+		p.expr(n, precLowest)
+	default:
+		p.clause(node)
+	}
+}
+
+func (p *printer) script(s *Script) {
+	for i, stmt := range s.Statements {
+		if i > 0 {
+			p.newline()
+		}
+		p.statement(stmt)
+		if isUnit(stmt) {
+			p.newline()
+			p.write("/")
+		}
+	}
+	if len(s.Statements) > 0 {
+		p.write("\n")
+	}
+}
+
+// isUnit reports whether s is a PL/SQL unit, which a script ends with a
+// slash.
+func isUnit(s Statement) bool {
+	switch s.(type) {
+	case *BlockStatement, *CreateProcedureStatement, *CreateFunctionStatement,
+		*CreatePackageStatement, *CreatePackageBodyStatement, *CreateTriggerStatement,
+		*CreateSimpleDmlTriggerStatement, *CreateCompoundDmlTriggerStatement,
+		*CreateNonDmlTriggerStatement, *CreateTypeStatement, *CreateNestTableStatement:
+		return true
+	}
+	return false
+}
+
+// statement writes s and its terminator.
+func (p *printer) statement(s Statement) {
+	if isNil(s) {
+		return
+	}
+	p.stmt(s)
+	switch s := s.(type) {
+	case *LabelDeclaration, *SqlPlusCommand:
+	case *ErrorStatement:
+		if !strings.HasSuffix(strings.TrimSpace(s.Text), ";") {
+			p.write(";")
+		}
+	default:
+		p.write(";")
+	}
+}
+
+// statements writes list one level deeper, a statement a line.
+func (p *printer) statements(list []Statement) {
+	p.depth++
+	for _, s := range list {
+		p.newline()
+		p.statement(s)
+	}
+	p.depth--
+}
+
+func (p *printer) stmt(s Statement) {
+	switch s := s.(type) {
+	case *AssignmentStatement:
+		p.write(s.Left)
+		p.write(" := ")
+		p.expr(s.Right, precLowest)
+	case *BlockStatement:
+		if len(s.Declarations) > 0 {
+			p.keyword("DECLARE")
+			p.declarations(s.Declarations)
+			p.newline()
+		}
+		p.body(s.Body)
+	case *Body:
+		p.body(s)
+	case *IfStatement:
+		p.ifStatement(s)
+	case *LoopStatement:
+		p.keyword("LOOP")
+		p.statements(s.Statements)
+		p.newline()
+		p.keyword("END LOOP")
+	case *OpenStatement:
+		p.keyword("OPEN ")
+		p.write(s.Name)
+	case *OpenForStatement:
+		p.keyword("OPEN ")
+		p.expr(s.Name, precLowest)
+		p.keyword(" FOR ")
+		if query, ok := s.For.(*StatementExpression); ok && isQuery(query.Stmt) {
+			p.query(query.Stmt)
+		} else {
+			p.expr(s.For, precLowest)
+		}
+		if !isNil(s.Using) {
+			p.write(" ")
+			p.expr(s.Using, precLowest)
+		}
+	case *CloseStatement:
+		p.keyword("CLOSE ")
+		p.write(s.Name)
+	case *FetchStatement:
+		p.keyword("FETCH ")
+		p.write(s.Cursor)
+		if s.Into != "" {
+			p.keyword(" INTO ")
+			p.write(s.Into)
+		}
+	case *ExitStatement:
+		p.keyword("EXIT")
+		if !isNil(s.Condition) {
+			p.keyword(" WHEN ")
+			p.expr(s.Condition, precLowest)
+		}
+	case *ContinueStatement:
+		p.keyword("CONTINUE")
+	case *ProcedureCall:
+		p.expr(s.Name, precAtom)
+		if s.Arguments != nil {
+			p.write("(")
+			p.exprs(s.Arguments, precLowest)
+			p.write(")")
+		}
+	case *ReturnStatement:
+		p.keyword("RETURN")
+		if !isNil(s.Name) {
+			p.write(" ")
+			p.expr(s.Name, precLowest)
+		}
+	case *NullStatement:
+		p.keyword("NULL")
+	case *ExecuteImmediateStatement:
+		p.keyword("EXECUTE IMMEDIATE ")
+		p.write(s.Sql)
+		if s.Into != nil {
+			p.write(" ")
+			p.into(s.Into)
+		}
+		if s.Using != nil {
+			p.write(" ")
+			p.expr(s.Using, precLowest)
+		}
+	case *RaiseStatement:
+		p.keyword("RAISE")
+		if s.Name != "" {
+			p.write(" ")
+			p.write(s.Name)
+		}
+	case *GotoStatement:
+		p.keyword("GOTO ")
+		p.write(s.Label)
+	case *LabelDeclaration:
+		p.write("<<")
+		p.write(s.Label)
+		p.write(">>")
+	case *CaseWhenStatement:
+		p.caseStatement(s)
+	case *SelectStatement, *SetOperationStatement:
+		p.query(s)
+	case *DeleteStatement:
+		p.keyword("DELETE FROM ")
+		p.expr(s.Table, precLowest)
+		p.where(s.Where)
+	case *UpdateStatement:
+		p.keyword("UPDATE ")
+		p.expr(s.Table, precLowest)
+		p.newline()
+		p.keyword("SET ")
+		p.exprs(s.SetExprs, precLowest)
+		p.where(s.Where)
+	case *InsertStatement:
+		p.insert(s)
+	case *MergeStatement:
+		p.merge(s)
+	case *MergeUpdateStatement:
+		p.mergeUpdate(s)
+	case *MergeInsertStatement:
+		p.mergeInsert(s)
+	case *CommitStatement:
+		p.keyword("COMMIT")
+	case *RollbackStatement:
+		p.keyword("ROLLBACK")
+		if s.Savepoint != "" {
+			p.keyword(" TO SAVEPOINT ")
+			p.write(s.Savepoint)
+		}
+	case *SavepointStatement:
+		p.keyword("SAVEPOINT ")
+		p.write(s.Name)
+	case *SetTransactionStatement:
+		p.setTransaction(s)
+	case *LockTableStatement:
+		p.lockTable(s)
+	case *AlterSessionStatement:
+		p.keyword("ALTER SESSION ")
+		if len(s.Parameters) == 0 {
+			p.write(s.Clause)
+			break
+		}
+		p.keyword("SET")
+		for _, param := range s.Parameters {
+			p.write(" ")
+			p.sessionParameter(param)
+		}
+	case *CreateProcedureStatement:
+		p.procedure(s, true)
+	case *CreateFunctionStatement:
+		p.function(s, true)
+	case *CreatePackageStatement:
+		p.pkg(s)
+	case *CreatePackageBodyStatement:
+		p.pkgBody(s)
+	case *CreateTriggerStatement:
+		p.trigger(s, nil)
+	case *CreateSimpleDmlTriggerStatement:
+		p.trigger(&s.CreateTriggerStatement, func() { p.simpleDmlTrigger(s) })
+	case *CreateCompoundDmlTriggerStatement:
+		p.trigger(&s.CreateTriggerStatement, func() { p.compoundDmlTrigger(s) })
+	case *CreateNonDmlTriggerStatement:
+		p.trigger(&s.CreateTriggerStatement, func() { p.nonDmlTrigger(s) })
+	case *TriggerBlock:
+		p.triggerBlock(s)
+	case *CompoundTriggerBlock:
+		p.compoundTriggerBlock(s, "")
+	case *TimingPoint:
+		p.timingPoint(s)
+	case *CreateTypeStatement:
+		p.keyword("CREATE TYPE ")
+		p.write(s.Name)
+	case *CreateNestTableStatement:
+		p.keyword("CREATE TYPE ")
+		p.write(s.Name)
+		p.keyword(" AS TABLE OF ")
+		p.write(s.ElementType)
+	case *CreateSynonymStatement:
+		p.keyword("CREATE SYNONYM ")
+		p.expr(s.Synonym, precLowest)
+		p.keyword(" FOR ")
+		p.expr(s.Original, precLowest)
+	case *DropFunctionStatement:
+		p.keyword("DROP FUNCTION ")
+		p.write(s.Name)
+	case *DropProcedureStatement:
+		p.keyword("DROP PROCEDURE ")
+		p.write(s.Name)
+	case *DropTriggerStatement:
+		p.keyword("DROP TRIGGER ")
+		p.write(s.Name)
+	case *DropPackageStatement:
+		p.keyword("DROP PACKAGE ")
+		if s.IsBody {
+			p.keyword("BODY ")
+		}
+		if s.Schema != "" {
+			p.write(s.Schema)
+			p.write(".")
+		}
+		p.write(s.Name)
+	case *SqlPlusCommand:
+		p.keyword(s.Name)
+		if s.Args != "" {
+			if s.Name != "@" && s.Name != "@@" {
+				p.write(" ")
+			}
+			p.write(s.Args)
+		}
+	case *ErrorStatement:
+		p.write(strings.TrimSpace(s.Text))
+	default:
+		p.fail(s)
+	}
+}
+
+// body writes BEGIN, the statements and END.
+func (p *printer) body(b *Body) {
+	p.keyword("BEGIN")
+	if b != nil {
+		p.statements(b.Statements)
+	}
+	p.newline()
+	p.keyword("END")
+}
+
+func (p *printer) ifStatement(s *IfStatement) {
+	p.keyword("IF ")
+	p.expr(s.Condition, precLowest)
+	p.keyword(" THEN")
+	p.statements(s.ThenBlock)
+	for _, elsif := range s.ElseIfs {
+		p.newline()
+		p.keyword("ELSIF ")
+		p.expr(elsif.Condition, precLowest)
+		p.keyword(" THEN")
+		p.statements(elsif.ThenBlock)
+	}
+	if len(s.ElseBlock) > 0 {
+		p.newline()
+		p.keyword("ELSE")
+		p.statements(s.ElseBlock)
+	}
+	p.newline()
+	p.keyword("END IF")
+}
+
+func (p *printer) caseStatement(s *CaseWhenStatement) {
+	p.keyword("CASE")
+	if !isNil(s.Expr) {
+		p.write(" ")
+		p.expr(s.Expr, precLowest)
+	}
+	p.depth++
+	for _, when := range s.WhenClauses {
+		p.newline()
+		p.caseWhenBlock(when)
+	}
+	if s.ElseClause != nil {
+		p.newline()
+		p.keyword("ELSE")
+		p.statements(s.ElseClause.Stmts)
+	}
+	p.depth--
+	p.newline()
+	p.keyword("END CASE")
+}
+
+func (p *printer) caseWhenBlock(b *CaseWhenBlock) {
+	p.keyword("WHEN ")
+	p.expr(b.Condition, precLowest)
+	p.keyword(" THEN")
+	if b.Stmts != nil || isNil(b.Expr) {
+		p.statements(b.Stmts)
+		return
+	}
+	p.write(" ")
+	p.expr(b.Expr, precLowest)
+}
+
+// caseExpr writes the CASE expression s.
+func (p *printer) caseExpr(s *CaseWhenStatement) {
+	p.keyword("CASE")
+	if !isNil(s.Expr) {
+		p.write(" ")
+		p.expr(s.Expr, precLowest)
+	}
+	for _, when := range s.WhenClauses {
+		p.keyword(" WHEN ")
+		p.expr(when.Condition, precLowest)
+		p.keyword(" THEN ")
+		p.expr(when.Expr, precLowest)
+	}
+	if s.ElseClause != nil {
+		p.keyword(" ELSE ")
+		p.expr(s.ElseClause.Expr, precLowest)
+	}
+	p.keyword(" END")
+}
+
+func (p *printer) into(c *IntoClause) {
+	if c.IsBulk {
+		p.keyword("BULK COLLECT ")
+	}
+	p.keyword("INTO ")
+	p.exprs(c.Vars, precLowest)
+}
+
+func (p *printer) where(cond Expr) {
+	if isNil(cond) {
+		return
+	}
+	p.newline()
+	p.keyword("WHERE ")
+	p.expr(cond, precLowest)
+}
+
+func (p *printer) insert(s *InsertStatement) {
+	p.keyword("INSERT ")
+	if len(s.AllInto) == 1 && len(s.Conditions) == 0 && len(s.Else) == 0 &&
+		(s.Select == nil || s.AllInto[0].Values == nil) {
+		p.insertInto(s.AllInto[0])
+		if s.Select != nil {
+			p.newline()
+			p.query(s.Select)
+		}
+		return
+	}
+	switch {
+	case s.IsFirst:
+		p.keyword("FIRST")
+	default:
+		p.keyword("ALL")
+	}
+	p.depth++
+	for _, into := range s.AllInto {
+		p.newline()
+		p.insertInto(into)
+	}
+	for _, c := range s.Conditions {
+		p.newline()
+		p.keyword("WHEN ")
+		p.expr(c.Condition, precLowest)
+		p.keyword(" THEN")
+		p.depth++
+		for _, into := range c.Into {
+			p.newline()
+			p.insertInto(into)
+		}
+		p.depth--
+	}
+	if len(s.Else) > 0 {
+		p.newline()
+		p.keyword("ELSE")
+		p.depth++
+		for _, into := range s.Else {
+			p.newline()
+			p.insertInto(into)
+		}
+		p.depth--
+	}
+	p.depth--
+	if s.Select != nil {
+		p.newline()
+		p.query(s.Select)
+	}
+}
+
+func (p *printer) insertInto(c *InsertIntoClause) {
+	p.keyword("INTO ")
+	if c.Table != nil {
+		p.tableRef(c.Table)
+	}
+	if c.Columns != nil {
+		p.write(" (")
+		p.exprs(c.Columns, precLowest)
+		p.write(")")
+	}
+	if c.Values != nil {
+		p.keyword(" VALUES ")
+		p.write("(")
+		p.exprs(c.Values, precLowest)
+		p.write(")")
+	}
+}
+
+func (p *printer) merge(s *MergeStatement) {
+	p.keyword("MERGE INTO ")
+	if s.Table != nil {
+		p.tableRef(s.Table)
+	}
+	p.newline()
+	p.keyword("USING ")
+	p.expr(s.Using, precLowest)
+	p.newline()
+	p.keyword("ON ")
+	p.write("(")
+	p.expr(s.OnCondition, precLowest)
+	p.write(")")
+	if s.MergeUpdate != nil {
+		p.newline()
+		p.mergeUpdate(s.MergeUpdate)
+	}
+	if s.MergeInsert != nil {
+		p.newline()
+		p.mergeInsert(s.MergeInsert)
+	}
+}
+
+func (p *printer) mergeUpdate(s *MergeUpdateStatement) {
+	p.keyword("WHEN MATCHED THEN UPDATE SET ")
+	p.exprs(s.SetElems, precLowest)
+	if !isNil(s.Where) {
+		p.keyword(" WHERE ")
+		p.expr(s.Where, precLowest)
+	}
+	if !isNil(s.Delete) {
+		p.keyword(" DELETE WHERE ")
+		p.expr(s.Delete, precLowest)
+	}
+}
+
+func (p *printer) mergeInsert(s *MergeInsertStatement) {
+	p.keyword("WHEN NOT MATCHED THEN INSERT")
+	if s.Columns != nil {
+		p.write(" (")
+		p.exprs(s.Columns, precLowest)
+		p.write(")")
+	}
+	p.keyword(" VALUES ")
+	p.write("(")
+	p.exprs(s.Values, precLowest)
+	p.write(")")
+	if !isNil(s.Where) {
+		p.keyword(" WHERE ")
+		p.expr(s.Where, precLowest)
+	}
+}
+
+func (p *printer) setTransaction(s *SetTransactionStatement) {
+	p.keyword("SET TRANSACTION")
+	switch {
+	case s.ReadOnly:
+		p.keyword(" READ ONLY")
+	case s.ReadWrite:
+		p.keyword(" READ WRITE")
+	case s.IsolationLevel != "":
+		p.keyword(" ISOLATION LEVEL ")
+		p.keyword(s.IsolationLevel)
+	case s.RollbackSegment != "":
+		p.keyword(" USE ROLLBACK SEGMENT ")
+		p.write(s.RollbackSegment)
+	}
+	if s.Name != "" {
+		p.keyword(" NAME ")
+		p.write(quote(s.Name))
+	}
+}
+
+func (p *printer) lockTable(s *LockTableStatement) {
+	p.keyword("LOCK TABLE ")
+	for i, t := range s.Tables {
+		if i > 0 {
+			p.write(", ")
+		}
+		p.tableRef(t)
+	}
+	p.keyword(" IN ")
+	p.keyword(s.Mode)
+	p.keyword(" MODE")
+	switch {
+	case s.NoWait:
+		p.keyword(" NOWAIT")
+	case !isNil(s.Wait):
+		p.keyword(" WAIT ")
+		p.expr(s.Wait, precLowest)
+	}
+}
+
+func (p *printer) sessionParameter(param *SessionParameter) {
+	p.write(param.Name)
+	p.write(" = ")
+	p.write(param.Value)
+}
+
+// procedure writes a procedure, with CREATE when it is a unit of its own
+// rather than part of a package. A procedure without a body is a
+// declaration.
+func (p *printer) procedure(s *CreateProcedureStatement, unit bool) {
+	if unit {
+		p.create(s.IsReplace)
+	}
+	p.keyword("PROCEDURE ")
+	p.write(s.Name)
+	p.parameters(s.Parameters)
+	if s.Body == nil && !unit {
+		return
+	}
+	p.keyword(" IS")
+	p.declarations(s.Declarations)
+	p.newline()
+	p.body(s.Body)
+}
+
+func (p *printer) function(s *CreateFunctionStatement, unit bool) {
+	if unit {
+		p.create(s.IsReplace)
+	}
+	p.keyword("FUNCTION ")
+	p.write(s.Name)
+	p.parameters(s.Parameters)
+	p.keyword(" RETURN ")
+	p.write(s.Return)
+	if s.Body == nil && !unit {
+		return
+	}
+	p.keyword(" IS")
+	p.declarations(s.Declarations)
+	p.newline()
+	p.body(s.Body)
+}
+
+func (p *printer) create(replace bool) {
+	p.keyword("CREATE ")
+	if replace {
+		p.keyword("OR REPLACE ")
+	}
+}
+
+func (p *printer) parameters(params []*Parameter) {
+	if len(params) == 0 {
+		return
+	}
+	p.write("(")
+	for i, param := range params {
+		if i > 0 {
+			p.write(", ")
+		}
+		p.parameter(param)
+	}
+	p.write(")")
+}
+
+func (p *printer) parameter(param *Parameter) {
+	p.write(param.Name)
+	if param.DataType != "" {
+		p.write(" ")
+		p.write(param.DataType)
+	}
+}
+
+func (p *printer) pkg(s *CreatePackageStatement) {
+	p.keyword("CREATE OR REPLACE PACKAGE ")
+	p.write(s.Name)
+	p.keyword(" IS")
+	p.depth++
+	for _, d := range s.Types {
+		p.newline()
+		p.declaration(d)
+	}
+	for _, d := range s.Variables {
+		p.newline()
+		p.declaration(d)
+	}
+	for _, proc := range s.Procedures {
+		p.newline()
+		p.procedure(proc, false)
+		p.write(";")
+	}
+	p.depth--
+	p.newline()
+	p.keyword("END")
+}
+
+func (p *printer) pkgBody(s *CreatePackageBodyStatement) {
+	p.keyword("CREATE OR REPLACE PACKAGE BODY ")
+	p.write(s.Name)
+	p.keyword(" IS")
+	p.depth++
+	for _, proc := range s.Procedures {
+		p.newline()
+		p.procedure(proc, false)
+		p.write(";")
+	}
+	for _, fn := range s.Functions {
+		p.newline()
+		p.function(fn, false)
+		p.write(";")
+	}
+	p.depth--
+	p.newline()
+	p.keyword("END")
+}
+
+// trigger writes the parts common to all triggers around those written by
+// kind.
+func (p *printer) trigger(s *CreateTriggerStatement, kind func()) {
+	p.create(s.IsReplace)
+	p.keyword("TRIGGER ")
+	p.write(s.Name)
+	if kind != nil {
+		kind()
+	}
+	if len(s.Follows) > 0 {
+		p.newline()
+		p.keyword("FOLLOWS ")
+		p.write(strings.Join(s.Follows, ", "))
+	}
+	if len(s.Precedes) > 0 {
+		p.newline()
+		p.keyword("PRECEDES ")
+		p.write(strings.Join(s.Precedes, ", "))
+	}
+	if s.Disabled {
+		p.newline()
+		p.keyword("DISABLE")
+	}
+	if !isNil(s.When) {
+		p.newline()
+		p.keyword("WHEN ")
+		p.write("(")
+		p.expr(s.When, precLowest)
+		p.write(")")
+	}
+	p.newline()
+	switch body := s.TriggerBody.(type) {
+	case *TriggerBlock:
+		p.triggerBlock(body)
+	case *CompoundTriggerBlock:
+		p.compoundTriggerBlock(body, s.Name)
+	case nil:
+		p.body(nil)
+	default:
+		p.fail(body)
+	}
+}
+
+func (p *printer) simpleDmlTrigger(s *CreateSimpleDmlTriggerStatement) {
+	p.newline()
+	switch {
+	case s.IsInsteadOf:
+		p.keyword("INSTEAD OF ")
+	case s.IsBefore:
+		p.keyword("BEFORE ")
+	default:
+		p.keyword("AFTER ")
+	}
+	p.dmlEvents(s.Events, s.UpdateColumns, s.NestedTable, s.TableView)
+	if s.Referencing != nil {
+		p.newline()
+		p.referencing(s.Referencing)
+	}
+	if s.ForEachRow {
+		p.newline()
+		p.keyword("FOR EACH ROW")
+	}
+}
+
+func (p *printer) compoundDmlTrigger(s *CreateCompoundDmlTriggerStatement) {
+	p.newline()
+	p.keyword("FOR ")
+	p.dmlEvents(s.Events, s.UpdateColumns, s.NestedTable, s.TableView)
+	if s.Referencing != nil {
+		p.newline()
+		p.referencing(s.Referencing)
+	}
+}
+
+func (p *printer) nonDmlTrigger(s *CreateNonDmlTriggerStatement) {
+	p.newline()
+	if s.IsBefore {
+		p.keyword("BEFORE ")
+	} else {
+		p.keyword("AFTER ")
+	}
+	for i, event := range s.Events {
+		if i > 0 {
+			p.keyword(" OR ")
+		}
+		p.write(event)
+	}
+	p.keyword(" ON ")
+	switch {
+	case s.OnDatabase:
+		p.keyword("DATABASE")
+	case s.Schema != "":
+		p.write(s.Schema)
+		p.keyword(".SCHEMA")
+	default:
+		p.keyword("SCHEMA")
+	}
+}
+
+// dmlEvents writes the events of a DML trigger and the table they are on.
+func (p *printer) dmlEvents(events, columns []string, nested, table string) {
+	of := len(columns) > 0
+	for i, event := range events {
+		if i > 0 {
+			p.keyword(" OR ")
+		}
+		p.write(event)
+		if of && strings.EqualFold(event, "UPDATE") {
+			p.keyword(" OF ")
+			p.write(strings.Join(columns, ", "))
+			of = false
+		}
+	}
+	p.keyword(" ON ")
+	if nested != "" {
+		p.keyword("NESTED TABLE ")
+		p.write(nested)
+		p.keyword(" OF ")
+	}
+	p.write(table)
+}
+
+func (p *printer) referencing(ref *TriggerReferencing) {
+	p.keyword("REFERENCING")
+	for _, name := range []struct{ row, alias string }{
+		{"OLD", ref.Old}, {"NEW", ref.New}, {"PARENT", ref.Parent},
+	} {
+		if name.alias != "" {
+			p.keyword(" " + name.row + " AS ")
+			p.write(name.alias)
+		}
+	}
+}
+
+func (p *printer) triggerBlock(b *TriggerBlock) {
+	if len(b.Declarations) > 0 {
+		p.keyword("DECLARE")
+		p.declarations(b.Declarations)
+		p.newline()
+	}
+	p.body(b.Body)
+}
+
+// compoundTriggerBlock writes the body of the compound trigger name.
+func (p *printer) compoundTriggerBlock(b *CompoundTriggerBlock, name string) {
+	p.keyword("COMPOUND TRIGGER")
+	p.declarations(b.Declarations)
+	p.depth++
+	for _, tp := range b.TimingPoints {
+		p.newline()
+		p.statement(tp)
+	}
+	p.depth--
+	p.newline()
+	p.keyword("END")
+	if name != "" {
+		p.write(" ")
+		p.write(name)
+	}
+}
+
+func (p *printer) timingPoint(s *TimingPoint) {
+	timing := "AFTER"
+	if s.IsBefore {
+		timing = "BEFORE"
+	}
+	if s.ForEachRow {
+		timing += " EACH ROW"
+	} else {
+		timing += " STATEMENT"
+	}
+	p.keyword(timing)
+	p.keyword(" IS")
+	p.newline()
+	p.keyword("BEGIN")
+	if s.Body != nil {
+		p.statements(s.Body.Statements)
+	}
+	p.newline()
+	p.keyword("END ")
+	p.keyword(timing)
+}
+
+// declarations writes list one level deeper, a declaration a line.
+func (p *printer) declarations(list []Declaration) {
+	p.depth++
+	for _, d := range list {
+		p.newline()
+		p.declaration(d)
+	}
+	p.depth--
+}
+
+// declaration writes d and its terminator.
+func (p *printer) declaration(d Declaration) {
+	if isNil(d) {
+		return
+	}
+	switch d := d.(type) {
+	case *VariableDeclaration:
+		p.write(d.Name)
+		p.write(" ")
+		p.write(d.DataType)
+		if !isNil(d.Initialization) {
+			p.write(" := ")
+			p.expr(d.Initialization, precLowest)
+		}
+	case *ExceptionDeclaration:
+		p.write(d.Name)
+		p.keyword(" EXCEPTION")
+	case *CursorDeclaration:
+		p.cursor(d)
+	case *NestTableTypeDeclaration:
+		p.keyword("TYPE ")
+		p.write(d.Name)
+		p.keyword(" IS TABLE OF ")
+		p.write(d.ElementType)
+		if d.IndexBy != "" {
+			p.keyword(" INDEX BY ")
+			p.write(d.IndexBy)
+		}
+	case *FunctionDeclaration:
+		p.keyword("FUNCTION ")
+		p.write(d.Name)
+		p.parameters(d.Parameters)
+		p.keyword(" RETURN ")
+		p.write(d.Return)
+	case *AutonomousTransactionDeclaration:
+		p.keyword("PRAGMA AUTONOMOUS_TRANSACTION")
+	default:
+		p.fail(d)
+		return
+	}
+	p.write(";")
+}
+
+func (p *printer) cursor(d *CursorDeclaration) {
+	if d.IsReference {
+		p.keyword("TYPE ")
+		p.write(d.Name)
+		p.keyword(" IS REF CURSOR")
+		if d.Return != "" {
+			p.keyword(" RETURN ")
+			p.write(d.Return)
+		}
+		return
+	}
+	p.keyword("CURSOR ")
+	p.write(d.Name)
+	p.parameters(d.Parameters)
+	if d.Return != "" {
+		p.keyword(" RETURN ")
+		p.write(d.Return)
+	}
+	if !isNil(d.Stmt) {
+		p.keyword(" IS")
+		p.depth++
+		p.newline()
+		p.query(d.Stmt)
+		p.depth--
+	}
+}
+
+// isQuery reports whether s is a query.
+func isQuery(s Statement) bool {
+	switch s.(type) {
+	case *SelectStatement, *SetOperationStatement:
+		return true
+	}
+	return false
+}
+
+// query writes the query s, without its terminator.
+func (p *printer) query(s Statement) {
+	switch s := s.(type) {
+	case *SelectStatement:
+		p.selectStatement(s)
+	case *SetOperationStatement:
+		for i, member := range s.SelectList {
+			if i > 0 {
+				op := "UNION"
+				if sel, ok := member.(*SelectStatement); ok && sel.SetOperator != nil {
+					op = setOperators[*sel.SetOperator]
+				}
+				p.newline()
+				p.keyword(op)
+				p.newline()
+			}
+			p.query(member)
+		}
+	default:
+		if !isNil(s) {
+			p.stmt(s)
+		}
+	}
+}
+
+func (p *printer) selectStatement(s *SelectStatement) {
+	if s.With != nil {
+		p.with(s.With)
+		p.newline()
+	}
+	p.keyword("SELECT ")
+	if s.Fields != nil {
+		p.fieldList(s.Fields)
+	}
+	if s.From != nil {
+		p.newline()
+		p.keyword("FROM ")
+		p.from(s.From)
+	}
+	p.where(s.Where)
+	if s.Model != nil {
+		p.newline()
+		p.model(s.Model)
+	}
+	if s.ForUpdate != nil {
+		p.newline()
+		p.forUpdate(s.ForUpdate)
+	}
+}
+
+func (p *printer) with(w *WithClause) {
+	p.keyword("WITH ")
+	for i, cte := range w.CTEs {
+		if i > 0 {
+			p.write(",")
+			p.newline()
+		}
+		p.expr(cte, precLowest)
+	}
+}
+
+func (p *printer) fieldList(l *FieldList) {
+	for i, f := range l.Fields {
+		if i > 0 {
+			p.write(", ")
+		}
+		p.selectField(f)
+	}
+}
+
+func (p *printer) selectField(f *SelectField) {
+	switch {
+	case f.WildCard != nil:
+		p.wildCard(f.WildCard)
+	case !isNil(f.Expr):
+		if alias, ok := f.Expr.(*AliasExpression); ok {
+			p.alias(alias, true)
+			return
+		}
+		p.expr(f.Expr, precLowest)
+	}
+}
+
+func (p *printer) wildCard(w *WildCardField) {
+	if w.Table != "" && w.Table != "*" {
+		p.write(w.Table)
+		p.write(".")
+	}
+	p.write("*")
+}
+
+func (p *printer) from(f *FromClause) {
+	for i, ref := range f.TableRefs {
+		if i > 0 {
+			p.write(", ")
+		}
+		p.tableRef(ref)
+	}
+}
+
+func (p *printer) tableRef(t *TableRef) {
+	if !isNil(t.Source) {
+		p.expr(t.Source, precLowest)
+	} else {
+		p.write(t.Table)
+	}
+	if t.Alias != "" {
+		p.write(" ")
+		p.write(t.Alias)
+	}
+	if t.Pivot != nil {
+		p.write(" ")
+		p.pivot(t.Pivot)
+	}
+	if t.Unpivot != nil {
+		p.write(" ")
+		p.unpivot(t.Unpivot)
+	}
+}
+
+func (p *printer) pivot(c *PivotClause) {
+	p.keyword("PIVOT ")
+	if c.IsXML {
+		p.keyword("XML ")
+	}
+	p.write("(")
+	for i, agg := range c.Aggregates {
+		if i > 0 {
+			p.write(", ")
+		}
+		p.pivotElement(agg)
+	}
+	p.keyword(" FOR ")
+	p.columns(c.For)
+	p.keyword(" IN ")
+	p.write("(")
+	switch {
+	case !isNil(c.InQuery):
+		p.query(c.InQuery)
+	case c.InAny:
+		p.keyword("ANY")
+	default:
+		for i, elem := range c.In {
+			if i > 0 {
+				p.write(", ")
+			}
+			p.pivotInElement(elem)
+		}
+	}
+	p.write("))")
+}
+
+func (p *printer) pivotElement(e *PivotElement) {
+	p.write(e.Function)
+	p.write("(")
+	p.expr(e.Expr, precLowest)
+	p.write(")")
+	if e.Alias != "" {
+		p.keyword(" AS ")
+		p.write(e.Alias)
+	}
+}
+
+func (p *printer) pivotInElement(e *PivotInElement) {
+	p.columns(e.Values)
+	if e.Alias != "" {
+		p.keyword(" AS ")
+		p.write(e.Alias)
+	}
+}
+
+func (p *printer) unpivot(c *UnpivotClause) {
+	p.keyword("UNPIVOT ")
+	if c.IncludeNulls {
+		p.keyword("INCLUDE NULLS ")
+	}
+	p.write("(")
+	p.columns(c.Columns)
+	p.keyword(" FOR ")
+	p.columns(c.For)
+	p.keyword(" IN ")
+	p.write("(")
+	for i, elem := range c.In {
+		if i > 0 {
+			p.write(", ")
+		}
+		p.unpivotInElement(elem)
+	}
+	p.write("))")
+}
+
+func (p *printer) unpivotInElement(e *UnpivotInElement) {
+	p.columns(e.Columns)
+	if len(e.Values) > 0 {
+		p.keyword(" AS ")
+		p.columns(e.Values)
+	}
+}
+
+// columns writes a single expression as it is and a list of them in
+// parentheses.
+func (p *printer) columns(exprs []Expr) {
+	if len(exprs) == 1 {
+		p.expr(exprs[0], precLowest)
+		return
+	}
+	p.write("(")
+	p.exprs(exprs, precLowest)
+	p.write(")")
+}
+
+func (p *printer) model(m *ModelClause) {
+	p.keyword("MODEL")
+	for _, opt := range m.CellReferenceOptions {
+		p.write(" ")
+		p.keyword(opt)
+	}
+	if m.ReturnRows != "" {
+		p.write(" ")
+		p.keyword(m.ReturnRows)
+	}
+	p.depth++
+	for _, ref := range m.ReferenceModels {
+		p.newline()
+		p.modelDefinition(ref, true)
+	}
+	if m.MainModel != nil {
+		p.newline()
+		p.modelDefinition(m.MainModel, false)
+	}
+	p.depth--
+}
+
+// modelDefinition writes a reference model, or the main model.
+func (p *printer) modelDefinition(m *ModelDefinition, reference bool) {
+	if reference {
+		p.keyword("REFERENCE ")
+		p.write(m.Name)
+		p.keyword(" ON ")
+		p.write("(")
+		p.query(m.Query)
+		p.write(")")
+		p.newline()
+	} else if m.Name != "" {
+		p.keyword("MAIN ")
+		p.write(m.Name)
+		p.newline()
+	}
+	if m.PartitionBy != nil {
+		p.keyword("PARTITION BY ")
+		p.modelColumns(m.PartitionBy)
+		p.newline()
+	}
+	p.keyword("DIMENSION BY ")
+	p.modelColumns(m.DimensionBy)
+	p.newline()
+	p.keyword("MEASURES ")
+	p.modelColumns(m.Measures)
+	for _, opt := range m.CellReferenceOptions {
+		p.write(" ")
+		p.keyword(opt)
+	}
+	if reference {
+		return
+	}
+	p.newline()
+	if m.RulesOption != "" {
+		p.keyword(m.RulesOption)
+		p.write(" ")
+	}
+	p.write("(")
+	p.depth++
+	for i, rule := range m.Rules {
+		if i > 0 {
+			p.write(",")
+		}
+		p.newline()
+		p.modelRule(rule)
+	}
+	p.depth--
+	p.newline()
+	p.write(")")
+}
+
+func (p *printer) modelColumns(exprs []Expr) {
+	p.write("(")
+	p.exprs(exprs, precLowest)
+	p.write(")")
+}
+
+func (p *printer) modelRule(r *ModelRule) {
+	if r.Option != "" {
+		p.keyword(r.Option)
+		p.write(" ")
+	}
+	p.expr(r.Cell, precLowest)
+	if !isNil(r.OrderBy) {
+		p.write(" ")
+		p.expr(r.OrderBy, precLowest)
+	}
+	p.write(" = ")
+	p.expr(r.Expr, precLowest)
+}
+
+func (p *printer) forUpdate(c *ForUpdateClause) {
+	p.keyword("FOR UPDATE")
+	if !isNil(c.Options) {
+		p.write(" ")
+		p.expr(c.Options, precLowest)
+	}
+}
+
+// clause writes the nodes that are neither statements, declarations nor
+// expressions.
+func (p *printer) clause(node AstNode) {
+	switch n := node.(type) {
+	case *ElseBlock:
+		p.keyword("ELSE")
+		p.statements(n.Statements)
+	case *CaseWhenBlock:
+		p.caseWhenBlock(n)
+	case *IntoClause:
+		p.into(n)
+	case *FieldList:
+		p.fieldList(n)
+	case *SelectField:
+		p.selectField(n)
+	case *WildCardField:
+		p.wildCard(n)
+	case *FromClause:
+		p.keyword("FROM ")
+		p.from(n)
+	case *TableRef:
+		p.tableRef(n)
+	case *PivotClause:
+		p.pivot(n)
+	case *PivotElement:
+		p.pivotElement(n)
+	case *PivotInElement:
+		p.pivotInElement(n)
+	case *UnpivotClause:
+		p.unpivot(n)
+	case *UnpivotInElement:
+		p.unpivotInElement(n)
+	case *ForUpdateClause:
+		p.forUpdate(n)
+	case *WithClause:
+		p.with(n)
+	case *ModelClause:
+		p.model(n)
+	case *ModelDefinition:
+		p.modelDefinition(n, !isNil(n.Query))
+	case *ModelRule:
+		p.modelRule(n)
+	case *InsertIntoClause:
+		p.insertInto(n)
+	case *ConditionalInsertClause:
+		p.keyword("WHEN ")
+		p.expr(n.Condition, precLowest)
+		p.keyword(" THEN")
+		p.depth++
+		for _, into := range n.Into {
+			p.newline()
+			p.insertInto(into)
+		}
+		p.depth--
+	case *SessionParameter:
+		p.sessionParameter(n)
+	case *Parameter:
+		p.parameter(n)
+	case *Argument:
+		p.write(n.Name)
+	case *TriggerReferencing:
+		p.referencing(n)
+	case *ConditionalBlock:
+		p.conditionalBlock(n)
+	case *ConditionalBranch:
+		p.conditionalBranch(n, true)
+	default:
+		p.fail(node)
+	}
+}
+
+func (p *printer) conditionalBlock(b *ConditionalBlock) {
+	for i, branch := range b.Branches {
+		if i > 0 {
+			p.newline()
+		}
+		p.conditionalBranch(branch, i == 0)
+	}
+	p.newline()
+	p.keyword("$END")
+}
+
+// conditionalBranch writes a branch of a conditional compilation block,
+// first telling whether it is the $IF branch.
+func (p *printer) conditionalBranch(b *ConditionalBranch, first bool) {
+	switch {
+	case first:
+		p.keyword("$IF ")
+		p.write(b.Condition)
+		p.keyword(" $THEN")
+	case b.Condition == "":
+		p.keyword("$ELSE")
+	default:
+		p.keyword("$ELSIF ")
+		p.write(b.Condition)
+		p.keyword(" $THEN")
+	}
+	p.depth++
+	for _, node := range b.Nodes {
+		p.newline()
+		p.node(node)
+	}
+	p.depth--
+}
+
+// precedence returns the precedence of e.
+func precedence(e Expr) int {
+	switch e := e.(type) {
+	case *BinaryExpression:
+		return binaryPrecedence(e.Operator)
+	case *UnaryLogicalExpression:
+		return precNot
+	case *RelationalExpression, *InExpression, *LikeExpression, *BetweenExpression:
+		return precCompare
+	case *SignExpression:
+		return precSign
+	}
+	return precAtom
+}
+
+func binaryPrecedence(op string) int {
+	switch strings.ToUpper(op) {
+	case "OR":
+		return precOr
+	case "AND":
+		return precAnd
+	case "=":
+		return precCompare
+	case "||":
+		return precConcat
+	case "+", "-":
+		return precAdd
+	case "*", "/", "MOD":
+		return precMul
+	case "**":
+		return precPow
+	}
+	return precAdd
+}
+
+// expr writes e, in parentheses when it binds looser than min.
+func (p *printer) expr(e Expr, min int) {
+	if isNil(e) {
+		return
+	}
+	if precedence(e) < min {
+		p.write("(")
+		p.expr(e, precLowest)
+		p.write(")")
+		return
+	}
+	switch e := e.(type) {
+	case *NameExpression:
+		p.write(e.Name)
+	case *StringLiteral:
+		p.write(e.Value)
+	case *NumericLiteral:
+		p.write(strconv.FormatInt(e.Value, 10))
+	case *NullExpression:
+		p.keyword("NULL")
+	case *BindNameExpression:
+		p.expr(e.Name, precAtom)
+	case *DotExpression:
+		if !isNil(e.Parent) {
+			p.expr(e.Parent, precAtom)
+			p.write(".")
+		}
+		p.expr(e.Name, precAtom)
+	case *FunctionCallExpression:
+		p.expr(e.Name, precAtom)
+		p.write("(")
+		p.exprs(e.Args, precLowest)
+		p.write(")")
+	case *CastExpression:
+		p.expr(e.Expr, precLowest)
+		p.keyword(" AS ")
+		p.write(e.DataType)
+	case *CursorAttribute:
+		p.write(e.Cursor)
+		p.write("%")
+		p.keyword(e.Attr)
+	case *SqlCursorAttribute:
+		if !isNil(e.Expr) {
+			p.expr(e.Expr, min)
+			break
+		}
+		p.keyword("SQL%")
+		p.keyword(e.Attr)
+	case *SequenceReference:
+		if !isNil(e.Expr) {
+			p.expr(e.Expr, min)
+			break
+		}
+		p.write(e.Sequence)
+		p.write(".")
+		p.keyword(e.Pseudo)
+	case *PseudoColumn:
+		if !isNil(e.Expr) {
+			p.expr(e.Expr, min)
+			break
+		}
+		if e.Table != "" {
+			p.write(e.Table)
+			p.write(".")
+		}
+		p.keyword(e.Name)
+	case *CorrelationReference:
+		if !isNil(e.Expr) {
+			p.expr(e.Expr, min)
+			break
+		}
+		p.write(":")
+		p.keyword(e.Row)
+		p.write(".")
+		p.write(e.Name)
+	case *BinaryExpression:
+		prec := binaryPrecedence(e.Operator)
+		p.expr(e.Left, prec)
+		p.write(" ")
+		switch op := strings.ToUpper(e.Operator); op {
+		case "AND", "OR":
+			p.keyword(op)
+		default:
+			p.write(e.Operator)
+		}
+		p.write(" ")
+		p.expr(e.Right, prec+1)
+	case *UnaryLogicalExpression:
+		if e.Operator == "" {
+			p.keyword("NOT ")
+			p.expr(e.Expr, precCompare)
+			break
+		}
+		p.expr(e.Expr, precCompare)
+		p.keyword(" IS ")
+		if e.Not {
+			p.keyword("NOT ")
+		}
+		p.keyword(e.Operator)
+	case *RelationalExpression:
+		p.expr(e.Left, precCompare)
+		p.write(" ")
+		p.keyword(e.Operator)
+		p.write(" ")
+		p.expr(e.Right, precConcat)
+	case *InExpression:
+		p.expr(e.Expr, precConcat)
+		p.keyword(" IN ")
+		if len(e.Elems) == 1 {
+			if query, ok := e.Elems[0].(*StatementExpression); ok && isQuery(query.Stmt) {
+				p.expr(query, precLowest)
+				break
+			}
+		}
+		p.write("(")
+		p.exprs(e.Elems, precConcat)
+		p.write(")")
+	case *LikeExpression:
+		p.expr(e.Expr, precConcat)
+		p.keyword(" LIKE ")
+		p.expr(e.LikeExpr, precConcat)
+	case *BetweenExpression:
+		p.expr(e.Expr, precConcat)
+		p.keyword(" BETWEEN ")
+		for i, elem := range e.Elems {
+			if i > 0 {
+				p.keyword(" AND ")
+			}
+			p.expr(elem, precConcat)
+		}
+	case *SignExpression:
+		p.write(e.Sign)
+		if inner, ok := e.Expr.(*SignExpression); ok && inner.Sign == e.Sign {
+			// a space keeps -- from starting a comment
+			p.write(" ")
+		}
+		p.expr(e.Expr, precSign)
+	case *ExistsExpression:
+		p.keyword("EXISTS ")
+		p.expr(e.Expr, precAtom)
+	case *QueryExpression:
+		p.write("(")
+		if e.Query != nil {
+			p.depth++
+			p.query(e.Query)
+			p.depth--
+		}
+		p.write(")")
+	case *StatementExpression:
+		switch s := e.Stmt.(type) {
+		case *CaseWhenStatement:
+			p.caseExpr(s)
+		default:
+			p.write("(")
+			p.depth++
+			p.query(s)
+			p.depth--
+			p.write(")")
+		}
+	case *OuterJoinExpression:
+		p.expr(e.Expr, precAtom)
+		p.write("(+)")
+	case *AliasExpression:
+		p.alias(e, false)
+	case *ExprListExpression:
+		p.write("(")
+		p.exprs(e.Exprs, precLowest)
+		p.write(")")
+	case *NamedArgumentExpression:
+		p.expr(e.Name, precAtom)
+		p.write(" => ")
+		p.expr(e.Value, precLowest)
+	case *ForUpdateOptionsExpression:
+		switch {
+		case e.SkipLocked:
+			p.keyword("SKIP LOCKED")
+		case e.NoWait:
+			p.keyword("NOWAIT")
+		case !isNil(e.Wait):
+			p.keyword("WAIT ")
+			p.expr(e.Wait, precLowest)
+		}
+	case *ListaggExpression:
+		p.keyword("LISTAGG")
+		p.write("(")
+		p.exprs(e.Args, precLowest)
+		p.write(")")
+		if !isNil(e.Within) {
+			p.keyword(" WITHIN GROUP ")
+			p.write("(")
+			p.expr(e.Within, precLowest)
+			p.write(")")
+		}
+		if !isNil(e.Over) {
+			p.keyword(" OVER ")
+			p.write("(")
+			p.expr(e.Over, precLowest)
+			p.write(")")
+		}
+	case *OrderByClause:
+		p.keyword("ORDER ")
+		if e.Siblings {
+			p.keyword("SIBLINGS ")
+		}
+		p.keyword("BY ")
+		p.exprs(e.Elements, precLowest)
+	case *OrderByElement:
+		p.expr(e.Item, precLowest)
+		if e.Desc {
+			p.keyword(" DESC")
+		}
+	case *UsingClause:
+		p.keyword("USING ")
+		if e.WildCard != nil {
+			p.write("*")
+			break
+		}
+		p.exprs(e.Elems, precLowest)
+	case *UsingElement:
+		if e.IsIn {
+			p.keyword("IN ")
+		}
+		if e.IsOut {
+			p.keyword("OUT ")
+		}
+		p.expr(e.Elem, precLowest)
+	case *ModelCellExpression:
+		p.expr(e.Measure, precSign)
+		p.write("[")
+		p.exprs(e.Dimensions, precLowest)
+		p.write("]")
+	case *CommonTableExpression:
+		p.expr(e.Name, precAtom)
+		if len(e.ColNameList) > 0 {
+			p.write(" (")
+			p.exprs(e.ColNameList, precLowest)
+			p.write(")")
+		}
+		p.keyword(" AS ")
+		if e.Query != nil {
+			p.expr(e.Query, precLowest)
+		}
+	case *JsonValueExpression:
+		p.jsonValue(e)
+	case *JsonOnClause:
+		p.jsonAction(e)
+	case *JsonObjectExpression:
+		p.jsonObject(e)
+	case *JsonObjectEntry:
+		p.jsonObjectEntry(e)
+	case *JsonArrayExpression:
+		p.jsonArray(e)
+	case *JsonTableExpression:
+		p.jsonTable(e)
+	case *JsonTableColumn:
+		p.jsonTableColumn(e)
+	case *XmlTableExpression:
+		p.xmlTable(e)
+	case *XmlTableColumn:
+		p.xmlTableColumn(e)
+	case *XmlAggExpression:
+		p.keyword("XMLAGG")
+		p.write("(")
+		p.expr(e.Expr, precLowest)
+		if e.OrderBy != nil {
+			p.write(" ")
+			p.expr(e.OrderBy, precLowest)
+		}
+		p.write(")")
+	case *XmlElementExpression:
+		p.xmlElement(e)
+	default:
+		p.fail(e)
+	}
+}
+
+// exprs writes list separated by commas.
+func (p *printer) exprs(list []Expr, min int) {
+	for i, e := range list {
+		if i > 0 {
+			p.write(", ")
+		}
+		p.expr(e, min)
+	}
+}
+
+// alias writes e with its alias, after AS when as is set.
+func (p *printer) alias(e *AliasExpression, as bool) {
+	p.expr(e.Expr, precLowest)
+	if as {
+		p.keyword(" AS ")
+	} else {
+		p.write(" ")
+	}
+	p.write(e.Alias)
+}
+
+// aliases writes list separated by commas, with AS before the aliases.
+func (p *printer) aliases(list []Expr) {
+	for i, e := range list {
+		if i > 0 {
+			p.write(", ")
+		}
+		if alias, ok := e.(*AliasExpression); ok {
+			p.alias(alias, true)
+			continue
+		}
+		p.expr(e, precLowest)
+	}
+}
+
+// quote returns s as a string literal.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// jsonOn writes the ON clause c of target, such as ERROR.
+func (p *printer) jsonOn(c *JsonOnClause, target string) {
+	if c == nil {
+		return
+	}
+	p.write(" ")
+	p.jsonAction(c)
+	p.keyword(" ON " + target)
+}
+
+func (p *printer) jsonAction(c *JsonOnClause) {
+	p.keyword(c.Action)
+	if !isNil(c.Default) {
+		p.write(" ")
+		p.expr(c.Default, precLowest)
+	}
+}
+
+func (p *printer) jsonValue(e *JsonValueExpression) {
+	p.keyword(e.Function)
+	p.write("(")
+	p.expr(e.Expr, precLowest)
+	if e.FormatJson {
+		p.keyword(" FORMAT JSON")
+	}
+	if e.Path != "" {
+		p.write(", ")
+		p.write(quote(e.Path))
+	}
+	if e.Returning != "" {
+		p.keyword(" RETURNING ")
+		p.write(e.Returning)
+	}
+	if e.Wrapper != "" {
+		p.write(" ")
+		p.write(e.Wrapper)
+	}
+	p.jsonOn(e.OnError, "ERROR")
+	p.jsonOn(e.OnEmpty, "EMPTY")
+	p.jsonOn(e.OnMismatch, "MISMATCH")
+	p.write(")")
+}
+
+func (p *printer) jsonObject(e *JsonObjectExpression) {
+	p.keyword(e.Function)
+	p.write("(")
+	agg := strings.EqualFold(e.Function, "JSON_OBJECTAGG")
+	n := 0
+	for _, entry := range e.Entries {
+		if entry == nil {
+			continue
+		}
+		if n > 0 {
+			p.write(", ")
+		}
+		n++
+		if agg {
+			p.keyword("KEY ")
+		}
+		p.jsonObjectEntry(entry)
+	}
+	p.jsonOn(e.OnNull, "NULL")
+	if e.Returning != "" {
+		p.keyword(" RETURNING ")
+		p.write(e.Returning)
+	}
+	if e.Strict {
+		p.keyword(" STRICT")
+	}
+	if e.UniqueKeys {
+		p.keyword(" WITH UNIQUE KEYS")
+	}
+	p.write(")")
+}
+
+func (p *printer) jsonObjectEntry(e *JsonObjectEntry) {
+	if e == nil {
+		return
+	}
+	switch {
+	case isNil(e.Value):
+		p.expr(e.Key, precLowest)
+	case isNil(e.Key):
+		p.expr(e.Value, precLowest)
+	default:
+		p.expr(e.Key, precLowest)
+		p.keyword(" VALUE ")
+		p.expr(e.Value, precLowest)
+	}
+	if e.FormatJson {
+		p.keyword(" FORMAT JSON")
+	}
+}
+
+func (p *printer) jsonArray(e *JsonArrayExpression) {
+	p.keyword(e.Function)
+	p.write("(")
+	p.exprs(e.Elements, precLowest)
+	if e.OrderBy != nil {
+		p.write(" ")
+		p.expr(e.OrderBy, precLowest)
+	}
+	p.jsonOn(e.OnNull, "NULL")
+	if e.Returning != "" {
+		p.keyword(" RETURNING ")
+		p.write(e.Returning)
+	}
+	if e.Strict {
+		p.keyword(" STRICT")
+	}
+	p.write(")")
+}
+
+func (p *printer) jsonTable(e *JsonTableExpression) {
+	p.keyword("JSON_TABLE")
+	p.write("(")
+	p.expr(e.Expr, precLowest)
+	if e.FormatJson {
+		p.keyword(" FORMAT JSON")
+	}
+	if e.Path != "" {
+		p.write(", ")
+		p.write(quote(e.Path))
+	}
+	p.jsonOn(e.OnError, "ERROR")
+	p.jsonOn(e.OnEmpty, "EMPTY")
+	p.jsonTableColumns(e.Columns)
+	p.write(")")
+}
+
+func (p *printer) jsonTableColumns(columns []*JsonTableColumn) {
+	p.keyword(" COLUMNS ")
+	p.write("(")
+	n := 0
+	for _, column := range columns {
+		if column == nil {
+			continue
+		}
+		if n > 0 {
+			p.write(", ")
+		}
+		n++
+		p.jsonTableColumn(column)
+	}
+	p.write(")")
+}
+
+func (p *printer) jsonTableColumn(c *JsonTableColumn) {
+	if c == nil {
+		return
+	}
+	switch {
+	case c.Nested != nil:
+		p.keyword("NESTED PATH ")
+		p.write(quote(c.Path))
+		p.jsonTableColumns(c.Nested)
+		return
+	case c.ForOrdinality:
+		p.write(c.Name)
+		p.keyword(" FOR ORDINALITY")
+		return
+	}
+	p.write(c.Name)
+	if c.DataType != "" {
+		p.write(" ")
+		p.write(c.DataType)
+	}
+	if c.FormatJson {
+		// JSON_QUERY columns: FORMAT JSON [wrapper] PATH
+		p.keyword(" FORMAT JSON")
+		if c.Wrapper != "" {
+			p.write(" ")
+			p.write(c.Wrapper)
+		}
+	}
+	if c.Exists {
+		p.keyword(" EXISTS")
+	}
+	if c.Path != "" {
+		p.keyword(" PATH ")
+		p.write(quote(c.Path))
+	}
+	if c.Wrapper != "" && !c.FormatJson {
+		p.write(" ")
+		p.write(c.Wrapper)
+	}
+	p.jsonOn(c.OnError, "ERROR")
+	p.jsonOn(c.OnEmpty, "EMPTY")
+}
+
+func (p *printer) xmlTable(e *XmlTableExpression) {
+	p.keyword("XMLTABLE")
+	p.write("(")
+	if len(e.Namespaces) > 0 {
+		p.keyword("XMLNAMESPACES")
+		p.write("(")
+		for i, ns := range e.Namespaces {
+			if i > 0 {
+				p.write(", ")
+			}
+			if alias, ok := ns.(*AliasExpression); ok {
+				p.alias(alias, true)
+				continue
+			}
+			p.keyword("DEFAULT ")
+			p.expr(ns, precLowest)
+		}
+		p.write("), ")
+	}
+	p.expr(e.Query, precLowest)
+	if len(e.Passing) > 0 {
+		p.keyword(" PASSING ")
+		p.aliases(e.Passing)
+	}
+	if len(e.Columns) > 0 {
+		p.keyword(" COLUMNS ")
+		for i, column := range e.Columns {
+			if i > 0 {
+				p.write(", ")
+			}
+			p.xmlTableColumn(column)
+		}
+	}
+	p.write(")")
+}
+
+func (p *printer) xmlTableColumn(c *XmlTableColumn) {
+	p.write(c.Name)
+	if c.ForOrdinality {
+		p.keyword(" FOR ORDINALITY")
+		return
+	}
+	if c.DataType != "" {
+		p.write(" ")
+		p.write(c.DataType)
+	}
+	if c.Path != "" {
+		p.keyword(" PATH ")
+		p.write(quote(c.Path))
+	}
+	if !isNil(c.Default) {
+		p.keyword(" DEFAULT ")
+		p.expr(c.Default, precLowest)
+	}
+}
+
+func (p *printer) xmlElement(e *XmlElementExpression) {
+	p.keyword("XMLELEMENT")
+	p.write("(")
+	if e.EvalName {
+		p.keyword("EVALNAME ")
+	}
+	p.expr(e.Name, precLowest)
+	if len(e.Attributes) > 0 {
+		p.write(", ")
+		p.keyword("XMLATTRIBUTES")
+		p.write("(")
+		p.aliases(e.Attributes)
+		p.write(")")
+	}
+	if len(e.Content) > 0 {
+		p.write(", ")
+		p.aliases(e.Content)
+	}
+	p.write(")")
+}
@@ -0,0 +1,107 @@
+package semantic
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	name := func(s string) Expr { return &NameExpression{Name: s} }
+	num := func(v int64) Expr { return &NumericLiteral{Value: v} }
+	binary := func(left Expr, op string, right Expr) *BinaryExpression {
+		return &BinaryExpression{Left: left, Operator: op, Right: right}
+	}
+	tests := []struct {
+		name string
+		node AstNode
+		opts *FormatOptions
+		want string
+	}{
+		{
+			name: "parentheses kept",
+			node: binary(binary(name("batch"), "+", num(1)), "*", num(2)),
+			want: "(batch + 1) * 2",
+		},
+		{
+			name: "parentheses not needed",
+			node: binary(name("batch"), "+", binary(num(1), "*", num(2))),
+			want: "batch + 1 * 2",
+		},
+		{
+			name: "right operand of the same precedence",
+			node: binary(name("a"), "-", binary(name("b"), "-", name("c"))),
+			want: "a - (b - c)",
+		},
+		{
+			name: "logical operators",
+			node: binary(binary(name("a"), "OR", name("b")), "AND", &UnaryLogicalExpression{Expr: name("c")}),
+			want: "(a OR b) AND NOT c",
+		},
+		{
+			name: "lower keywords",
+			node: binary(name("a"), "and", &UnaryLogicalExpression{Expr: name("c"), Operator: "NULL", Not: true}),
+			opts: &FormatOptions{Keywords: LowerKeywords},
+			want: "a and c is not null",
+		},
+		{
+			name: "if statement",
+			node: &IfStatement{
+				Condition: binary(name("total"), ">", num(10)),
+				ThenBlock: []Statement{&NullStatement{}},
+				ElseBlock: []Statement{&AssignmentStatement{Left: "total", Right: num(0)}},
+			},
+			want: "IF total > 10 THEN\n    NULL;\nELSE\n    total := 0;\nEND IF;",
+		},
+		{
+			name: "nil json object entry",
+			node: &JsonObjectExpression{Function: "JSON_OBJECT", Entries: []*JsonObjectEntry{
+				nil,
+				{Key: &StringLiteral{Value: "'id'"}, Value: name("id")},
+				nil,
+				{Key: &StringLiteral{Value: "'total'"}, Value: name("total")},
+			}},
+			want: "JSON_OBJECT('id' VALUE id, 'total' VALUE total)",
+		},
+		{
+			name: "nil json table column",
+			node: &JsonTableExpression{Expr: name("doc"), Path: "$.items[*]", Columns: []*JsonTableColumn{
+				{Name: "seq", ForOrdinality: true},
+				nil,
+				{Path: "$.tags[*]", Nested: []*JsonTableColumn{nil, {Name: "line", ForOrdinality: true}}},
+			}},
+			want: "JSON_TABLE(doc, '$.items[*]' COLUMNS (seq FOR ORDINALITY, NESTED PATH '$.tags[*]' COLUMNS (line FOR ORDINALITY)))",
+		},
+		{
+			name: "procedure",
+			node: &Script{Statements: []Statement{
+				&CreateProcedureStatement{
+					Name:       "archive",
+					IsReplace:  true,
+					Parameters: []*Parameter{{Name: "cutoff", DataType: "date"}},
+					Body: &Body{Statements: []Statement{
+						&LoopStatement{Statements: []Statement{&ExitStatement{}}},
+						&CommitStatement{},
+					}},
+				},
+				&CommitStatement{},
+			}},
+			opts: &FormatOptions{Keywords: LowerKeywords, Indent: "\t"},
+			want: "create or replace procedure archive(cutoff date) is\nbegin\n\tloop\n\t\texit;\n\tend loop;\n\tcommit;\nend;\n/\ncommit;\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf strings.Builder
+			assert.Nil(t, Format(&buf, tt.node, tt.opts))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestFormatUnsupported(t *testing.T) {
+	var buf strings.Builder
+	assert.NotNil(t, Format(&buf, nil, nil))
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,187 @@
+package semantic
+
+import (
+	"strings"
+)
+
+type (
+	// Identifier is a name as Oracle resolves it: unquoted names fold to
+	// upper case, quoted ones keep their case. Name holds the resolved
+	// name without quotes.
+	Identifier struct {
+		Name   string
+		Quoted bool
+	}
+
+	// QualifiedName is a dotted name such as schema.package.member, one
+	// identifier per part.
+	QualifiedName []Identifier
+)
+
+// NewIdentifier returns the identifier written as text in the source.
+func NewIdentifier(text string) Identifier {
+	text = strings.TrimSpace(text)
+	if len(text) >= 2 && text[0] == '"' && text[len(text)-1] == '"' {
+		return Identifier{Name: text[1 : len(text)-1], Quoted: true}
+	}
+	return Identifier{Name: strings.ToUpper(text)}
+}
+
+// String returns the identifier as it would be written in the source,
+// quoted only when the name cannot be written without quotes. Identifiers
+// that refer to the same object have the same string.
+func (id Identifier) String() string {
+	if isPlainName(id.Name) {
+		return id.Name
+	}
+	return `"` + id.Name + `"`
+}
+
+// Equal reports whether id and other refer to the same name.
+func (id Identifier) Equal(other Identifier) bool {
+	return id.Name == other.Name
+}
+
+// EqualFold reports whether id refers to the name written as text.
+func (id Identifier) EqualFold(text string) bool {
+	return id.Equal(NewIdentifier(text))
+}
+
+// isPlainName reports whether name is an unquoted identifier folded to
+// upper case.
+func isPlainName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r >= 'A' && r <= 'Z':
+		case i > 0 && (r >= '0' && r <= '9' || r == '_' || r == '$' || r == '#'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// ParseQualifiedName splits the dotted name written as text in the source
+// into its parts. Dots within quoted parts do not separate.
+func ParseQualifiedName(text string) QualifiedName {
+	parts := SplitQualifiedName(text)
+	name := make(QualifiedName, 0, len(parts))
+	for _, part := range parts {
+		name = append(name, NewIdentifier(part))
+	}
+	return name
+}
+
+// SplitQualifiedName splits text at the dots outside of quotes and returns
+// the parts as they are written.
+func SplitQualifiedName(text string) []string {
+	var parts []string
+	start, quoted := 0, false
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '"':
+			quoted = !quoted
+		case '.':
+			if !quoted {
+				parts = append(parts, strings.TrimSpace(text[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, strings.TrimSpace(text[start:]))
+}
+
+func (q QualifiedName) String() string {
+	parts := make([]string, len(q))
+	for i, id := range q {
+		parts[i] = id.String()
+	}
+	return strings.Join(parts, ".")
+}
+
+// Equal reports whether q and other refer to the same name.
+func (q QualifiedName) Equal(other QualifiedName) bool {
+	if len(q) != len(other) {
+		return false
+	}
+	for i := range q {
+		if !q[i].Equal(other[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Last returns the unqualified name, which is the last part.
+func (q QualifiedName) Last() Identifier {
+	if len(q) == 0 {
+		return Identifier{}
+	}
+	return q[len(q)-1]
+}
+
+// Qualifier returns the parts before the last one, such as the schema of
+// schema.table or the package of pkg.proc.
+func (q QualifiedName) Qualifier() QualifiedName {
+	if len(q) == 0 {
+		return nil
+	}
+	return q[:len(q)-1]
+}
+
+// NormalizeName returns the canonical form of the possibly qualified name
+// written as text, under which it is looked up: `nthis."DATA_ROW"` and
+// `NTHIS.data_row` both become NTHIS.DATA_ROW.
+func NormalizeName(text string) string {
+	return ParseQualifiedName(text).String()
+}
+
+// Ident returns the identifier of the name.
+func (e *NameExpression) Ident() Identifier {
+	return NewIdentifier(e.Name)
+}
+
+// QualifiedName returns the dotted name of the chain, or nil when a part of
+// it is not a plain name.
+func (e *DotExpression) QualifiedName() QualifiedName {
+	name, ok := e.Name.(*NameExpression)
+	if !ok {
+		return nil
+	}
+	var q QualifiedName
+	switch parent := e.Parent.(type) {
+	case nil:
+	case *DotExpression:
+		if q = parent.QualifiedName(); q == nil {
+			return nil
+		}
+	case *NameExpression:
+		q = QualifiedName{parent.Ident()}
+	default:
+		return nil
+	}
+	return append(q[:len(q):len(q)], name.Ident())
+}
+
+// QualifiedName returns the name of the package.
+func (s *CreatePackageStatement) QualifiedName() QualifiedName {
+	return ParseQualifiedName(s.Name)
+}
+
+// QualifiedName returns the name of the package.
+func (s *CreatePackageBodyStatement) QualifiedName() QualifiedName {
+	return ParseQualifiedName(s.Name)
+}
+
+// QualifiedName returns the name of the procedure.
+func (s *CreateProcedureStatement) QualifiedName() QualifiedName {
+	return ParseQualifiedName(s.Name)
+}
+
+// QualifiedName returns the name of the function.
+func (s *CreateFunctionStatement) QualifiedName() QualifiedName {
+	return ParseQualifiedName(s.Name)
+}
@@ -5,23 +5,26 @@ var declTypes = Types{{
 	Fields:  "semantic.AutonomousTransactionDeclaration",
 	Comment: "",
 }, {
-	Name:    "CursorDeclaration",
-	Fields:  "semantic.CursorDeclaration",
-	Comment: "",
+	Name:     "CursorDeclaration",
+	Fields:   "semantic.CursorDeclaration",
+	Comment:  "",
+	Children: []Child{{"Parameters", ChildNodes}, {"Stmt", ChildInterface}},
 }, {
 	Name:    "ExceptionDeclaration",
 	Fields:  "semantic.ExceptionDeclaration",
 	Comment: "",
 }, {
-	Name:    "FunctionDeclaration",
-	Fields:  "semantic.FunctionDeclaration",
-	Comment: "",
+	Name:     "FunctionDeclaration",
+	Fields:   "semantic.FunctionDeclaration",
+	Comment:  "",
+	Children: []Child{{"Parameters", ChildNodes}},
 }, {
 	Name:    "NestTableTypeDeclaration",
 	Fields:  "semantic.NestTableTypeDeclaration",
 	Comment: "",
 }, {
-	Name:    "VariableDeclaration",
-	Fields:  "semantic.VariableDeclaration",
-	Comment: "",
+	Name:     "VariableDeclaration",
+	Fields:   "semantic.VariableDeclaration",
+	Comment:  "",
+	Children: []Child{{"Initialization", ChildInterface}},
 }}
@@ -1,73 +1,133 @@
 package main
 
 var exprTypes = Types{{
-	Name:    "AliasExpression",
-	Fields:  "semantic.AliasExpression",
-	Comment: "",
-}, {
-	Name:    "BetweenExpression",
-	Fields:  "semantic.BetweenExpression",
-	Comment: "",
-}, {
-	Name:    "BinaryExpression",
-	Fields:  "semantic.BinaryExpression",
-	Comment: "",
-}, {
-	Name:    "BindNameExpression",
-	Fields:  "semantic.BindNameExpression",
-	Comment: "",
-}, {
-	Name:    "CastExpression",
-	Fields:  "semantic.CastExpression",
-	Comment: "",
-}, {
-	Name:    "CommonTableExpression",
-	Fields:  "semantic.CommonTableExpression",
-	Comment: "",
+	Name:     "AliasExpression",
+	Fields:   "semantic.AliasExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}},
+}, {
+	Name:     "BetweenExpression",
+	Fields:   "semantic.BetweenExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}, {"Elems", ChildInterfaces}},
+}, {
+	Name:     "BinaryExpression",
+	Fields:   "semantic.BinaryExpression",
+	Comment:  "",
+	Children: []Child{{"Left", ChildInterface}, {"Right", ChildInterface}},
+}, {
+	Name:     "BindNameExpression",
+	Fields:   "semantic.BindNameExpression",
+	Comment:  "",
+	Children: []Child{{"Name", ChildInterface}},
+}, {
+	Name:     "CastExpression",
+	Fields:   "semantic.CastExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}},
+}, {
+	Name:     "CommonTableExpression",
+	Fields:   "semantic.CommonTableExpression",
+	Comment:  "",
+	Children: []Child{{"Name", ChildInterface}, {"Query", ChildNode}, {"ColNameList", ChildInterfaces}},
+}, {
+	Name:     "CorrelationReference",
+	Fields:   "semantic.CorrelationReference",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}},
 }, {
 	Name:    "CursorAttribute",
 	Fields:  "semantic.CursorAttribute",
 	Comment: "",
 }, {
-	Name:    "DotExpression",
-	Fields:  "semantic.DotExpression",
-	Comment: "",
-}, {
-	Name:    "ExistsExpression",
-	Fields:  "semantic.ExistsExpression",
-	Comment: "",
-}, {
-	Name:    "ExprListExpression",
-	Fields:  "semantic.ExprListExpression",
-	Comment: "",
-}, {
-	Name:    "ForUpdateOptionsExpression",
-	Fields:  "semantic.ForUpdateOptionsExpression",
-	Comment: "",
-}, {
-	Name:    "FunctionCallExpression",
-	Fields:  "semantic.FunctionCallExpression",
-	Comment: "",
-}, {
-	Name:    "InExpression",
-	Fields:  "semantic.InExpression",
-	Comment: "",
-}, {
-	Name:    "LikeExpression",
-	Fields:  "semantic.LikeExpression",
-	Comment: "",
-}, {
-	Name:    "ListaggExpression",
-	Fields:  "semantic.ListaggExpression",
-	Comment: "",
+	Name:     "DotExpression",
+	Fields:   "semantic.DotExpression",
+	Comment:  "",
+	Children: []Child{{"Name", ChildInterface}, {"Parent", ChildInterface}},
+}, {
+	Name:     "ExistsExpression",
+	Fields:   "semantic.ExistsExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}},
+}, {
+	Name:     "ExprListExpression",
+	Fields:   "semantic.ExprListExpression",
+	Comment:  "",
+	Children: []Child{{"Exprs", ChildInterfaces}},
+}, {
+	Name:     "ForUpdateOptionsExpression",
+	Fields:   "semantic.ForUpdateOptionsExpression",
+	Comment:  "",
+	Children: []Child{{"Wait", ChildInterface}},
+}, {
+	Name:     "FunctionCallExpression",
+	Fields:   "semantic.FunctionCallExpression",
+	Comment:  "",
+	Children: []Child{{"Name", ChildInterface}, {"Args", ChildInterfaces}},
+}, {
+	Name:     "InExpression",
+	Fields:   "semantic.InExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}, {"Elems", ChildInterfaces}},
+}, {
+	Name:     "JsonArrayExpression",
+	Fields:   "semantic.JsonArrayExpression",
+	Comment:  "",
+	Children: []Child{{"Elements", ChildInterfaces}, {"OrderBy", ChildNode}, {"OnNull", ChildNode}},
+}, {
+	Name:     "JsonObjectEntry",
+	Fields:   "semantic.JsonObjectEntry",
+	Comment:  "",
+	Children: []Child{{"Key", ChildInterface}, {"Value", ChildInterface}},
+}, {
+	Name:     "JsonObjectExpression",
+	Fields:   "semantic.JsonObjectExpression",
+	Comment:  "",
+	Children: []Child{{"Entries", ChildNodes}, {"OnNull", ChildNode}},
+}, {
+	Name:     "JsonOnClause",
+	Fields:   "semantic.JsonOnClause",
+	Comment:  "",
+	Children: []Child{{"Default", ChildInterface}},
+}, {
+	Name:     "JsonTableColumn",
+	Fields:   "semantic.JsonTableColumn",
+	Comment:  "",
+	Children: []Child{{"OnError", ChildNode}, {"OnEmpty", ChildNode}, {"Nested", ChildNodes}},
+}, {
+	Name:     "JsonTableExpression",
+	Fields:   "semantic.JsonTableExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}, {"OnError", ChildNode}, {"OnEmpty", ChildNode}, {"Columns", ChildNodes}},
+}, {
+	Name:     "JsonValueExpression",
+	Fields:   "semantic.JsonValueExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}, {"OnError", ChildNode}, {"OnEmpty", ChildNode}, {"OnMismatch", ChildNode}},
+}, {
+	Name:     "LikeExpression",
+	Fields:   "semantic.LikeExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}, {"LikeExpr", ChildInterface}},
+}, {
+	Name:     "ListaggExpression",
+	Fields:   "semantic.ListaggExpression",
+	Comment:  "",
+	Children: []Child{{"Args", ChildInterfaces}, {"Within", ChildInterface}, {"Over", ChildInterface}},
+}, {
+	Name:     "ModelCellExpression",
+	Fields:   "semantic.ModelCellExpression",
+	Comment:  "",
+	Children: []Child{{"Measure", ChildInterface}, {"Dimensions", ChildInterfaces}},
 }, {
 	Name:    "NameExpression",
 	Fields:  "semantic.NameExpression",
 	Comment: "",
 }, {
-	Name:    "NamedArgumentExpression",
-	Fields:  "semantic.NamedArgumentExpression",
-	Comment: "",
+	Name:     "NamedArgumentExpression",
+	Fields:   "semantic.NamedArgumentExpression",
+	Comment:  "",
+	Children: []Child{{"Name", ChildInterface}, {"Value", ChildInterface}},
 }, {
 	Name:    "NullExpression",
 	Fields:  "semantic.NullExpression",
@@ -77,47 +137,92 @@ var exprTypes = Types{{
 	Fields:  "semantic.NumericLiteral",
 	Comment: "",
 }, {
-	Name:    "OrderByClause",
-	Fields:  "semantic.OrderByClause",
-	Comment: "",
-}, {
-	Name:    "OrderByElement",
-	Fields:  "semantic.OrderByElement",
-	Comment: "",
-}, {
-	Name:    "OuterJoinExpression",
-	Fields:  "semantic.OuterJoinExpression",
-	Comment: "",
-}, {
-	Name:    "QueryExpression",
-	Fields:  "semantic.QueryExpression",
-	Comment: "",
-}, {
-	Name:    "RelationalExpression",
-	Fields:  "semantic.RelationalExpression",
-	Comment: "",
-}, {
-	Name:    "SignExpression",
-	Fields:  "semantic.SignExpression",
-	Comment: "",
-}, {
-	Name:    "StatementExpression",
-	Fields:  "semantic.StatementExpression",
-	Comment: "",
+	Name:     "OrderByClause",
+	Fields:   "semantic.OrderByClause",
+	Comment:  "",
+	Children: []Child{{"Elements", ChildInterfaces}},
+}, {
+	Name:     "OrderByElement",
+	Fields:   "semantic.OrderByElement",
+	Comment:  "",
+	Children: []Child{{"Item", ChildInterface}},
+}, {
+	Name:     "OuterJoinExpression",
+	Fields:   "semantic.OuterJoinExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}},
+}, {
+	Name:     "PseudoColumn",
+	Fields:   "semantic.PseudoColumn",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}},
+}, {
+	Name:     "QueryExpression",
+	Fields:   "semantic.QueryExpression",
+	Comment:  "",
+	Children: []Child{{"Query", ChildNode}},
+}, {
+	Name:     "RelationalExpression",
+	Fields:   "semantic.RelationalExpression",
+	Comment:  "",
+	Children: []Child{{"Left", ChildInterface}, {"Right", ChildInterface}},
+}, {
+	Name:     "SequenceReference",
+	Fields:   "semantic.SequenceReference",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}},
+}, {
+	Name:     "SignExpression",
+	Fields:   "semantic.SignExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}},
+}, {
+	Name:     "SqlCursorAttribute",
+	Fields:   "semantic.SqlCursorAttribute",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}},
+}, {
+	Name:     "StatementExpression",
+	Fields:   "semantic.StatementExpression",
+	Comment:  "",
+	Children: []Child{{"Stmt", ChildInterface}},
 }, {
 	Name:    "StringLiteral",
 	Fields:  "semantic.StringLiteral",
 	Comment: "",
 }, {
-	Name:    "UnaryLogicalExpression",
-	Fields:  "semantic.UnaryLogicalExpression",
-	Comment: "",
-}, {
-	Name:    "UsingClause",
-	Fields:  "semantic.UsingClause",
-	Comment: "",
-}, {
-	Name:    "UsingElement",
-	Fields:  "semantic.UsingElement",
-	Comment: "",
+	Name:     "UnaryLogicalExpression",
+	Fields:   "semantic.UnaryLogicalExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}},
+}, {
+	Name:     "UsingClause",
+	Fields:   "semantic.UsingClause",
+	Comment:  "",
+	Children: []Child{{"Elems", ChildInterfaces}},
+}, {
+	Name:     "UsingElement",
+	Fields:   "semantic.UsingElement",
+	Comment:  "",
+	Children: []Child{{"Elem", ChildInterface}},
+}, {
+	Name:     "XmlAggExpression",
+	Fields:   "semantic.XmlAggExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}, {"OrderBy", ChildNode}},
+}, {
+	Name:     "XmlElementExpression",
+	Fields:   "semantic.XmlElementExpression",
+	Comment:  "",
+	Children: []Child{{"Name", ChildInterface}, {"Attributes", ChildInterfaces}, {"Content", ChildInterfaces}},
+}, {
+	Name:     "XmlTableColumn",
+	Fields:   "semantic.XmlTableColumn",
+	Comment:  "",
+	Children: []Child{{"Default", ChildInterface}},
+}, {
+	Name:     "XmlTableExpression",
+	Fields:   "semantic.XmlTableExpression",
+	Comment:  "",
+	Children: []Child{{"Namespaces", ChildInterfaces}, {"Query", ChildInterface}, {"Passing", ChildInterfaces}, {"Columns", ChildNodes}},
 }}
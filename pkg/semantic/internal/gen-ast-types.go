@@ -93,6 +93,10 @@ func main() {
 		return
 	}
 
+	g.WriteKinds(nodeTypes)
+
+	g.WriteChildren(nodeTypes)
+
 	err = g.Format()
 	if err != nil {
 		err = fmt.Errorf("formating generated code: %w", err)
@@ -121,8 +125,29 @@ type Type struct {
 	Fields string
 	// optional, comment for this type
 	Comment string
+	// fields that may hold child nodes, in field order
+	Children []Child
 }
 
+// Child is a field of a node that may hold child nodes.
+type Child struct {
+	Name string
+	Kind ChildKind
+}
+
+type ChildKind int
+
+const (
+	// ChildNode is a pointer to a node.
+	ChildNode ChildKind = iota + 1
+	// ChildInterface is an interface, holding a node or not.
+	ChildInterface
+	// ChildNodes is a slice of pointers to nodes.
+	ChildNodes
+	// ChildInterfaces is a slice of interfaces.
+	ChildInterfaces
+)
+
 type Types []Type
 
 func (t Types) Len() int {
@@ -196,6 +221,60 @@ func (g *Generator) WriteGobRegister(kind string, types Types) (err error) {
 	return
 }
 
+// WriteKinds writes nodeKinds, which the JSON decoder looks the kinds of
+// nodes up in.
+func (g *Generator) WriteKinds(types Types) {
+	g.buf.WriteString(`// nodeKinds maps the kind of each node in JSON, the name of its type, to
+// a function making a zero node of the type.
+var nodeKinds = map[string]func() AstNode{
+`)
+	for _, item := range types {
+		fmt.Fprintf(&g.buf, "%[1]q: func() AstNode { return &%[1]s{} },", item.Name)
+		g.linebreak()
+	}
+	g.buf.WriteString("}")
+	g.linebreak()
+	g.linebreak()
+}
+
+// WriteChildren writes appendChildren, which lists the children of a node
+// by a type switch rather than by reflection.
+func (g *Generator) WriteChildren(types Types) {
+	g.buf.WriteString(`// appendChildren appends the children of node to children, in the order
+// of the fields of its type. Types the generator did not see fall back to
+// reflection.
+func appendChildren(children []AstNode, node AstNode) []AstNode {
+	switch n := node.(type) {
+`)
+	for _, item := range types {
+		fmt.Fprintf(&g.buf, "case *%s:", item.Name)
+		g.linebreak()
+		if len(item.Children) > 0 {
+			g.buf.WriteString("if n == nil {\nbreak\n}")
+			g.linebreak()
+		}
+		for _, c := range item.Children {
+			switch c.Kind {
+			case ChildNode:
+				fmt.Fprintf(&g.buf, "if n.%[1]s != nil {\nchildren = append(children, n.%[1]s)\n}", c.Name)
+			case ChildInterface:
+				fmt.Fprintf(&g.buf, "children = appendChild(children, n.%s)", c.Name)
+			case ChildNodes:
+				fmt.Fprintf(&g.buf, "for _, c := range n.%s {\nif c != nil {\nchildren = append(children, c)\n}\n}", c.Name)
+			case ChildInterfaces:
+				fmt.Fprintf(&g.buf, "for _, c := range n.%s {\nchildren = appendChild(children, c)\n}", c.Name)
+			}
+			g.linebreak()
+		}
+	}
+	g.buf.WriteString(`default:
+		return reflectChildren(children, node)
+	}
+	return children
+}
+`)
+}
+
 func (g *Generator) checkExprTypes(kind string, types Types) (err error) {
 	if len(types) == 0 {
 		err = errors.New("provided 0 type")
@@ -1,53 +1,68 @@
 package main
 
 var nodeTypes = Types{{
-	Name:    "AliasExpression",
-	Fields:  "semantic.AliasExpression",
-	Comment: "",
+	Name:     "AliasExpression",
+	Fields:   "semantic.AliasExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}},
+}, {
+	Name:     "AlterSessionStatement",
+	Fields:   "semantic.AlterSessionStatement",
+	Comment:  "",
+	Children: []Child{{"Parameters", ChildNodes}},
 }, {
 	Name:    "Argument",
 	Fields:  "semantic.Argument",
 	Comment: "",
 }, {
-	Name:    "AssignmentStatement",
-	Fields:  "semantic.AssignmentStatement",
-	Comment: "",
+	Name:     "AssignmentStatement",
+	Fields:   "semantic.AssignmentStatement",
+	Comment:  "",
+	Children: []Child{{"Right", ChildInterface}},
 }, {
 	Name:    "AutonomousTransactionDeclaration",
 	Fields:  "semantic.AutonomousTransactionDeclaration",
 	Comment: "",
 }, {
-	Name:    "BetweenExpression",
-	Fields:  "semantic.BetweenExpression",
-	Comment: "",
+	Name:     "BetweenExpression",
+	Fields:   "semantic.BetweenExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}, {"Elems", ChildInterfaces}},
 }, {
-	Name:    "BinaryExpression",
-	Fields:  "semantic.BinaryExpression",
-	Comment: "",
+	Name:     "BinaryExpression",
+	Fields:   "semantic.BinaryExpression",
+	Comment:  "",
+	Children: []Child{{"Left", ChildInterface}, {"Right", ChildInterface}},
 }, {
-	Name:    "BindNameExpression",
-	Fields:  "semantic.BindNameExpression",
-	Comment: "",
+	Name:     "BindNameExpression",
+	Fields:   "semantic.BindNameExpression",
+	Comment:  "",
+	Children: []Child{{"Name", ChildInterface}},
 }, {
-	Name:    "BlockStatement",
-	Fields:  "semantic.BlockStatement",
-	Comment: "",
+	Name:     "BlockStatement",
+	Fields:   "semantic.BlockStatement",
+	Comment:  "",
+	Children: []Child{{"Declarations", ChildInterfaces}, {"Body", ChildNode}},
 }, {
-	Name:    "Body",
-	Fields:  "semantic.Body",
-	Comment: "",
+	Name:     "Body",
+	Fields:   "semantic.Body",
+	Comment:  "",
+	Children: []Child{{"Statements", ChildInterfaces}},
 }, {
-	Name:    "CaseWhenBlock",
-	Fields:  "semantic.CaseWhenBlock",
-	Comment: "",
+	Name:     "CaseWhenBlock",
+	Fields:   "semantic.CaseWhenBlock",
+	Comment:  "",
+	Children: []Child{{"Condition", ChildInterface}, {"Expr", ChildInterface}, {"Stmts", ChildInterfaces}},
 }, {
-	Name:    "CaseWhenStatement",
-	Fields:  "semantic.CaseWhenStatement",
-	Comment: "",
+	Name:     "CaseWhenStatement",
+	Fields:   "semantic.CaseWhenStatement",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}, {"WhenClauses", ChildNodes}, {"ElseClause", ChildNode}},
 }, {
-	Name:    "CastExpression",
-	Fields:  "semantic.CastExpression",
-	Comment: "",
+	Name:     "CastExpression",
+	Fields:   "semantic.CastExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}},
 }, {
 	Name:    "CloseStatement",
 	Fields:  "semantic.CloseStatement",
@@ -57,53 +72,88 @@ var nodeTypes = Types{{
 	Fields:  "semantic.CommitStatement",
 	Comment: "",
 }, {
-	Name:    "CommonTableExpression",
-	Fields:  "semantic.CommonTableExpression",
-	Comment: "",
+	Name:     "CommonTableExpression",
+	Fields:   "semantic.CommonTableExpression",
+	Comment:  "",
+	Children: []Child{{"Name", ChildInterface}, {"Query", ChildNode}, {"ColNameList", ChildInterfaces}},
 }, {
-	Name:    "CompoundTriggerBlock",
-	Fields:  "semantic.CompoundTriggerBlock",
-	Comment: "",
+	Name:     "CompoundTriggerBlock",
+	Fields:   "semantic.CompoundTriggerBlock",
+	Comment:  "",
+	Children: []Child{{"Declarations", ChildInterfaces}, {"TimingPoints", ChildNodes}},
+}, {
+	Name:     "ConditionalBlock",
+	Fields:   "semantic.ConditionalBlock",
+	Comment:  "",
+	Children: []Child{{"Branches", ChildNodes}},
+}, {
+	Name:     "ConditionalBranch",
+	Fields:   "semantic.ConditionalBranch",
+	Comment:  "",
+	Children: []Child{{"Nodes", ChildInterfaces}},
+}, {
+	Name:     "ConditionalInsertClause",
+	Fields:   "semantic.ConditionalInsertClause",
+	Comment:  "",
+	Children: []Child{{"Condition", ChildInterface}, {"Into", ChildNodes}},
 }, {
 	Name:    "ContinueStatement",
 	Fields:  "semantic.ContinueStatement",
 	Comment: "",
 }, {
-	Name:    "CreateCompoundDmlTriggerStatement",
-	Fields:  "semantic.CreateCompoundDmlTriggerStatement",
-	Comment: "",
+	Name:     "CorrelationReference",
+	Fields:   "semantic.CorrelationReference",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}},
 }, {
-	Name:    "CreateFunctionStatement",
-	Fields:  "semantic.CreateFunctionStatement",
-	Comment: "",
+	Name:     "CreateCompoundDmlTriggerStatement",
+	Fields:   "semantic.CreateCompoundDmlTriggerStatement",
+	Comment:  "",
+	Children: []Child{{"When", ChildInterface}, {"TriggerBody", ChildInterface}, {"Referencing", ChildNode}},
+}, {
+	Name:     "CreateFunctionStatement",
+	Fields:   "semantic.CreateFunctionStatement",
+	Comment:  "",
+	Children: []Child{{"Parameters", ChildNodes}, {"Declarations", ChildInterfaces}, {"Body", ChildNode}},
 }, {
 	Name:    "CreateNestTableStatement",
 	Fields:  "semantic.CreateNestTableStatement",
 	Comment: "",
 }, {
-	Name:    "CreatePackageBodyStatement",
-	Fields:  "semantic.CreatePackageBodyStatement",
-	Comment: "",
+	Name:     "CreateNonDmlTriggerStatement",
+	Fields:   "semantic.CreateNonDmlTriggerStatement",
+	Comment:  "",
+	Children: []Child{{"When", ChildInterface}, {"TriggerBody", ChildInterface}},
 }, {
-	Name:    "CreatePackageStatement",
-	Fields:  "semantic.CreatePackageStatement",
-	Comment: "",
+	Name:     "CreatePackageBodyStatement",
+	Fields:   "semantic.CreatePackageBodyStatement",
+	Comment:  "",
+	Children: []Child{{"Procedures", ChildNodes}, {"Functions", ChildNodes}},
 }, {
-	Name:    "CreateProcedureStatement",
-	Fields:  "semantic.CreateProcedureStatement",
-	Comment: "",
+	Name:     "CreatePackageStatement",
+	Fields:   "semantic.CreatePackageStatement",
+	Comment:  "",
+	Children: []Child{{"Procedures", ChildNodes}, {"Types", ChildInterfaces}, {"Variables", ChildInterfaces}},
 }, {
-	Name:    "CreateSimpleDmlTriggerStatement",
-	Fields:  "semantic.CreateSimpleDmlTriggerStatement",
-	Comment: "",
+	Name:     "CreateProcedureStatement",
+	Fields:   "semantic.CreateProcedureStatement",
+	Comment:  "",
+	Children: []Child{{"Parameters", ChildNodes}, {"Declarations", ChildInterfaces}, {"Body", ChildNode}},
 }, {
-	Name:    "CreateSynonymStatement",
-	Fields:  "semantic.CreateSynonymStatement",
-	Comment: "",
+	Name:     "CreateSimpleDmlTriggerStatement",
+	Fields:   "semantic.CreateSimpleDmlTriggerStatement",
+	Comment:  "",
+	Children: []Child{{"When", ChildInterface}, {"TriggerBody", ChildInterface}, {"Referencing", ChildNode}},
 }, {
-	Name:    "CreateTriggerStatement",
-	Fields:  "semantic.CreateTriggerStatement",
-	Comment: "",
+	Name:     "CreateSynonymStatement",
+	Fields:   "semantic.CreateSynonymStatement",
+	Comment:  "",
+	Children: []Child{{"Synonym", ChildInterface}, {"Original", ChildInterface}},
+}, {
+	Name:     "CreateTriggerStatement",
+	Fields:   "semantic.CreateTriggerStatement",
+	Comment:  "",
+	Children: []Child{{"When", ChildInterface}, {"TriggerBody", ChildInterface}},
 }, {
 	Name:    "CreateTypeStatement",
 	Fields:  "semantic.CreateTypeStatement",
@@ -113,17 +163,20 @@ var nodeTypes = Types{{
 	Fields:  "semantic.CursorAttribute",
 	Comment: "",
 }, {
-	Name:    "CursorDeclaration",
-	Fields:  "semantic.CursorDeclaration",
-	Comment: "",
+	Name:     "CursorDeclaration",
+	Fields:   "semantic.CursorDeclaration",
+	Comment:  "",
+	Children: []Child{{"Parameters", ChildNodes}, {"Stmt", ChildInterface}},
 }, {
-	Name:    "DeleteStatement",
-	Fields:  "semantic.DeleteStatement",
-	Comment: "",
+	Name:     "DeleteStatement",
+	Fields:   "semantic.DeleteStatement",
+	Comment:  "",
+	Children: []Child{{"Table", ChildInterface}, {"Where", ChildInterface}},
 }, {
-	Name:    "DotExpression",
-	Fields:  "semantic.DotExpression",
-	Comment: "",
+	Name:     "DotExpression",
+	Fields:   "semantic.DotExpression",
+	Comment:  "",
+	Children: []Child{{"Name", ChildInterface}, {"Parent", ChildInterface}},
 }, {
 	Name:    "DropFunctionStatement",
 	Fields:  "semantic.DropFunctionStatement",
@@ -141,117 +194,204 @@ var nodeTypes = Types{{
 	Fields:  "semantic.DropTriggerStatement",
 	Comment: "",
 }, {
-	Name:    "ElseBlock",
-	Fields:  "semantic.ElseBlock",
+	Name:     "ElseBlock",
+	Fields:   "semantic.ElseBlock",
+	Comment:  "",
+	Children: []Child{{"Statements", ChildInterfaces}},
+}, {
+	Name:    "ErrorStatement",
+	Fields:  "semantic.ErrorStatement",
 	Comment: "",
 }, {
 	Name:    "ExceptionDeclaration",
 	Fields:  "semantic.ExceptionDeclaration",
 	Comment: "",
 }, {
-	Name:    "ExecuteImmediateStatement",
-	Fields:  "semantic.ExecuteImmediateStatement",
-	Comment: "",
+	Name:     "ExecuteImmediateStatement",
+	Fields:   "semantic.ExecuteImmediateStatement",
+	Comment:  "",
+	Children: []Child{{"Into", ChildNode}, {"Using", ChildNode}},
 }, {
-	Name:    "ExistsExpression",
-	Fields:  "semantic.ExistsExpression",
-	Comment: "",
+	Name:     "ExistsExpression",
+	Fields:   "semantic.ExistsExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}},
 }, {
-	Name:    "ExitStatement",
-	Fields:  "semantic.ExitStatement",
-	Comment: "",
+	Name:     "ExitStatement",
+	Fields:   "semantic.ExitStatement",
+	Comment:  "",
+	Children: []Child{{"Condition", ChildInterface}},
 }, {
-	Name:    "ExprListExpression",
-	Fields:  "semantic.ExprListExpression",
-	Comment: "",
+	Name:     "ExprListExpression",
+	Fields:   "semantic.ExprListExpression",
+	Comment:  "",
+	Children: []Child{{"Exprs", ChildInterfaces}},
 }, {
 	Name:    "FetchStatement",
 	Fields:  "semantic.FetchStatement",
 	Comment: "",
 }, {
-	Name:    "FieldList",
-	Fields:  "semantic.FieldList",
-	Comment: "",
+	Name:     "FieldList",
+	Fields:   "semantic.FieldList",
+	Comment:  "",
+	Children: []Child{{"Fields", ChildNodes}},
 }, {
-	Name:    "ForUpdateClause",
-	Fields:  "semantic.ForUpdateClause",
-	Comment: "",
+	Name:     "ForUpdateClause",
+	Fields:   "semantic.ForUpdateClause",
+	Comment:  "",
+	Children: []Child{{"Options", ChildInterface}},
 }, {
-	Name:    "ForUpdateOptionsExpression",
-	Fields:  "semantic.ForUpdateOptionsExpression",
-	Comment: "",
+	Name:     "ForUpdateOptionsExpression",
+	Fields:   "semantic.ForUpdateOptionsExpression",
+	Comment:  "",
+	Children: []Child{{"Wait", ChildInterface}},
 }, {
-	Name:    "FromClause",
-	Fields:  "semantic.FromClause",
-	Comment: "",
+	Name:     "FromClause",
+	Fields:   "semantic.FromClause",
+	Comment:  "",
+	Children: []Child{{"TableRefs", ChildNodes}},
 }, {
-	Name:    "FunctionCallExpression",
-	Fields:  "semantic.FunctionCallExpression",
-	Comment: "",
+	Name:     "FunctionCallExpression",
+	Fields:   "semantic.FunctionCallExpression",
+	Comment:  "",
+	Children: []Child{{"Name", ChildInterface}, {"Args", ChildInterfaces}},
 }, {
-	Name:    "FunctionDeclaration",
-	Fields:  "semantic.FunctionDeclaration",
-	Comment: "",
+	Name:     "FunctionDeclaration",
+	Fields:   "semantic.FunctionDeclaration",
+	Comment:  "",
+	Children: []Child{{"Parameters", ChildNodes}},
 }, {
 	Name:    "GotoStatement",
 	Fields:  "semantic.GotoStatement",
 	Comment: "",
 }, {
-	Name:    "IfStatement",
-	Fields:  "semantic.IfStatement",
-	Comment: "",
-}, {
-	Name:    "InExpression",
-	Fields:  "semantic.InExpression",
-	Comment: "",
-}, {
-	Name:    "InsertIntoClause",
-	Fields:  "semantic.InsertIntoClause",
-	Comment: "",
-}, {
-	Name:    "InsertStatement",
-	Fields:  "semantic.InsertStatement",
-	Comment: "",
-}, {
-	Name:    "IntoClause",
-	Fields:  "semantic.IntoClause",
-	Comment: "",
+	Name:     "IfStatement",
+	Fields:   "semantic.IfStatement",
+	Comment:  "",
+	Children: []Child{{"Condition", ChildInterface}, {"ThenBlock", ChildInterfaces}, {"ElseBlock", ChildInterfaces}, {"ElseIfs", ChildNodes}},
+}, {
+	Name:     "InExpression",
+	Fields:   "semantic.InExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}, {"Elems", ChildInterfaces}},
+}, {
+	Name:     "InsertIntoClause",
+	Fields:   "semantic.InsertIntoClause",
+	Comment:  "",
+	Children: []Child{{"Table", ChildNode}, {"Columns", ChildInterfaces}, {"Values", ChildInterfaces}},
+}, {
+	Name:     "InsertStatement",
+	Fields:   "semantic.InsertStatement",
+	Comment:  "",
+	Children: []Child{{"AllInto", ChildNodes}, {"Conditions", ChildNodes}, {"Else", ChildNodes}, {"Select", ChildNode}},
+}, {
+	Name:     "IntoClause",
+	Fields:   "semantic.IntoClause",
+	Comment:  "",
+	Children: []Child{{"Vars", ChildInterfaces}},
+}, {
+	Name:     "JsonArrayExpression",
+	Fields:   "semantic.JsonArrayExpression",
+	Comment:  "",
+	Children: []Child{{"Elements", ChildInterfaces}, {"OrderBy", ChildNode}, {"OnNull", ChildNode}},
+}, {
+	Name:     "JsonObjectEntry",
+	Fields:   "semantic.JsonObjectEntry",
+	Comment:  "",
+	Children: []Child{{"Key", ChildInterface}, {"Value", ChildInterface}},
+}, {
+	Name:     "JsonObjectExpression",
+	Fields:   "semantic.JsonObjectExpression",
+	Comment:  "",
+	Children: []Child{{"Entries", ChildNodes}, {"OnNull", ChildNode}},
+}, {
+	Name:     "JsonOnClause",
+	Fields:   "semantic.JsonOnClause",
+	Comment:  "",
+	Children: []Child{{"Default", ChildInterface}},
+}, {
+	Name:     "JsonTableColumn",
+	Fields:   "semantic.JsonTableColumn",
+	Comment:  "",
+	Children: []Child{{"OnError", ChildNode}, {"OnEmpty", ChildNode}, {"Nested", ChildNodes}},
+}, {
+	Name:     "JsonTableExpression",
+	Fields:   "semantic.JsonTableExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}, {"OnError", ChildNode}, {"OnEmpty", ChildNode}, {"Columns", ChildNodes}},
+}, {
+	Name:     "JsonValueExpression",
+	Fields:   "semantic.JsonValueExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}, {"OnError", ChildNode}, {"OnEmpty", ChildNode}, {"OnMismatch", ChildNode}},
 }, {
 	Name:    "LabelDeclaration",
 	Fields:  "semantic.LabelDeclaration",
 	Comment: "",
 }, {
-	Name:    "LikeExpression",
-	Fields:  "semantic.LikeExpression",
-	Comment: "",
-}, {
-	Name:    "ListaggExpression",
-	Fields:  "semantic.ListaggExpression",
-	Comment: "",
-}, {
-	Name:    "LoopStatement",
-	Fields:  "semantic.LoopStatement",
-	Comment: "",
-}, {
-	Name:    "MergeInsertStatement",
-	Fields:  "semantic.MergeInsertStatement",
-	Comment: "",
-}, {
-	Name:    "MergeStatement",
-	Fields:  "semantic.MergeStatement",
-	Comment: "",
-}, {
-	Name:    "MergeUpdateStatement",
-	Fields:  "semantic.MergeUpdateStatement",
-	Comment: "",
+	Name:     "LikeExpression",
+	Fields:   "semantic.LikeExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}, {"LikeExpr", ChildInterface}},
+}, {
+	Name:     "ListaggExpression",
+	Fields:   "semantic.ListaggExpression",
+	Comment:  "",
+	Children: []Child{{"Args", ChildInterfaces}, {"Within", ChildInterface}, {"Over", ChildInterface}},
+}, {
+	Name:     "LockTableStatement",
+	Fields:   "semantic.LockTableStatement",
+	Comment:  "",
+	Children: []Child{{"Tables", ChildNodes}, {"Wait", ChildInterface}},
+}, {
+	Name:     "LoopStatement",
+	Fields:   "semantic.LoopStatement",
+	Comment:  "",
+	Children: []Child{{"Statements", ChildInterfaces}},
+}, {
+	Name:     "MergeInsertStatement",
+	Fields:   "semantic.MergeInsertStatement",
+	Comment:  "",
+	Children: []Child{{"Columns", ChildInterfaces}, {"Values", ChildInterfaces}, {"Where", ChildInterface}},
+}, {
+	Name:     "MergeStatement",
+	Fields:   "semantic.MergeStatement",
+	Comment:  "",
+	Children: []Child{{"Table", ChildNode}, {"Using", ChildInterface}, {"OnCondition", ChildInterface}, {"MergeUpdate", ChildNode}, {"MergeInsert", ChildNode}},
+}, {
+	Name:     "MergeUpdateStatement",
+	Fields:   "semantic.MergeUpdateStatement",
+	Comment:  "",
+	Children: []Child{{"SetElems", ChildInterfaces}, {"Where", ChildInterface}, {"Delete", ChildInterface}},
+}, {
+	Name:     "ModelCellExpression",
+	Fields:   "semantic.ModelCellExpression",
+	Comment:  "",
+	Children: []Child{{"Measure", ChildInterface}, {"Dimensions", ChildInterfaces}},
+}, {
+	Name:     "ModelClause",
+	Fields:   "semantic.ModelClause",
+	Comment:  "",
+	Children: []Child{{"ReferenceModels", ChildNodes}, {"MainModel", ChildNode}},
+}, {
+	Name:     "ModelDefinition",
+	Fields:   "semantic.ModelDefinition",
+	Comment:  "",
+	Children: []Child{{"Query", ChildInterface}, {"PartitionBy", ChildInterfaces}, {"DimensionBy", ChildInterfaces}, {"Measures", ChildInterfaces}, {"Rules", ChildNodes}},
+}, {
+	Name:     "ModelRule",
+	Fields:   "semantic.ModelRule",
+	Comment:  "",
+	Children: []Child{{"Cell", ChildInterface}, {"OrderBy", ChildInterface}, {"Expr", ChildInterface}},
 }, {
 	Name:    "NameExpression",
 	Fields:  "semantic.NameExpression",
 	Comment: "",
 }, {
-	Name:    "NamedArgumentExpression",
-	Fields:  "semantic.NamedArgumentExpression",
-	Comment: "",
+	Name:     "NamedArgumentExpression",
+	Fields:   "semantic.NamedArgumentExpression",
+	Comment:  "",
+	Children: []Child{{"Name", ChildInterface}, {"Value", ChildInterface}},
 }, {
 	Name:    "NestTableTypeDeclaration",
 	Fields:  "semantic.NestTableTypeDeclaration",
@@ -269,119 +409,223 @@ var nodeTypes = Types{{
 	Fields:  "semantic.NumericLiteral",
 	Comment: "",
 }, {
-	Name:    "OpenForStatement",
-	Fields:  "semantic.OpenForStatement",
-	Comment: "",
+	Name:     "OpenForStatement",
+	Fields:   "semantic.OpenForStatement",
+	Comment:  "",
+	Children: []Child{{"Name", ChildInterface}, {"For", ChildInterface}, {"Using", ChildInterface}},
 }, {
 	Name:    "OpenStatement",
 	Fields:  "semantic.OpenStatement",
 	Comment: "",
 }, {
-	Name:    "OrderByClause",
-	Fields:  "semantic.OrderByClause",
-	Comment: "",
+	Name:     "OrderByClause",
+	Fields:   "semantic.OrderByClause",
+	Comment:  "",
+	Children: []Child{{"Elements", ChildInterfaces}},
 }, {
-	Name:    "OrderByElement",
-	Fields:  "semantic.OrderByElement",
-	Comment: "",
+	Name:     "OrderByElement",
+	Fields:   "semantic.OrderByElement",
+	Comment:  "",
+	Children: []Child{{"Item", ChildInterface}},
 }, {
-	Name:    "OuterJoinExpression",
-	Fields:  "semantic.OuterJoinExpression",
-	Comment: "",
+	Name:     "OuterJoinExpression",
+	Fields:   "semantic.OuterJoinExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}},
 }, {
 	Name:    "Parameter",
 	Fields:  "semantic.Parameter",
 	Comment: "",
 }, {
-	Name:    "ProcedureCall",
-	Fields:  "semantic.ProcedureCall",
-	Comment: "",
+	Name:     "PivotClause",
+	Fields:   "semantic.PivotClause",
+	Comment:  "",
+	Children: []Child{{"Aggregates", ChildNodes}, {"For", ChildInterfaces}, {"In", ChildNodes}, {"InQuery", ChildInterface}},
 }, {
-	Name:    "QueryExpression",
-	Fields:  "semantic.QueryExpression",
-	Comment: "",
+	Name:     "PivotElement",
+	Fields:   "semantic.PivotElement",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}},
+}, {
+	Name:     "PivotInElement",
+	Fields:   "semantic.PivotInElement",
+	Comment:  "",
+	Children: []Child{{"Values", ChildInterfaces}},
+}, {
+	Name:     "ProcedureCall",
+	Fields:   "semantic.ProcedureCall",
+	Comment:  "",
+	Children: []Child{{"Name", ChildInterface}, {"Arguments", ChildInterfaces}},
+}, {
+	Name:     "PseudoColumn",
+	Fields:   "semantic.PseudoColumn",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}},
+}, {
+	Name:     "QueryExpression",
+	Fields:   "semantic.QueryExpression",
+	Comment:  "",
+	Children: []Child{{"Query", ChildNode}},
 }, {
 	Name:    "RaiseStatement",
 	Fields:  "semantic.RaiseStatement",
 	Comment: "",
 }, {
-	Name:    "RelationalExpression",
-	Fields:  "semantic.RelationalExpression",
-	Comment: "",
+	Name:     "RelationalExpression",
+	Fields:   "semantic.RelationalExpression",
+	Comment:  "",
+	Children: []Child{{"Left", ChildInterface}, {"Right", ChildInterface}},
 }, {
-	Name:    "ReturnStatement",
-	Fields:  "semantic.ReturnStatement",
-	Comment: "",
+	Name:     "ReturnStatement",
+	Fields:   "semantic.ReturnStatement",
+	Comment:  "",
+	Children: []Child{{"Name", ChildInterface}},
 }, {
 	Name:    "RollbackStatement",
 	Fields:  "semantic.RollbackStatement",
 	Comment: "",
 }, {
-	Name:    "Script",
-	Fields:  "semantic.Script",
+	Name:    "SavepointStatement",
+	Fields:  "semantic.SavepointStatement",
 	Comment: "",
 }, {
-	Name:    "SelectField",
-	Fields:  "semantic.SelectField",
-	Comment: "",
+	Name:     "Script",
+	Fields:   "semantic.Script",
+	Comment:  "",
+	Children: []Child{{"Statements", ChildInterfaces}, {"Conditionals", ChildNodes}},
 }, {
-	Name:    "SelectStatement",
-	Fields:  "semantic.SelectStatement",
-	Comment: "",
+	Name:     "SelectField",
+	Fields:   "semantic.SelectField",
+	Comment:  "",
+	Children: []Child{{"WildCard", ChildNode}, {"Expr", ChildInterface}},
 }, {
-	Name:    "SetOperationStatement",
-	Fields:  "semantic.SetOperationStatement",
-	Comment: "",
+	Name:     "SelectStatement",
+	Fields:   "semantic.SelectStatement",
+	Comment:  "",
+	Children: []Child{{"Fields", ChildNode}, {"From", ChildNode}, {"Where", ChildInterface}, {"ForUpdate", ChildNode}, {"With", ChildNode}, {"Model", ChildNode}},
 }, {
-	Name:    "SignExpression",
-	Fields:  "semantic.SignExpression",
+	Name:     "SequenceReference",
+	Fields:   "semantic.SequenceReference",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}},
+}, {
+	Name:    "SessionParameter",
+	Fields:  "semantic.SessionParameter",
 	Comment: "",
 }, {
-	Name:    "StatementExpression",
-	Fields:  "semantic.StatementExpression",
+	Name:     "SetOperationStatement",
+	Fields:   "semantic.SetOperationStatement",
+	Comment:  "",
+	Children: []Child{{"SelectList", ChildInterfaces}},
+}, {
+	Name:    "SetTransactionStatement",
+	Fields:  "semantic.SetTransactionStatement",
 	Comment: "",
+}, {
+	Name:     "SignExpression",
+	Fields:   "semantic.SignExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}},
+}, {
+	Name:     "SqlCursorAttribute",
+	Fields:   "semantic.SqlCursorAttribute",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}},
+}, {
+	Name:     "SqlPlusCommand",
+	Fields:   "semantic.SqlPlusCommand",
+	Comment:  "",
+	Children: []Child{{"Call", ChildInterface}, {"Include", ChildNode}},
+}, {
+	Name:     "StatementExpression",
+	Fields:   "semantic.StatementExpression",
+	Comment:  "",
+	Children: []Child{{"Stmt", ChildInterface}},
 }, {
 	Name:    "StringLiteral",
 	Fields:  "semantic.StringLiteral",
 	Comment: "",
 }, {
-	Name:    "TableRef",
-	Fields:  "semantic.TableRef",
-	Comment: "",
+	Name:     "TableRef",
+	Fields:   "semantic.TableRef",
+	Comment:  "",
+	Children: []Child{{"Source", ChildInterface}, {"Pivot", ChildNode}, {"Unpivot", ChildNode}},
 }, {
-	Name:    "TimingPoint",
-	Fields:  "semantic.TimingPoint",
-	Comment: "",
+	Name:     "TimingPoint",
+	Fields:   "semantic.TimingPoint",
+	Comment:  "",
+	Children: []Child{{"Body", ChildNode}},
 }, {
-	Name:    "TriggerBlock",
-	Fields:  "semantic.TriggerBlock",
-	Comment: "",
+	Name:     "TriggerBlock",
+	Fields:   "semantic.TriggerBlock",
+	Comment:  "",
+	Children: []Child{{"Declarations", ChildInterfaces}, {"Body", ChildNode}},
 }, {
-	Name:    "UnaryLogicalExpression",
-	Fields:  "semantic.UnaryLogicalExpression",
+	Name:    "TriggerReferencing",
+	Fields:  "semantic.TriggerReferencing",
 	Comment: "",
 }, {
-	Name:    "UpdateStatement",
-	Fields:  "semantic.UpdateStatement",
-	Comment: "",
+	Name:     "UnaryLogicalExpression",
+	Fields:   "semantic.UnaryLogicalExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}},
 }, {
-	Name:    "UsingClause",
-	Fields:  "semantic.UsingClause",
-	Comment: "",
+	Name:     "UnpivotClause",
+	Fields:   "semantic.UnpivotClause",
+	Comment:  "",
+	Children: []Child{{"Columns", ChildInterfaces}, {"For", ChildInterfaces}, {"In", ChildNodes}},
 }, {
-	Name:    "UsingElement",
-	Fields:  "semantic.UsingElement",
-	Comment: "",
+	Name:     "UnpivotInElement",
+	Fields:   "semantic.UnpivotInElement",
+	Comment:  "",
+	Children: []Child{{"Columns", ChildInterfaces}, {"Values", ChildInterfaces}},
 }, {
-	Name:    "VariableDeclaration",
-	Fields:  "semantic.VariableDeclaration",
-	Comment: "",
+	Name:     "UpdateStatement",
+	Fields:   "semantic.UpdateStatement",
+	Comment:  "",
+	Children: []Child{{"Table", ChildInterface}, {"Where", ChildInterface}, {"SetExprs", ChildInterfaces}, {"SetValue", ChildInterface}},
+}, {
+	Name:     "UsingClause",
+	Fields:   "semantic.UsingClause",
+	Comment:  "",
+	Children: []Child{{"Elems", ChildInterfaces}},
+}, {
+	Name:     "UsingElement",
+	Fields:   "semantic.UsingElement",
+	Comment:  "",
+	Children: []Child{{"Elem", ChildInterface}},
+}, {
+	Name:     "VariableDeclaration",
+	Fields:   "semantic.VariableDeclaration",
+	Comment:  "",
+	Children: []Child{{"Initialization", ChildInterface}},
 }, {
 	Name:    "WildCardField",
 	Fields:  "semantic.WildCardField",
 	Comment: "",
 }, {
-	Name:    "WithClause",
-	Fields:  "semantic.WithClause",
-	Comment: "",
+	Name:     "WithClause",
+	Fields:   "semantic.WithClause",
+	Comment:  "",
+	Children: []Child{{"CTEs", ChildNodes}},
+}, {
+	Name:     "XmlAggExpression",
+	Fields:   "semantic.XmlAggExpression",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}, {"OrderBy", ChildNode}},
+}, {
+	Name:     "XmlElementExpression",
+	Fields:   "semantic.XmlElementExpression",
+	Comment:  "",
+	Children: []Child{{"Name", ChildInterface}, {"Attributes", ChildInterfaces}, {"Content", ChildInterfaces}},
+}, {
+	Name:     "XmlTableColumn",
+	Fields:   "semantic.XmlTableColumn",
+	Comment:  "",
+	Children: []Child{{"Default", ChildInterface}},
+}, {
+	Name:     "XmlTableExpression",
+	Fields:   "semantic.XmlTableExpression",
+	Comment:  "",
+	Children: []Child{{"Namespaces", ChildInterfaces}, {"Query", ChildInterface}, {"Passing", ChildInterfaces}, {"Columns", ChildNodes}},
 }}
@@ -1,21 +1,30 @@
 package main
 
 var stmtTypes = Types{{
-	Name:    "AssignmentStatement",
-	Fields:  "semantic.AssignmentStatement",
-	Comment: "",
-}, {
-	Name:    "BlockStatement",
-	Fields:  "semantic.BlockStatement",
-	Comment: "",
-}, {
-	Name:    "Body",
-	Fields:  "semantic.Body",
-	Comment: "",
-}, {
-	Name:    "CaseWhenStatement",
-	Fields:  "semantic.CaseWhenStatement",
-	Comment: "",
+	Name:     "AlterSessionStatement",
+	Fields:   "semantic.AlterSessionStatement",
+	Comment:  "",
+	Children: []Child{{"Parameters", ChildNodes}},
+}, {
+	Name:     "AssignmentStatement",
+	Fields:   "semantic.AssignmentStatement",
+	Comment:  "",
+	Children: []Child{{"Right", ChildInterface}},
+}, {
+	Name:     "BlockStatement",
+	Fields:   "semantic.BlockStatement",
+	Comment:  "",
+	Children: []Child{{"Declarations", ChildInterfaces}, {"Body", ChildNode}},
+}, {
+	Name:     "Body",
+	Fields:   "semantic.Body",
+	Comment:  "",
+	Children: []Child{{"Statements", ChildInterfaces}},
+}, {
+	Name:     "CaseWhenStatement",
+	Fields:   "semantic.CaseWhenStatement",
+	Comment:  "",
+	Children: []Child{{"Expr", ChildInterface}, {"WhenClauses", ChildNodes}, {"ElseClause", ChildNode}},
 }, {
 	Name:    "CloseStatement",
 	Fields:  "semantic.CloseStatement",
@@ -25,57 +34,72 @@ var stmtTypes = Types{{
 	Fields:  "semantic.CommitStatement",
 	Comment: "",
 }, {
-	Name:    "CompoundTriggerBlock",
-	Fields:  "semantic.CompoundTriggerBlock",
-	Comment: "",
+	Name:     "CompoundTriggerBlock",
+	Fields:   "semantic.CompoundTriggerBlock",
+	Comment:  "",
+	Children: []Child{{"Declarations", ChildInterfaces}, {"TimingPoints", ChildNodes}},
 }, {
 	Name:    "ContinueStatement",
 	Fields:  "semantic.ContinueStatement",
 	Comment: "",
 }, {
-	Name:    "CreateCompoundDmlTriggerStatement",
-	Fields:  "semantic.CreateCompoundDmlTriggerStatement",
-	Comment: "",
+	Name:     "CreateCompoundDmlTriggerStatement",
+	Fields:   "semantic.CreateCompoundDmlTriggerStatement",
+	Comment:  "",
+	Children: []Child{{"When", ChildInterface}, {"TriggerBody", ChildInterface}, {"Referencing", ChildNode}},
 }, {
-	Name:    "CreateFunctionStatement",
-	Fields:  "semantic.CreateFunctionStatement",
-	Comment: "",
+	Name:     "CreateFunctionStatement",
+	Fields:   "semantic.CreateFunctionStatement",
+	Comment:  "",
+	Children: []Child{{"Parameters", ChildNodes}, {"Declarations", ChildInterfaces}, {"Body", ChildNode}},
 }, {
 	Name:    "CreateNestTableStatement",
 	Fields:  "semantic.CreateNestTableStatement",
 	Comment: "",
 }, {
-	Name:    "CreatePackageBodyStatement",
-	Fields:  "semantic.CreatePackageBodyStatement",
-	Comment: "",
+	Name:     "CreateNonDmlTriggerStatement",
+	Fields:   "semantic.CreateNonDmlTriggerStatement",
+	Comment:  "",
+	Children: []Child{{"When", ChildInterface}, {"TriggerBody", ChildInterface}},
 }, {
-	Name:    "CreatePackageStatement",
-	Fields:  "semantic.CreatePackageStatement",
-	Comment: "",
+	Name:     "CreatePackageBodyStatement",
+	Fields:   "semantic.CreatePackageBodyStatement",
+	Comment:  "",
+	Children: []Child{{"Procedures", ChildNodes}, {"Functions", ChildNodes}},
 }, {
-	Name:    "CreateProcedureStatement",
-	Fields:  "semantic.CreateProcedureStatement",
-	Comment: "",
+	Name:     "CreatePackageStatement",
+	Fields:   "semantic.CreatePackageStatement",
+	Comment:  "",
+	Children: []Child{{"Procedures", ChildNodes}, {"Types", ChildInterfaces}, {"Variables", ChildInterfaces}},
 }, {
-	Name:    "CreateSimpleDmlTriggerStatement",
-	Fields:  "semantic.CreateSimpleDmlTriggerStatement",
-	Comment: "",
+	Name:     "CreateProcedureStatement",
+	Fields:   "semantic.CreateProcedureStatement",
+	Comment:  "",
+	Children: []Child{{"Parameters", ChildNodes}, {"Declarations", ChildInterfaces}, {"Body", ChildNode}},
 }, {
-	Name:    "CreateSynonymStatement",
-	Fields:  "semantic.CreateSynonymStatement",
-	Comment: "",
+	Name:     "CreateSimpleDmlTriggerStatement",
+	Fields:   "semantic.CreateSimpleDmlTriggerStatement",
+	Comment:  "",
+	Children: []Child{{"When", ChildInterface}, {"TriggerBody", ChildInterface}, {"Referencing", ChildNode}},
 }, {
-	Name:    "CreateTriggerStatement",
-	Fields:  "semantic.CreateTriggerStatement",
-	Comment: "",
+	Name:     "CreateSynonymStatement",
+	Fields:   "semantic.CreateSynonymStatement",
+	Comment:  "",
+	Children: []Child{{"Synonym", ChildInterface}, {"Original", ChildInterface}},
+}, {
+	Name:     "CreateTriggerStatement",
+	Fields:   "semantic.CreateTriggerStatement",
+	Comment:  "",
+	Children: []Child{{"When", ChildInterface}, {"TriggerBody", ChildInterface}},
 }, {
 	Name:    "CreateTypeStatement",
 	Fields:  "semantic.CreateTypeStatement",
 	Comment: "",
 }, {
-	Name:    "DeleteStatement",
-	Fields:  "semantic.DeleteStatement",
-	Comment: "",
+	Name:     "DeleteStatement",
+	Fields:   "semantic.DeleteStatement",
+	Comment:  "",
+	Children: []Child{{"Table", ChildInterface}, {"Where", ChildInterface}},
 }, {
 	Name:    "DropFunctionStatement",
 	Fields:  "semantic.DropFunctionStatement",
@@ -93,13 +117,19 @@ var stmtTypes = Types{{
 	Fields:  "semantic.DropTriggerStatement",
 	Comment: "",
 }, {
-	Name:    "ExecuteImmediateStatement",
-	Fields:  "semantic.ExecuteImmediateStatement",
+	Name:    "ErrorStatement",
+	Fields:  "semantic.ErrorStatement",
 	Comment: "",
 }, {
-	Name:    "ExitStatement",
-	Fields:  "semantic.ExitStatement",
-	Comment: "",
+	Name:     "ExecuteImmediateStatement",
+	Fields:   "semantic.ExecuteImmediateStatement",
+	Comment:  "",
+	Children: []Child{{"Into", ChildNode}, {"Using", ChildNode}},
+}, {
+	Name:     "ExitStatement",
+	Fields:   "semantic.ExitStatement",
+	Comment:  "",
+	Children: []Child{{"Condition", ChildInterface}},
 }, {
 	Name:    "FetchStatement",
 	Fields:  "semantic.FetchStatement",
@@ -109,79 +139,111 @@ var stmtTypes = Types{{
 	Fields:  "semantic.GotoStatement",
 	Comment: "",
 }, {
-	Name:    "IfStatement",
-	Fields:  "semantic.IfStatement",
-	Comment: "",
+	Name:     "IfStatement",
+	Fields:   "semantic.IfStatement",
+	Comment:  "",
+	Children: []Child{{"Condition", ChildInterface}, {"ThenBlock", ChildInterfaces}, {"ElseBlock", ChildInterfaces}, {"ElseIfs", ChildNodes}},
 }, {
-	Name:    "InsertStatement",
-	Fields:  "semantic.InsertStatement",
-	Comment: "",
+	Name:     "InsertStatement",
+	Fields:   "semantic.InsertStatement",
+	Comment:  "",
+	Children: []Child{{"AllInto", ChildNodes}, {"Conditions", ChildNodes}, {"Else", ChildNodes}, {"Select", ChildNode}},
 }, {
 	Name:    "LabelDeclaration",
 	Fields:  "semantic.LabelDeclaration",
 	Comment: "",
 }, {
-	Name:    "LoopStatement",
-	Fields:  "semantic.LoopStatement",
-	Comment: "",
+	Name:     "LockTableStatement",
+	Fields:   "semantic.LockTableStatement",
+	Comment:  "",
+	Children: []Child{{"Tables", ChildNodes}, {"Wait", ChildInterface}},
 }, {
-	Name:    "MergeInsertStatement",
-	Fields:  "semantic.MergeInsertStatement",
-	Comment: "",
+	Name:     "LoopStatement",
+	Fields:   "semantic.LoopStatement",
+	Comment:  "",
+	Children: []Child{{"Statements", ChildInterfaces}},
 }, {
-	Name:    "MergeStatement",
-	Fields:  "semantic.MergeStatement",
-	Comment: "",
+	Name:     "MergeInsertStatement",
+	Fields:   "semantic.MergeInsertStatement",
+	Comment:  "",
+	Children: []Child{{"Columns", ChildInterfaces}, {"Values", ChildInterfaces}, {"Where", ChildInterface}},
 }, {
-	Name:    "MergeUpdateStatement",
-	Fields:  "semantic.MergeUpdateStatement",
-	Comment: "",
+	Name:     "MergeStatement",
+	Fields:   "semantic.MergeStatement",
+	Comment:  "",
+	Children: []Child{{"Table", ChildNode}, {"Using", ChildInterface}, {"OnCondition", ChildInterface}, {"MergeUpdate", ChildNode}, {"MergeInsert", ChildNode}},
+}, {
+	Name:     "MergeUpdateStatement",
+	Fields:   "semantic.MergeUpdateStatement",
+	Comment:  "",
+	Children: []Child{{"SetElems", ChildInterfaces}, {"Where", ChildInterface}, {"Delete", ChildInterface}},
 }, {
 	Name:    "NullStatement",
 	Fields:  "semantic.NullStatement",
 	Comment: "",
 }, {
-	Name:    "OpenForStatement",
-	Fields:  "semantic.OpenForStatement",
-	Comment: "",
+	Name:     "OpenForStatement",
+	Fields:   "semantic.OpenForStatement",
+	Comment:  "",
+	Children: []Child{{"Name", ChildInterface}, {"For", ChildInterface}, {"Using", ChildInterface}},
 }, {
 	Name:    "OpenStatement",
 	Fields:  "semantic.OpenStatement",
 	Comment: "",
 }, {
-	Name:    "ProcedureCall",
-	Fields:  "semantic.ProcedureCall",
-	Comment: "",
+	Name:     "ProcedureCall",
+	Fields:   "semantic.ProcedureCall",
+	Comment:  "",
+	Children: []Child{{"Name", ChildInterface}, {"Arguments", ChildInterfaces}},
 }, {
 	Name:    "RaiseStatement",
 	Fields:  "semantic.RaiseStatement",
 	Comment: "",
 }, {
-	Name:    "ReturnStatement",
-	Fields:  "semantic.ReturnStatement",
-	Comment: "",
+	Name:     "ReturnStatement",
+	Fields:   "semantic.ReturnStatement",
+	Comment:  "",
+	Children: []Child{{"Name", ChildInterface}},
 }, {
 	Name:    "RollbackStatement",
 	Fields:  "semantic.RollbackStatement",
 	Comment: "",
 }, {
-	Name:    "SelectStatement",
-	Fields:  "semantic.SelectStatement",
+	Name:    "SavepointStatement",
+	Fields:  "semantic.SavepointStatement",
 	Comment: "",
 }, {
-	Name:    "SetOperationStatement",
-	Fields:  "semantic.SetOperationStatement",
-	Comment: "",
+	Name:     "SelectStatement",
+	Fields:   "semantic.SelectStatement",
+	Comment:  "",
+	Children: []Child{{"Fields", ChildNode}, {"From", ChildNode}, {"Where", ChildInterface}, {"ForUpdate", ChildNode}, {"With", ChildNode}, {"Model", ChildNode}},
 }, {
-	Name:    "TimingPoint",
-	Fields:  "semantic.TimingPoint",
-	Comment: "",
+	Name:     "SetOperationStatement",
+	Fields:   "semantic.SetOperationStatement",
+	Comment:  "",
+	Children: []Child{{"SelectList", ChildInterfaces}},
 }, {
-	Name:    "TriggerBlock",
-	Fields:  "semantic.TriggerBlock",
+	Name:    "SetTransactionStatement",
+	Fields:  "semantic.SetTransactionStatement",
 	Comment: "",
 }, {
-	Name:    "UpdateStatement",
-	Fields:  "semantic.UpdateStatement",
-	Comment: "",
+	Name:     "SqlPlusCommand",
+	Fields:   "semantic.SqlPlusCommand",
+	Comment:  "",
+	Children: []Child{{"Call", ChildInterface}, {"Include", ChildNode}},
+}, {
+	Name:     "TimingPoint",
+	Fields:   "semantic.TimingPoint",
+	Comment:  "",
+	Children: []Child{{"Body", ChildNode}},
+}, {
+	Name:     "TriggerBlock",
+	Fields:   "semantic.TriggerBlock",
+	Comment:  "",
+	Children: []Child{{"Declarations", ChildInterfaces}, {"Body", ChildNode}},
+}, {
+	Name:     "UpdateStatement",
+	Fields:   "semantic.UpdateStatement",
+	Comment:  "",
+	Children: []Child{{"Table", ChildInterface}, {"Where", ChildInterface}, {"SetExprs", ChildInterfaces}, {"SetValue", ChildInterface}},
 }}
@@ -0,0 +1,216 @@
+package semantic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// The JSON form of a tree writes every node as an object whose "kind" is
+// the name of its type, followed by its exported fields under their Go
+// names. The fields of SyntaxNode and the other embedded structs that are
+// not nodes appear in place, so positions sit next to the other fields;
+// an embedded node, such as the CreateTriggerStatement of the kinds of
+// triggers, is an object of its own under the name of its type. Nil
+// pointers, interfaces and slices are written as null. ast.schema.json
+// describes the result.
+
+type (
+	JSONNodeEncoder         struct{}
+	JSONNodeDecoder[T Node] struct{}
+)
+
+// kindKey is the key holding the type of a node.
+const kindKey = "kind"
+
+func NewJSONNodeEncoder() *JSONNodeEncoder {
+	return &JSONNodeEncoder{}
+}
+
+func (n *JSONNodeEncoder) Encode(node Node) ([]byte, error) {
+	var buf bytes.Buffer
+	err := encodeJSON(&buf, reflect.ValueOf(node))
+	return buf.Bytes(), err
+}
+
+func NewJSONNodeDecoder[T Node]() *JSONNodeDecoder[T] {
+	return &JSONNodeDecoder[T]{}
+}
+
+func (n *JSONNodeDecoder[T]) Decode(data []byte) (T, error) {
+	var node T
+	err := decodeJSON(data, reflect.ValueOf(&node).Elem())
+	return node, err
+}
+
+var astNodeType = reflect.TypeOf((*AstNode)(nil)).Elem()
+
+// isNodeType reports whether t is the struct type of a node.
+func isNodeType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && reflect.PtrTo(t).Implements(astNodeType)
+}
+
+func encodeJSON(buf *bytes.Buffer, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Invalid:
+		buf.WriteString("null")
+	case reflect.Interface, reflect.Ptr:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		return encodeJSON(buf, v.Elem())
+	case reflect.Struct:
+		buf.WriteByte('{')
+		first := true
+		if isNodeType(v.Type()) {
+			fmt.Fprintf(buf, "%q:%q", kindKey, v.Type().Name())
+			first = false
+		}
+		if _, err := encodeFields(buf, v, first); err != nil {
+			return err
+		}
+		buf.WriteByte('}')
+	case reflect.Slice:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		buf.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeJSON(buf, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+		return fmt.Errorf("cannot encode %s as JSON", v.Type())
+	default:
+		data, err := json.Marshal(v.Interface())
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+	}
+	return nil
+}
+
+// encodeFields writes the exported fields of the struct v, flattening the
+// embedded structs that are not nodes. first is whether no member of the
+// object has been written yet, and is returned updated.
+func encodeFields(buf *bytes.Buffer, v reflect.Value, first bool) (bool, error) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && !isNodeType(f.Type) {
+			var err error
+			if first, err = encodeFields(buf, v.Field(i), first); err != nil {
+				return first, err
+			}
+			continue
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		fmt.Fprintf(buf, "%q:", f.Name)
+		if err := encodeJSON(buf, v.Field(i)); err != nil {
+			return first, fmt.Errorf("%s.%s: %w", t.Name(), f.Name, err)
+		}
+	}
+	return first, nil
+}
+
+func decodeJSON(data []byte, v reflect.Value) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Interface:
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+		var kind string
+		if err := json.Unmarshal(fields[kindKey], &kind); err != nil || kind == "" {
+			return fmt.Errorf("node of %s has no %s", v.Type(), kindKey)
+		}
+		newNode, ok := nodeKinds[kind]
+		if !ok {
+			return fmt.Errorf("unknown node kind %q", kind)
+		}
+		node := reflect.ValueOf(newNode())
+		if !node.Type().AssignableTo(v.Type()) {
+			return fmt.Errorf("%s node is not a %s", kind, v.Type())
+		}
+		if err := decodeFields(fields, node.Elem()); err != nil {
+			return err
+		}
+		v.Set(node)
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		return decodeJSON(data, v.Elem())
+	case reflect.Struct:
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+		if isNodeType(v.Type()) {
+			var kind string
+			_ = json.Unmarshal(fields[kindKey], &kind)
+			if kind != v.Type().Name() {
+				return fmt.Errorf("%s node is not a %s", kind, v.Type().Name())
+			}
+		}
+		return decodeFields(fields, v)
+	case reflect.Slice:
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+		s := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := decodeJSON(item, s.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+	default:
+		return json.Unmarshal(data, v.Addr().Interface())
+	}
+	return nil
+}
+
+// decodeFields sets the exported fields of the struct v from the members
+// of its object, the counterpart of encodeFields.
+func decodeFields(fields map[string]json.RawMessage, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && !isNodeType(f.Type) {
+			if err := decodeFields(fields, v.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+		data, ok := fields[f.Name]
+		if !ok {
+			continue
+		}
+		if err := decodeJSON(data, v.Field(i)); err != nil {
+			return fmt.Errorf("%s.%s: %w", t.Name(), f.Name, err)
+		}
+	}
+	return nil
+}
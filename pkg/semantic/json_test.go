@@ -0,0 +1,138 @@
+package semantic
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONNodeEncoder(t *testing.T) {
+	trigger := &CreateSimpleDmlTriggerStatement{
+		CreateTriggerStatement: CreateTriggerStatement{
+			SyntaxNode: testPosition(7),
+			Name:       "audit_orders",
+			IsReplace:  true,
+			TriggerBody: &TriggerBlock{Body: &Body{Statements: []Statement{
+				&AssignmentStatement{Left: ":new.amount", Right: &NumericLiteral{Value: 0}},
+			}}},
+		},
+		IsBefore:   true,
+		ForEachRow: true,
+		Events:     []string{"UPDATE"},
+		TableView:  "orders",
+	}
+	tests := []struct {
+		name string
+		node Node
+		// fields are some of the members expected in the JSON object.
+		fields map[string]any
+	}{
+		{
+			name:   "statement",
+			node:   &NullStatement{SyntaxNode: testPosition(3)},
+			fields: map[string]any{"kind": "NullStatement", "SourceLine": 3.0, "Synthetic": false},
+		},
+		{
+			name:   "nil fields",
+			node:   &DeleteStatement{Table: &NameExpression{Name: "orders"}},
+			fields: map[string]any{"kind": "DeleteStatement", "Where": nil},
+		},
+		{
+			name:   "procedure",
+			node:   applyProcedure(),
+			fields: map[string]any{"kind": "Script", "Conditionals": nil},
+		},
+		{
+			name:   "embedded node",
+			node:   trigger,
+			fields: map[string]any{"kind": "CreateSimpleDmlTriggerStatement", "IsBefore": true, "TableView": "orders"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := NewJSONNodeEncoder().Encode(tt.node)
+			require.Nil(t, err)
+			var doc map[string]any
+			require.Nil(t, json.Unmarshal(data, &doc), string(data))
+			for key, value := range tt.fields {
+				assert.Equal(t, value, doc[key], key)
+			}
+
+			decoded, err := NewJSONNodeDecoder[Node]().Decode(data)
+			require.Nil(t, err)
+			assert.Equal(t, tt.node, decoded)
+		})
+	}
+
+	// the embedded CreateTriggerStatement is an object of its own
+	data, err := NewJSONNodeEncoder().Encode(trigger)
+	require.Nil(t, err)
+	var fields map[string]json.RawMessage
+	require.Nil(t, json.Unmarshal(data, &fields))
+	var embedded map[string]any
+	require.Nil(t, json.Unmarshal(fields["CreateTriggerStatement"], &embedded))
+	assert.Equal(t, "CreateTriggerStatement", embedded["kind"])
+	assert.Equal(t, "audit_orders", embedded["Name"])
+	assert.EqualValues(t, 7, embedded["SourceLine"])
+}
+
+func TestJSONNodeDecoder(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		// decode decodes data into the node type of the case.
+		decode func([]byte) (Node, error)
+		want   Node
+	}{
+		{
+			name: "statement",
+			data: `{"kind":"NullStatement","SourceLine":3}`,
+			decode: func(data []byte) (Node, error) {
+				return NewJSONNodeDecoder[Statement]().Decode(data)
+			},
+			want: &NullStatement{SyntaxNode: SyntaxNode{SourceLine: 3}},
+		},
+		{
+			name: "expression in a field",
+			data: `{"kind":"ExitStatement","Condition":{"kind":"NameExpression","Name":"done"}}`,
+			decode: func(data []byte) (Node, error) {
+				return NewJSONNodeDecoder[Statement]().Decode(data)
+			},
+			want: &ExitStatement{Condition: &NameExpression{Name: "done"}},
+		},
+		{
+			name: "statement for an expression",
+			data: `{"kind":"NullStatement"}`,
+			decode: func(data []byte) (Node, error) {
+				return NewJSONNodeDecoder[Expr]().Decode(data)
+			},
+		},
+		{
+			name: "unknown kind",
+			data: `{"kind":"NoSuchStatement"}`,
+			decode: func(data []byte) (Node, error) {
+				return NewJSONNodeDecoder[Statement]().Decode(data)
+			},
+		},
+		{
+			name: "no kind",
+			data: `{"SourceLine":3}`,
+			decode: func(data []byte) (Node, error) {
+				return NewJSONNodeDecoder[Statement]().Decode(data)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := tt.decode([]byte(tt.data))
+			if tt.want == nil {
+				assert.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, tt.want, node)
+		})
+	}
+}
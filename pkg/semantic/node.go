@@ -7,29 +7,60 @@ type (
 		Start, End int
 	}
 
+	// Position is a location in source text. Line and Column start at 1,
+	// Column counting characters; Offset is the byte offset from the start
+	// of the text.
+	Position struct {
+		Line   int
+		Column int
+		Offset int
+	}
+
+	// Range is the extent of a node in source text. End is the position
+	// just past its last character.
+	Range struct {
+		Start, End Position
+	}
+
 	Node interface {
 		// AstNode
 		// Type() NodeType
 		Line() int
 		Column() int
 		Span() Span
+		Range() Range
 	}
 
 	SetPosition interface {
 		SetLine(int)
 		SetColumn(int)
 		SetSpan(Span)
+		SetRange(Range)
 	}
 
+	// SyntaxNode holds the position of a node. SourceLine and SourceCol
+	// are 1-based, like the positions of SourceRange. SourceSpan holds the
+	// character offsets of its first and last character, both 0-based;
+	// SourceRange is the complete range, which nodes the parser makes up
+	// share with the nodes they stand for. Synthetic is set on the nodes a
+	// rewrite added, which have no source text.
 	SyntaxNode struct {
-		SourceLine int
-		SourceCol  int
-		SourceSpan Span
+		SourceLine  int
+		SourceCol   int
+		SourceSpan  Span
+		SourceRange Range
+		Synthetic   bool
 	}
 
 	Script struct {
 		SyntaxNode
 		Statements []Statement
+		// Conditionals lists the conditional compilation blocks of the
+		// script in source order, nested ones included.
+		Conditionals []*ConditionalBlock
+		// MinVersion is the oldest release that accepts all the syntax of
+		// the script, or zero when any release does.
+		MinVersion Version
 	}
 )
 
@@ -62,13 +93,49 @@ func (n *SyntaxNode) SetLine(line int) {
 }
 
 func (n *SyntaxNode) SetColumn(column int) {
-	n.SourceCol = column + 1
+	n.SourceCol = column
 }
 
 func (n *SyntaxNode) SetSpan(span Span) {
 	n.SourceSpan = span
 }
 
+func (n SyntaxNode) Range() Range {
+	return n.SourceRange
+}
+
+func (n *SyntaxNode) SetRange(r Range) {
+	n.SourceRange = r
+}
+
+func (n SyntaxNode) IsSynthetic() bool {
+	return n.Synthetic
+}
+
+func (n *SyntaxNode) SetSynthetic(synthetic bool) {
+	n.Synthetic = synthetic
+}
+
+// IsZero reports whether the range is unset.
+func (r Range) IsZero() bool {
+	return r == Range{}
+}
+
+// Contains reports whether other lies within r.
+func (r Range) Contains(other Range) bool {
+	return r.Start.Offset <= other.Start.Offset && other.End.Offset <= r.End.Offset
+}
+
+// SourceText returns the text of node in src, the source it was parsed
+// from.
+func SourceText(node Node, src string) string {
+	r := node.Range()
+	if r.IsZero() || r.Start.Offset < 0 || r.End.Offset > len(src) || r.Start.Offset > r.End.Offset {
+		return ""
+	}
+	return src[r.Start.Offset:r.End.Offset]
+}
+
 func (*Script) Type() NodeType {
 	return ScriptNode
 }
@@ -0,0 +1,99 @@
+package semantic
+
+// Path records the parent of each node of a tree, to answer questions about
+// the context of a node, such as the procedure or the loop it is in. It is
+// built from the children of the nodes, so it serves parsed and decoded
+// trees alike. A Path does not follow later changes to the tree.
+type Path struct {
+	root    AstNode
+	parents map[AstNode]AstNode
+}
+
+type pathBuilder struct {
+	path  *Path
+	stack Stack[AstNode]
+}
+
+func (b *pathBuilder) Visit(node AstNode) Visitor {
+	if node == nil {
+		b.stack.Pop()
+		return b
+	}
+	if len(b.stack) > 0 {
+		if _, seen := b.path.parents[node]; seen {
+			// a node shared by two parents keeps the first one
+			return nil
+		}
+		b.path.parents[node] = b.stack.Top()
+	}
+	b.stack.Push(node)
+	return b
+}
+
+// NewPath returns the Path of the tree rooted at root.
+func NewPath(root AstNode) *Path {
+	p := &Path{root: root, parents: make(map[AstNode]AstNode)}
+	Walk(&pathBuilder{path: p}, root)
+	return p
+}
+
+// Root returns the root of the tree.
+func (p *Path) Root() AstNode {
+	return p.root
+}
+
+// Parent returns the parent of node, or nil for the root and for the nodes
+// not in the tree.
+func (p *Path) Parent(node AstNode) AstNode {
+	return p.parents[node]
+}
+
+// Ancestors returns the ancestors of node, from its parent up to the root.
+func (p *Path) Ancestors(node AstNode) []AstNode {
+	var ancestors []AstNode
+	for n := p.parents[node]; n != nil; n = p.parents[n] {
+		ancestors = append(ancestors, n)
+	}
+	return ancestors
+}
+
+// Enclosing returns the nearest ancestor of node of type T.
+func Enclosing[T AstNode](p *Path, node AstNode) (T, bool) {
+	for n := p.parents[node]; n != nil; n = p.parents[n] {
+		if t, ok := n.(T); ok {
+			return t, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// EnclosingUnit returns the nearest program unit around node: a package,
+// a procedure or function, a trigger or a type. It returns nil for the
+// nodes of an anonymous block outside of any unit.
+func (p *Path) EnclosingUnit(node AstNode) AstNode {
+	for n := p.parents[node]; n != nil; n = p.parents[n] {
+		if IsProgramUnit(n) {
+			return n
+		}
+	}
+	return nil
+}
+
+// EnclosingLoop returns the innermost loop around node, or nil.
+func (p *Path) EnclosingLoop(node AstNode) *LoopStatement {
+	loop, _ := Enclosing[*LoopStatement](p, node)
+	return loop
+}
+
+// IsProgramUnit reports whether node is a stored program unit.
+func IsProgramUnit(node AstNode) bool {
+	switch node.(type) {
+	case *CreatePackageStatement, *CreatePackageBodyStatement,
+		*CreateProcedureStatement, *CreateFunctionStatement,
+		*CreateSimpleDmlTriggerStatement, *CreateCompoundDmlTriggerStatement,
+		*CreateNonDmlTriggerStatement, *CreateTypeStatement:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package semantic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// findNode returns the first node of type T below root.
+func findNode[T AstNode](root AstNode) T {
+	var found T
+	done := false
+	Inspect(root, func(node AstNode) bool {
+		if n, ok := node.(T); ok && !done {
+			found, done = n, true
+		}
+		return !done
+	})
+	return found
+}
+
+func TestPath(t *testing.T) {
+	decoded := func(t *testing.T, script *Script) *Script {
+		data, err := NewNodeEncoder().Encode(script)
+		require.Nil(t, err)
+		decoded, err := NewNodeDecoder[*Script]().Decode(data)
+		require.Nil(t, err)
+		return decoded
+	}
+	tests := []struct {
+		name string
+		tree func(t *testing.T) *Script
+	}{
+		{"built", func(t *testing.T) *Script { return testProcedure() }},
+		{"decoded", func(t *testing.T) *Script { return decoded(t, testProcedure()) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script := tt.tree(t)
+			path := NewPath(script)
+			update := findNode[*UpdateStatement](script)
+			del := findNode[*DeleteStatement](script)
+			require.NotNil(t, update)
+			require.NotNil(t, del)
+
+			assert.Same(t, script, path.Root())
+			loop := path.EnclosingLoop(update)
+			require.NotNil(t, loop)
+			assert.Same(t, loop, path.Parent(update))
+			assert.Nil(t, path.EnclosingLoop(del))
+
+			unit, ok := path.EnclosingUnit(update).(*CreateProcedureStatement)
+			require.True(t, ok)
+			assert.Equal(t, "archive", unit.Name)
+			assert.Same(t, unit, path.EnclosingUnit(del))
+			body, ok := Enclosing[*Body](path, update)
+			require.True(t, ok)
+			assert.Same(t, unit.Body, body)
+
+			ancestors := path.Ancestors(update)
+			require.Len(t, ancestors, 4)
+			assert.Equal(t, AstNode(script), ancestors[len(ancestors)-1])
+			assert.Nil(t, path.Parent(script))
+			assert.Nil(t, path.Parent(&NullStatement{}))
+		})
+	}
+}
+
+func TestPathOutsideUnits(t *testing.T) {
+	shared := &NameExpression{Name: "x"}
+	first := &AssignmentStatement{Left: "a", Right: shared}
+	second := &AssignmentStatement{Left: "b", Right: shared}
+	block := &BlockStatement{Body: &Body{Statements: []Statement{first, second}}}
+	path := NewPath(&Script{Statements: []Statement{block}})
+
+	assert.Nil(t, path.EnclosingUnit(first))
+	_, ok := Enclosing[*LoopStatement](path, first)
+	assert.False(t, ok)
+	// a node shared by two parents keeps the first one
+	assert.Same(t, first, path.Parent(shared))
+	assert.False(t, IsProgramUnit(block))
+	assert.True(t, IsProgramUnit(&CreatePackageBodyStatement{}))
+}
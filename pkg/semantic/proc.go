@@ -133,13 +133,16 @@ type (
 
 	NestTableTypeDeclaration struct {
 		SyntaxNode
-		Name string
+		Name        string
+		ElementType string
+		IndexBy     string
 	}
 
 	FunctionDeclaration struct {
 		SyntaxNode
 		Name       string
 		Parameters []*Parameter
+		Return     string
 	}
 
 	Parameter struct {
@@ -0,0 +1,524 @@
+package semantic
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// A Selector picks nodes out of a tree, in the manner of CSS selectors. A
+// selector is a list of node types, each followed by attribute predicates
+// in brackets, and separated by combinators:
+//
+//	CreateProcedureStatement LoopStatement UpdateStatement
+//	CreatePackageBodyStatement > CreateProcedureStatement[Name = "charge"]
+//	SelectStatement[From.TableRefs.Table ~= "@"], DeleteStatement[!Where]
+//
+// A type is the name of a node type, one of Statement, Expr and
+// Declaration for the nodes of that kind, or * for any node. Whitespace
+// between two types is the descendant combinator, matching the nodes of
+// the second type anywhere below one of the first, and > the child
+// combinator. Commas separate alternatives.
+//
+// The attribute of a predicate is a dotted path of the fields of the node,
+// or of methods without arguments such as Line; a path through a list
+// reaches the elements. [Attr] holds when some value is set, [!Attr] when
+// none is, and [Attr op value] when some value compares as asked: = and
+// != compare, ~= looks for value in it, ^= and $= check how it starts and
+// ends. Values are strings in single or double quotes, numbers, true or
+// false, and strings compare without regard to case, as Oracle does for
+// names.
+type Selector struct {
+	text string
+	alts [][]selectorStep
+}
+
+type selectorStep struct {
+	// combinator relates the step to the previous one: ' ' for a
+	// descendant, '>' for a child.
+	combinator byte
+	typeName   string
+	// iface is the type of the nodes matched for the names of interfaces,
+	// and node the type for the names of node types.
+	iface reflect.Type
+	node  reflect.Type
+	preds []selectorPredicate
+}
+
+type selectorPredicate struct {
+	path  []string
+	op    string // "" for [Attr], "!" for [!Attr]
+	value string
+}
+
+var selectorInterfaces = map[string]reflect.Type{
+	"Statement":   reflect.TypeOf((*Statement)(nil)).Elem(),
+	"Expr":        reflect.TypeOf((*Expr)(nil)).Elem(),
+	"Declaration": reflect.TypeOf((*Declaration)(nil)).Elem(),
+}
+
+// ParseSelector parses the selector written as text.
+func ParseSelector(text string) (*Selector, error) {
+	p := &selectorParser{text: text}
+	s := &Selector{text: text}
+	for {
+		steps, err := p.selector()
+		if err != nil {
+			return nil, err
+		}
+		s.alts = append(s.alts, steps)
+		p.skipSpace()
+		if p.eof() {
+			return s, nil
+		}
+		if !p.accept(",") {
+			return nil, p.errorf("unexpected %q", p.rest()[:1])
+		}
+	}
+}
+
+// MustParseSelector is like ParseSelector but panics when text is not a
+// valid selector.
+func MustParseSelector(text string) *Selector {
+	s, err := ParseSelector(text)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func (s *Selector) String() string {
+	return s.text
+}
+
+// Select returns the nodes of the tree rooted at root that s matches, in
+// depth-first order.
+func (s *Selector) Select(root AstNode) []AstNode {
+	var matches []AstNode
+	var stack Stack[AstNode]
+	Inspect(root, func(node AstNode) bool {
+		if node == nil {
+			stack.Pop()
+			return true
+		}
+		if s.match(node, stack) {
+			matches = append(matches, node)
+		}
+		stack.Push(node)
+		return true
+	})
+	return matches
+}
+
+// Match reports whether s matches node, whose ancestors are those p
+// records.
+func (s *Selector) Match(p *Path, node AstNode) bool {
+	ancestors := p.Ancestors(node)
+	stack := make([]AstNode, len(ancestors))
+	for i, n := range ancestors {
+		stack[len(stack)-1-i] = n
+	}
+	return s.match(node, stack)
+}
+
+// Select returns the nodes of the tree rooted at root that the selector
+// written as text matches.
+func Select(root AstNode, text string) ([]AstNode, error) {
+	s, err := ParseSelector(text)
+	if err != nil {
+		return nil, err
+	}
+	return s.Select(root), nil
+}
+
+// match reports whether s matches node, whose ancestors are listed in
+// stack from the root down.
+func (s *Selector) match(node AstNode, stack []AstNode) bool {
+	for _, steps := range s.alts {
+		if matchSteps(steps, node, stack) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSteps(steps []selectorStep, node AstNode, stack []AstNode) bool {
+	last := steps[len(steps)-1]
+	if !last.match(node) {
+		return false
+	}
+	if len(steps) == 1 {
+		return true
+	}
+	rest := steps[:len(steps)-1]
+	if last.combinator == '>' {
+		return len(stack) > 0 && matchSteps(rest, stack[len(stack)-1], stack[:len(stack)-1])
+	}
+	for i := len(stack) - 1; i >= 0; i-- {
+		if matchSteps(rest, stack[i], stack[:i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s selectorStep) match(node AstNode) bool {
+	t := reflect.TypeOf(node)
+	switch {
+	case s.node != nil:
+		if t != reflect.PtrTo(s.node) {
+			return false
+		}
+	case s.iface != nil:
+		if !t.Implements(s.iface) {
+			return false
+		}
+	}
+	for _, pred := range s.preds {
+		if !pred.match(node) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p selectorPredicate) match(node AstNode) bool {
+	values := attributeValues(reflect.ValueOf(node), p.path)
+	switch p.op {
+	case "":
+		return anySet(values)
+	case "!":
+		return !anySet(values)
+	case "!=":
+		for _, v := range values {
+			if s, ok := attributeText(v); ok && strings.EqualFold(s, p.value) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, v := range values {
+		s, ok := attributeText(v)
+		if !ok {
+			continue
+		}
+		s, value := strings.ToUpper(s), strings.ToUpper(p.value)
+		switch {
+		case p.op == "=" && s == value,
+			p.op == "~=" && strings.Contains(s, value),
+			p.op == "^=" && strings.HasPrefix(s, value),
+			p.op == "$=" && strings.HasSuffix(s, value):
+			return true
+		}
+	}
+	return false
+}
+
+// anySet reports whether one of values is not the zero value of its type.
+func anySet(values []reflect.Value) bool {
+	for _, v := range values {
+		if !v.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// attributeValues returns the values path reaches from v, going through
+// the elements of lists. Zero values are kept, so that they can be
+// compared; nil pointers and interfaces are left out.
+func attributeValues(v reflect.Value, path []string) []reflect.Value {
+	values := []reflect.Value{v}
+	for _, name := range path {
+		var next []reflect.Value
+		for _, v := range values {
+			for _, elem := range elements(v) {
+				if f, ok := attribute(elem, name); ok {
+					next = append(next, f)
+				}
+			}
+		}
+		values = next
+	}
+	var elems []reflect.Value
+	for _, v := range values {
+		elems = append(elems, elements(v)...)
+	}
+	return elems
+}
+
+// elements returns the elements of the list v, or v itself, leaving out
+// nil pointers and interfaces.
+func elements(v reflect.Value) []reflect.Value {
+	if v.Kind() == reflect.Slice {
+		var elems []reflect.Value
+		for i := 0; i < v.Len(); i++ {
+			elems = append(elems, elements(v.Index(i))...)
+		}
+		return elems
+	}
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil
+	}
+	return []reflect.Value{v}
+}
+
+// attribute returns the field or the value of the method of v called name.
+func attribute(v reflect.Value, name string) (reflect.Value, bool) {
+	if m := v.MethodByName(name); m.IsValid() && m.Type().NumIn() == 0 && m.Type().NumOut() == 1 {
+		return m.Call(nil)[0], true
+	}
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	if f, ok := v.Type().FieldByName(name); !ok || !f.IsExported() {
+		return reflect.Value{}, false
+	}
+	return v.FieldByName(name), true
+}
+
+// attributeText returns the text a value compares as.
+func attributeText(v reflect.Value) (string, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64), true
+	}
+	return "", false
+}
+
+// attributeType returns the type path reaches from t, or nil when it
+// goes through an interface and cannot be known before matching. The error
+// names the first part of path that t does not have.
+func attributeType(t reflect.Type, path []string) (reflect.Type, error) {
+	for _, name := range path {
+		for t.Kind() == reflect.Slice {
+			t = t.Elem()
+		}
+		if t.Kind() == reflect.Interface {
+			return nil, nil
+		}
+		if m, ok := t.MethodByName(name); ok && m.Type.NumIn() == 1 && m.Type.NumOut() == 1 {
+			t = m.Type.Out(0)
+			continue
+		}
+		st := t
+		if st.Kind() == reflect.Ptr {
+			st = st.Elem()
+		}
+		f, ok := reflect.StructField{}, false
+		if st.Kind() == reflect.Struct {
+			f, ok = st.FieldByName(name)
+		}
+		if !ok || !f.IsExported() {
+			return nil, fmt.Errorf("%s has no attribute %s", st.Name(), name)
+		}
+		t = f.Type
+	}
+	return t, nil
+}
+
+type selectorParser struct {
+	text string
+	pos  int
+}
+
+func (p *selectorParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("selector %q: %s at %d", p.text, fmt.Sprintf(format, args...), p.pos)
+}
+
+func (p *selectorParser) eof() bool {
+	return p.pos >= len(p.text)
+}
+
+func (p *selectorParser) rest() string {
+	return p.text[p.pos:]
+}
+
+func (p *selectorParser) skipSpace() bool {
+	start := p.pos
+	for !p.eof() && unicode.IsSpace(rune(p.text[p.pos])) {
+		p.pos++
+	}
+	return p.pos > start
+}
+
+func (p *selectorParser) accept(s string) bool {
+	if strings.HasPrefix(p.rest(), s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *selectorParser) ident() string {
+	start := p.pos
+	for !p.eof() {
+		c := rune(p.text[p.pos])
+		if !(c == '_' || unicode.IsLetter(c) || p.pos > start && unicode.IsDigit(c)) {
+			break
+		}
+		p.pos++
+	}
+	return p.text[start:p.pos]
+}
+
+func (p *selectorParser) selector() ([]selectorStep, error) {
+	p.skipSpace()
+	step, err := p.step()
+	if err != nil {
+		return nil, err
+	}
+	steps := []selectorStep{step}
+	for {
+		space := p.skipSpace()
+		combinator := byte(' ')
+		switch {
+		case p.accept(">"):
+			combinator = '>'
+			p.skipSpace()
+		case !space || p.eof() || strings.HasPrefix(p.rest(), ","):
+			return steps, nil
+		}
+		step, err := p.step()
+		if err != nil {
+			return nil, err
+		}
+		step.combinator = combinator
+		steps = append(steps, step)
+	}
+}
+
+func (p *selectorParser) step() (selectorStep, error) {
+	var step selectorStep
+	if p.accept("*") {
+		step.typeName = "*"
+	} else {
+		start := p.pos
+		step.typeName = p.ident()
+		if step.typeName == "" {
+			if p.eof() {
+				return step, p.errorf("missing node type")
+			}
+			return step, p.errorf("unexpected %q", p.rest()[:1])
+		}
+		if iface, ok := selectorInterfaces[step.typeName]; ok {
+			step.iface = iface
+		} else if newNode, ok := nodeKinds[step.typeName]; ok {
+			step.node = reflect.TypeOf(newNode()).Elem()
+		} else {
+			p.pos = start
+			return step, p.errorf("unknown node type %s", step.typeName)
+		}
+	}
+	for p.accept("[") {
+		pred, err := p.predicate()
+		if err != nil {
+			return step, err
+		}
+		if step.node != nil {
+			if _, err := attributeType(reflect.PtrTo(step.node), pred.path); err != nil {
+				return step, p.errorf("%v", err)
+			}
+		}
+		step.preds = append(step.preds, pred)
+	}
+	return step, nil
+}
+
+func (p *selectorParser) predicate() (selectorPredicate, error) {
+	var pred selectorPredicate
+	p.skipSpace()
+	if p.accept("!") {
+		pred.op = "!"
+		p.skipSpace()
+	}
+	for {
+		name := p.ident()
+		if name == "" {
+			return pred, p.errorf("missing attribute")
+		}
+		pred.path = append(pred.path, name)
+		if !p.accept(".") {
+			break
+		}
+	}
+	p.skipSpace()
+	if p.accept("]") {
+		return pred, nil
+	}
+	if pred.op == "!" {
+		return pred, p.errorf("missing ]")
+	}
+	for _, op := range []string{"!=", "~=", "^=", "$=", "="} {
+		if p.accept(op) {
+			pred.op = op
+			break
+		}
+	}
+	if pred.op == "" {
+		return pred, p.errorf("missing operator")
+	}
+	p.skipSpace()
+	value, err := p.value()
+	if err != nil {
+		return pred, err
+	}
+	pred.value = value
+	p.skipSpace()
+	if !p.accept("]") {
+		return pred, p.errorf("missing ]")
+	}
+	return pred, nil
+}
+
+func (p *selectorParser) value() (string, error) {
+	if p.eof() {
+		return "", p.errorf("missing value")
+	}
+	quote := p.text[p.pos]
+	if quote != '"' && quote != '\'' {
+		start := p.pos
+		for !p.eof() && !unicode.IsSpace(rune(p.text[p.pos])) && p.text[p.pos] != ']' {
+			p.pos++
+		}
+		if p.pos == start {
+			return "", p.errorf("missing value")
+		}
+		return p.text[start:p.pos], nil
+	}
+	p.pos++
+	var b strings.Builder
+	for !p.eof() {
+		c := p.text[p.pos]
+		p.pos++
+		switch {
+		case c == quote:
+			return b.String(), nil
+		case c == '\\' && !p.eof():
+			b.WriteByte(p.text[p.pos])
+			p.pos++
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
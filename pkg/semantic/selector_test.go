@@ -0,0 +1,134 @@
+package semantic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// selectorPackage returns the tree of
+//
+//	create or replace package body pkg_billing is
+//		procedure charge(id number) is
+//		begin
+//			loop
+//				update accounts set balance = 0 where id = id;
+//				exit;
+//			end loop;
+//			select count(*) into n from accounts@remote;
+//		end;
+//		procedure refund(id number) is
+//		begin
+//			update accounts set balance = 1;
+//			select count(*) into n from accounts;
+//		end;
+//	end;
+//
+// with the lines of its statements.
+func selectorPackage() *Script {
+	at := func(line int) SyntaxNode { return SyntaxNode{SourceLine: line} }
+	query := func(line int, table string) *SelectStatement {
+		return &SelectStatement{SyntaxNode: at(line), From: &FromClause{TableRefs: []*TableRef{{Table: table}}}}
+	}
+	update := func(line int, where Expr) *UpdateStatement {
+		return &UpdateStatement{SyntaxNode: at(line), Table: &NameExpression{Name: "accounts"}, Where: where}
+	}
+	return &Script{Statements: []Statement{
+		&CreatePackageBodyStatement{SyntaxNode: at(1), Name: "pkg_billing", Procedures: []*CreateProcedureStatement{
+			{
+				SyntaxNode: at(2),
+				Name:       "charge",
+				Parameters: []*Parameter{{Name: "id", DataType: "number"}},
+				Body: &Body{SyntaxNode: at(3), Statements: []Statement{
+					&LoopStatement{SyntaxNode: at(4), Statements: []Statement{
+						update(5, &BinaryExpression{Left: &NameExpression{Name: "id"}, Operator: "=", Right: &NameExpression{Name: "id"}}),
+						&ExitStatement{SyntaxNode: at(6)},
+					}},
+					query(8, "accounts@remote"),
+				}},
+			},
+			{
+				SyntaxNode: at(10),
+				Name:       "refund",
+				Parameters: []*Parameter{{Name: "id", DataType: "number"}},
+				Body: &Body{SyntaxNode: at(11), Statements: []Statement{
+					update(12, nil),
+					query(13, "accounts"),
+				}},
+			},
+		}},
+	}}
+}
+
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		selector string
+		lines    []int
+	}{
+		{"CreateProcedureStatement LoopStatement UpdateStatement", []int{5}},
+		{"CreateProcedureStatement UpdateStatement", []int{5, 12}},
+		{"Body > UpdateStatement", []int{12}},
+		{"LoopStatement > *", []int{5, 6}},
+		{"LoopStatement SelectStatement", nil},
+		{`SelectStatement[From.TableRefs.Table ~= "@"]`, []int{8}},
+		{`SelectStatement[From.TableRefs.Table $= 'REMOTE']`, []int{8}},
+		{`SelectStatement[From.TableRefs.Table ^= "accounts"]`, []int{8, 13}},
+		{`SelectStatement[From.TableRefs.Table != "accounts"]`, []int{8}},
+		{`CreateProcedureStatement[Name = REFUND], CreateProcedureStatement[Name='refund'] SelectStatement`, []int{10, 13}},
+		{`CreateProcedureStatement[Parameters.Name = "ID"]`, []int{2, 10}},
+		{"UpdateStatement[Where]", []int{5}},
+		{"UpdateStatement[!Where]", []int{12}},
+		{"Statement[Line = 6]", []int{6}},
+		{"CreateProcedureStatement[IsReplace = false]", []int{2, 10}},
+		{"CreateProcedureStatement[IsReplace != true]", []int{2, 10}},
+		{"CreateProcedureStatement[IsReplace]", nil},
+		{"CreateProcedureStatement[!IsReplace]", []int{2, 10}},
+		{"ExitStatement[Span.Start = 0]", []int{6}},
+		{"TableRef[Alias = '']", []int{0, 0}},
+		{"CreatePackageBodyStatement > CreateProcedureStatement > Body > Statement", []int{4, 8, 12, 13}},
+	}
+	script := selectorPackage()
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			nodes, err := Select(script, tt.selector)
+			require.Nil(t, err)
+			var lines []int
+			for _, n := range nodes {
+				lines = append(lines, n.(Node).Line())
+			}
+			assert.Equal(t, tt.lines, lines)
+		})
+	}
+}
+
+func TestSelectorMatch(t *testing.T) {
+	script := selectorPackage()
+	sel := MustParseSelector("LoopStatement > *")
+	assert.Equal(t, "LoopStatement > *", sel.String())
+	p := NewPath(script)
+	body := script.Statements[0].(*CreatePackageBodyStatement).Procedures[0].Body
+	for _, stmt := range body.Statements {
+		assert.False(t, sel.Match(p, stmt.(AstNode)))
+		if loop, ok := stmt.(*LoopStatement); ok {
+			assert.True(t, sel.Match(p, loop.Statements[0].(AstNode)))
+		}
+	}
+}
+
+func TestParseSelectorErrors(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"UpdateStatment",
+		"UpdateStatement[Tabel]",
+		"UpdateStatement[Where =]",
+		"UpdateStatement[Where",
+		"LoopStatement >",
+		"LoopStatement,",
+		`CreateProcedureStatement[Name = "charge]`,
+	} {
+		_, err := ParseSelector(text)
+		assert.NotNil(t, err, text)
+	}
+	assert.Panics(t, func() { MustParseSelector("LoopStatement >") })
+}
@@ -0,0 +1,22 @@
+package semantic
+
+type (
+	// SqlPlusCommand is a SQL*Plus client command such as SET, PROMPT,
+	// SPOOL, WHENEVER, EXEC or an @/@@ script include.
+	SqlPlusCommand struct {
+		SyntaxNode
+		Name string
+		Args string
+		// Call is the statement run by an EXEC command.
+		Call Statement
+		// Include is the parsed script of a resolved @, @@ or START command.
+		Include *Script
+	}
+)
+
+func (s *SqlPlusCommand) statement() {}
+
+// IsInclude reports whether the command runs another script file.
+func (s *SqlPlusCommand) IsInclude() bool {
+	return s.Name == "@" || s.Name == "@@" || s.Name == "START"
+}
@@ -31,6 +31,49 @@ type (
 	TableRef struct {
 		SyntaxNode
 		Table string
+		Alias string
+		// Source is the row source when it is a function such as
+		// JSON_TABLE or XMLTABLE rather than a table.
+		Source  Expr
+		Pivot   *PivotClause
+		Unpivot *UnpivotClause
+	}
+
+	PivotClause struct {
+		SyntaxNode
+		IsXML      bool
+		Aggregates []*PivotElement
+		For        []Expr
+		In         []*PivotInElement
+		InQuery    Statement
+		InAny      bool
+	}
+
+	PivotElement struct {
+		SyntaxNode
+		Function string
+		Expr     Expr
+		Alias    string
+	}
+
+	PivotInElement struct {
+		SyntaxNode
+		Values []Expr
+		Alias  string
+	}
+
+	UnpivotClause struct {
+		SyntaxNode
+		IncludeNulls bool
+		Columns      []Expr
+		For          []Expr
+		In           []*UnpivotInElement
+	}
+
+	UnpivotInElement struct {
+		SyntaxNode
+		Columns []Expr
+		Values  []Expr
 	}
 
 	FromClause struct {
@@ -56,6 +99,35 @@ type (
 		ForUpdate   *ForUpdateClause
 		SetOperator *SetOperator
 		With        *WithClause
+		Model       *ModelClause
+	}
+
+	ModelClause struct {
+		SyntaxNode
+		CellReferenceOptions []string
+		ReturnRows           string
+		ReferenceModels      []*ModelDefinition
+		MainModel            *ModelDefinition
+	}
+
+	ModelDefinition struct {
+		SyntaxNode
+		Name                 string
+		Query                Statement
+		PartitionBy          []Expr
+		DimensionBy          []Expr
+		Measures             []Expr
+		CellReferenceOptions []string
+		RulesOption          string
+		Rules                []*ModelRule
+	}
+
+	ModelRule struct {
+		SyntaxNode
+		Option  string
+		Cell    Expr
+		OrderBy Expr
+		Expr    Expr
 	}
 
 	WithClause struct {
@@ -77,6 +149,7 @@ type (
 	CreateNestTableStatement struct {
 		SyntaxNode
 		CreateTypeStatement
+		ElementType string
 	}
 
 	CreateSynonymStatement struct {
@@ -105,6 +178,51 @@ type (
 
 	RollbackStatement struct {
 		SyntaxNode
+		// Savepoint is the target of ROLLBACK TO SAVEPOINT, empty when the
+		// whole transaction is rolled back.
+		Savepoint string
+	}
+
+	SavepointStatement struct {
+		SyntaxNode
+		Name string
+	}
+
+	SetTransactionStatement struct {
+		SyntaxNode
+		ReadOnly  bool
+		ReadWrite bool
+		// IsolationLevel is SERIALIZABLE or READ COMMITTED.
+		IsolationLevel  string
+		RollbackSegment string
+		// Name is the NAME of the transaction, as a string literal.
+		Name string
+	}
+
+	LockTableStatement struct {
+		SyntaxNode
+		Tables []*TableRef
+		// Mode is the lock mode in upper case, such as ROW EXCLUSIVE.
+		Mode   string
+		NoWait bool
+		Wait   Expr
+	}
+
+	// AlterSessionStatement is ALTER SESSION SET with its Parameters, or
+	// one of the other forms of ALTER SESSION, given by Clause as written,
+	// such as ENABLE PARALLEL DML.
+	AlterSessionStatement struct {
+		SyntaxNode
+		Parameters []*SessionParameter
+		Clause     string
+	}
+
+	// SessionParameter is a name = value pair of ALTER SESSION SET. Both
+	// are kept as written.
+	SessionParameter struct {
+		SyntaxNode
+		Name  string
+		Value string
 	}
 
 	ContinueStatement struct {
@@ -127,8 +245,25 @@ type (
 
 	InsertStatement struct {
 		SyntaxNode
+		// AllInto holds the targets of a single table insert and of an
+		// unconditional INSERT ALL.
 		AllInto []*InsertIntoClause
-		Select  *SelectStatement
+		// IsFirst is set for INSERT FIRST, which inserts into the targets
+		// of the first condition that holds only. A conditional insert
+		// without it is an INSERT ALL.
+		IsFirst    bool
+		Conditions []*ConditionalInsertClause
+		// Else holds the targets of the ELSE part of a conditional insert.
+		Else   []*InsertIntoClause
+		Select *SelectStatement
+	}
+
+	// ConditionalInsertClause is a WHEN condition THEN INTO ... part of a
+	// multi-table insert.
+	ConditionalInsertClause struct {
+		SyntaxNode
+		Condition Expr
+		Into      []*InsertIntoClause
 	}
 
 	InsertIntoClause struct {
@@ -156,6 +291,18 @@ type (
 
 	MergeInsertStatement struct {
 		SyntaxNode
+		Columns []Expr
+		Values  []Expr
+		Where   Expr
+	}
+
+	// ErrorStatement stands for a unit statement that failed to parse or
+	// that could not be turned into a node. Text is its source as far as the
+	// parser recovered it.
+	ErrorStatement struct {
+		SyntaxNode
+		Text       string
+		Diagnostic Diagnostic
 	}
 )
 
@@ -165,6 +312,8 @@ func (s *SelectStatement) Type() NodeType {
 
 func (s *SelectStatement) statement() {}
 
+func (s *ErrorStatement) statement() {}
+
 func (s *SetOperationStatement) statement() {}
 
 func (s *CreateTypeStatement) statement() {}
@@ -179,6 +328,14 @@ func (s *CommitStatement) statement() {}
 
 func (s *RollbackStatement) statement() {}
 
+func (s *SavepointStatement) statement() {}
+
+func (s *SetTransactionStatement) statement() {}
+
+func (s *LockTableStatement) statement() {}
+
+func (s *AlterSessionStatement) statement() {}
+
 func (s *ContinueStatement) statement() {}
 
 func (s *DeleteStatement) statement() {}
@@ -192,3 +349,13 @@ func (s *MergeStatement) statement() {}
 func (s *MergeUpdateStatement) statement() {}
 
 func (s *MergeInsertStatement) statement() {}
+
+// Targets returns all the INTO clauses of s, those of its conditions and
+// of its ELSE part included.
+func (s *InsertStatement) Targets() []*InsertIntoClause {
+	targets := append([]*InsertIntoClause(nil), s.AllInto...)
+	for _, c := range s.Conditions {
+		targets = append(targets, c.Into...)
+	}
+	return append(targets, s.Else...)
+}
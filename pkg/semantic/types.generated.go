@@ -15,6 +15,7 @@ type ExprVisitor interface {
 	VisitBindNameExpression(v *BindNameExpression) (result interface{}, err error)
 	VisitCastExpression(v *CastExpression) (result interface{}, err error)
 	VisitCommonTableExpression(v *CommonTableExpression) (result interface{}, err error)
+	VisitCorrelationReference(v *CorrelationReference) (result interface{}, err error)
 	VisitCursorAttribute(v *CursorAttribute) (result interface{}, err error)
 	VisitDotExpression(v *DotExpression) (result interface{}, err error)
 	VisitExistsExpression(v *ExistsExpression) (result interface{}, err error)
@@ -22,8 +23,16 @@ type ExprVisitor interface {
 	VisitForUpdateOptionsExpression(v *ForUpdateOptionsExpression) (result interface{}, err error)
 	VisitFunctionCallExpression(v *FunctionCallExpression) (result interface{}, err error)
 	VisitInExpression(v *InExpression) (result interface{}, err error)
+	VisitJsonArrayExpression(v *JsonArrayExpression) (result interface{}, err error)
+	VisitJsonObjectEntry(v *JsonObjectEntry) (result interface{}, err error)
+	VisitJsonObjectExpression(v *JsonObjectExpression) (result interface{}, err error)
+	VisitJsonOnClause(v *JsonOnClause) (result interface{}, err error)
+	VisitJsonTableColumn(v *JsonTableColumn) (result interface{}, err error)
+	VisitJsonTableExpression(v *JsonTableExpression) (result interface{}, err error)
+	VisitJsonValueExpression(v *JsonValueExpression) (result interface{}, err error)
 	VisitLikeExpression(v *LikeExpression) (result interface{}, err error)
 	VisitListaggExpression(v *ListaggExpression) (result interface{}, err error)
+	VisitModelCellExpression(v *ModelCellExpression) (result interface{}, err error)
 	VisitNameExpression(v *NameExpression) (result interface{}, err error)
 	VisitNamedArgumentExpression(v *NamedArgumentExpression) (result interface{}, err error)
 	VisitNullExpression(v *NullExpression) (result interface{}, err error)
@@ -31,14 +40,21 @@ type ExprVisitor interface {
 	VisitOrderByClause(v *OrderByClause) (result interface{}, err error)
 	VisitOrderByElement(v *OrderByElement) (result interface{}, err error)
 	VisitOuterJoinExpression(v *OuterJoinExpression) (result interface{}, err error)
+	VisitPseudoColumn(v *PseudoColumn) (result interface{}, err error)
 	VisitQueryExpression(v *QueryExpression) (result interface{}, err error)
 	VisitRelationalExpression(v *RelationalExpression) (result interface{}, err error)
+	VisitSequenceReference(v *SequenceReference) (result interface{}, err error)
 	VisitSignExpression(v *SignExpression) (result interface{}, err error)
+	VisitSqlCursorAttribute(v *SqlCursorAttribute) (result interface{}, err error)
 	VisitStatementExpression(v *StatementExpression) (result interface{}, err error)
 	VisitStringLiteral(v *StringLiteral) (result interface{}, err error)
 	VisitUnaryLogicalExpression(v *UnaryLogicalExpression) (result interface{}, err error)
 	VisitUsingClause(v *UsingClause) (result interface{}, err error)
 	VisitUsingElement(v *UsingElement) (result interface{}, err error)
+	VisitXmlAggExpression(v *XmlAggExpression) (result interface{}, err error)
+	VisitXmlElementExpression(v *XmlElementExpression) (result interface{}, err error)
+	VisitXmlTableColumn(v *XmlTableColumn) (result interface{}, err error)
+	VisitXmlTableExpression(v *XmlTableExpression) (result interface{}, err error)
 }
 
 type StubExprVisitor struct{ ExprVisitor }
@@ -69,6 +85,10 @@ func (s StubExprVisitor) VisitCommonTableExpression(_ *CommonTableExpression) (i
 	return nil, errors.New("visit func for CommonTableExpression is not implemented")
 }
 
+func (s StubExprVisitor) VisitCorrelationReference(_ *CorrelationReference) (interface{}, error) {
+	return nil, errors.New("visit func for CorrelationReference is not implemented")
+}
+
 func (s StubExprVisitor) VisitCursorAttribute(_ *CursorAttribute) (interface{}, error) {
 	return nil, errors.New("visit func for CursorAttribute is not implemented")
 }
@@ -97,6 +117,34 @@ func (s StubExprVisitor) VisitInExpression(_ *InExpression) (interface{}, error)
 	return nil, errors.New("visit func for InExpression is not implemented")
 }
 
+func (s StubExprVisitor) VisitJsonArrayExpression(_ *JsonArrayExpression) (interface{}, error) {
+	return nil, errors.New("visit func for JsonArrayExpression is not implemented")
+}
+
+func (s StubExprVisitor) VisitJsonObjectEntry(_ *JsonObjectEntry) (interface{}, error) {
+	return nil, errors.New("visit func for JsonObjectEntry is not implemented")
+}
+
+func (s StubExprVisitor) VisitJsonObjectExpression(_ *JsonObjectExpression) (interface{}, error) {
+	return nil, errors.New("visit func for JsonObjectExpression is not implemented")
+}
+
+func (s StubExprVisitor) VisitJsonOnClause(_ *JsonOnClause) (interface{}, error) {
+	return nil, errors.New("visit func for JsonOnClause is not implemented")
+}
+
+func (s StubExprVisitor) VisitJsonTableColumn(_ *JsonTableColumn) (interface{}, error) {
+	return nil, errors.New("visit func for JsonTableColumn is not implemented")
+}
+
+func (s StubExprVisitor) VisitJsonTableExpression(_ *JsonTableExpression) (interface{}, error) {
+	return nil, errors.New("visit func for JsonTableExpression is not implemented")
+}
+
+func (s StubExprVisitor) VisitJsonValueExpression(_ *JsonValueExpression) (interface{}, error) {
+	return nil, errors.New("visit func for JsonValueExpression is not implemented")
+}
+
 func (s StubExprVisitor) VisitLikeExpression(_ *LikeExpression) (interface{}, error) {
 	return nil, errors.New("visit func for LikeExpression is not implemented")
 }
@@ -105,6 +153,10 @@ func (s StubExprVisitor) VisitListaggExpression(_ *ListaggExpression) (interface
 	return nil, errors.New("visit func for ListaggExpression is not implemented")
 }
 
+func (s StubExprVisitor) VisitModelCellExpression(_ *ModelCellExpression) (interface{}, error) {
+	return nil, errors.New("visit func for ModelCellExpression is not implemented")
+}
+
 func (s StubExprVisitor) VisitNameExpression(_ *NameExpression) (interface{}, error) {
 	return nil, errors.New("visit func for NameExpression is not implemented")
 }
@@ -133,6 +185,10 @@ func (s StubExprVisitor) VisitOuterJoinExpression(_ *OuterJoinExpression) (inter
 	return nil, errors.New("visit func for OuterJoinExpression is not implemented")
 }
 
+func (s StubExprVisitor) VisitPseudoColumn(_ *PseudoColumn) (interface{}, error) {
+	return nil, errors.New("visit func for PseudoColumn is not implemented")
+}
+
 func (s StubExprVisitor) VisitQueryExpression(_ *QueryExpression) (interface{}, error) {
 	return nil, errors.New("visit func for QueryExpression is not implemented")
 }
@@ -141,10 +197,18 @@ func (s StubExprVisitor) VisitRelationalExpression(_ *RelationalExpression) (int
 	return nil, errors.New("visit func for RelationalExpression is not implemented")
 }
 
+func (s StubExprVisitor) VisitSequenceReference(_ *SequenceReference) (interface{}, error) {
+	return nil, errors.New("visit func for SequenceReference is not implemented")
+}
+
 func (s StubExprVisitor) VisitSignExpression(_ *SignExpression) (interface{}, error) {
 	return nil, errors.New("visit func for SignExpression is not implemented")
 }
 
+func (s StubExprVisitor) VisitSqlCursorAttribute(_ *SqlCursorAttribute) (interface{}, error) {
+	return nil, errors.New("visit func for SqlCursorAttribute is not implemented")
+}
+
 func (s StubExprVisitor) VisitStatementExpression(_ *StatementExpression) (interface{}, error) {
 	return nil, errors.New("visit func for StatementExpression is not implemented")
 }
@@ -165,6 +229,22 @@ func (s StubExprVisitor) VisitUsingElement(_ *UsingElement) (interface{}, error)
 	return nil, errors.New("visit func for UsingElement is not implemented")
 }
 
+func (s StubExprVisitor) VisitXmlAggExpression(_ *XmlAggExpression) (interface{}, error) {
+	return nil, errors.New("visit func for XmlAggExpression is not implemented")
+}
+
+func (s StubExprVisitor) VisitXmlElementExpression(_ *XmlElementExpression) (interface{}, error) {
+	return nil, errors.New("visit func for XmlElementExpression is not implemented")
+}
+
+func (s StubExprVisitor) VisitXmlTableColumn(_ *XmlTableColumn) (interface{}, error) {
+	return nil, errors.New("visit func for XmlTableColumn is not implemented")
+}
+
+func (s StubExprVisitor) VisitXmlTableExpression(_ *XmlTableExpression) (interface{}, error) {
+	return nil, errors.New("visit func for XmlTableExpression is not implemented")
+}
+
 func (b *AliasExpression) ExprAccept(visitor ExprVisitor) (result interface{}, err error) {
 	return visitor.VisitAliasExpression(b)
 }
@@ -189,6 +269,10 @@ func (b *CommonTableExpression) ExprAccept(visitor ExprVisitor) (result interfac
 	return visitor.VisitCommonTableExpression(b)
 }
 
+func (b *CorrelationReference) ExprAccept(visitor ExprVisitor) (result interface{}, err error) {
+	return visitor.VisitCorrelationReference(b)
+}
+
 func (b *CursorAttribute) ExprAccept(visitor ExprVisitor) (result interface{}, err error) {
 	return visitor.VisitCursorAttribute(b)
 }
@@ -217,6 +301,34 @@ func (b *InExpression) ExprAccept(visitor ExprVisitor) (result interface{}, err
 	return visitor.VisitInExpression(b)
 }
 
+func (b *JsonArrayExpression) ExprAccept(visitor ExprVisitor) (result interface{}, err error) {
+	return visitor.VisitJsonArrayExpression(b)
+}
+
+func (b *JsonObjectEntry) ExprAccept(visitor ExprVisitor) (result interface{}, err error) {
+	return visitor.VisitJsonObjectEntry(b)
+}
+
+func (b *JsonObjectExpression) ExprAccept(visitor ExprVisitor) (result interface{}, err error) {
+	return visitor.VisitJsonObjectExpression(b)
+}
+
+func (b *JsonOnClause) ExprAccept(visitor ExprVisitor) (result interface{}, err error) {
+	return visitor.VisitJsonOnClause(b)
+}
+
+func (b *JsonTableColumn) ExprAccept(visitor ExprVisitor) (result interface{}, err error) {
+	return visitor.VisitJsonTableColumn(b)
+}
+
+func (b *JsonTableExpression) ExprAccept(visitor ExprVisitor) (result interface{}, err error) {
+	return visitor.VisitJsonTableExpression(b)
+}
+
+func (b *JsonValueExpression) ExprAccept(visitor ExprVisitor) (result interface{}, err error) {
+	return visitor.VisitJsonValueExpression(b)
+}
+
 func (b *LikeExpression) ExprAccept(visitor ExprVisitor) (result interface{}, err error) {
 	return visitor.VisitLikeExpression(b)
 }
@@ -225,6 +337,10 @@ func (b *ListaggExpression) ExprAccept(visitor ExprVisitor) (result interface{},
 	return visitor.VisitListaggExpression(b)
 }
 
+func (b *ModelCellExpression) ExprAccept(visitor ExprVisitor) (result interface{}, err error) {
+	return visitor.VisitModelCellExpression(b)
+}
+
 func (b *NameExpression) ExprAccept(visitor ExprVisitor) (result interface{}, err error) {
 	return visitor.VisitNameExpression(b)
 }
@@ -253,6 +369,10 @@ func (b *OuterJoinExpression) ExprAccept(visitor ExprVisitor) (result interface{
 	return visitor.VisitOuterJoinExpression(b)
 }
 
+func (b *PseudoColumn) ExprAccept(visitor ExprVisitor) (result interface{}, err error) {
+	return visitor.VisitPseudoColumn(b)
+}
+
 func (b *QueryExpression) ExprAccept(visitor ExprVisitor) (result interface{}, err error) {
 	return visitor.VisitQueryExpression(b)
 }
@@ -261,10 +381,18 @@ func (b *RelationalExpression) ExprAccept(visitor ExprVisitor) (result interface
 	return visitor.VisitRelationalExpression(b)
 }
 
+func (b *SequenceReference) ExprAccept(visitor ExprVisitor) (result interface{}, err error) {
+	return visitor.VisitSequenceReference(b)
+}
+
 func (b *SignExpression) ExprAccept(visitor ExprVisitor) (result interface{}, err error) {
 	return visitor.VisitSignExpression(b)
 }
 
+func (b *SqlCursorAttribute) ExprAccept(visitor ExprVisitor) (result interface{}, err error) {
+	return visitor.VisitSqlCursorAttribute(b)
+}
+
 func (b *StatementExpression) ExprAccept(visitor ExprVisitor) (result interface{}, err error) {
 	return visitor.VisitStatementExpression(b)
 }
@@ -285,7 +413,24 @@ func (b *UsingElement) ExprAccept(visitor ExprVisitor) (result interface{}, err
 	return visitor.VisitUsingElement(b)
 }
 
+func (b *XmlAggExpression) ExprAccept(visitor ExprVisitor) (result interface{}, err error) {
+	return visitor.VisitXmlAggExpression(b)
+}
+
+func (b *XmlElementExpression) ExprAccept(visitor ExprVisitor) (result interface{}, err error) {
+	return visitor.VisitXmlElementExpression(b)
+}
+
+func (b *XmlTableColumn) ExprAccept(visitor ExprVisitor) (result interface{}, err error) {
+	return visitor.VisitXmlTableColumn(b)
+}
+
+func (b *XmlTableExpression) ExprAccept(visitor ExprVisitor) (result interface{}, err error) {
+	return visitor.VisitXmlTableExpression(b)
+}
+
 type StmtVisitor interface {
+	VisitAlterSessionStatement(v *AlterSessionStatement) (err error)
 	VisitAssignmentStatement(v *AssignmentStatement) (err error)
 	VisitBlockStatement(v *BlockStatement) (err error)
 	VisitBody(v *Body) (err error)
@@ -297,6 +442,7 @@ type StmtVisitor interface {
 	VisitCreateCompoundDmlTriggerStatement(v *CreateCompoundDmlTriggerStatement) (err error)
 	VisitCreateFunctionStatement(v *CreateFunctionStatement) (err error)
 	VisitCreateNestTableStatement(v *CreateNestTableStatement) (err error)
+	VisitCreateNonDmlTriggerStatement(v *CreateNonDmlTriggerStatement) (err error)
 	VisitCreatePackageBodyStatement(v *CreatePackageBodyStatement) (err error)
 	VisitCreatePackageStatement(v *CreatePackageStatement) (err error)
 	VisitCreateProcedureStatement(v *CreateProcedureStatement) (err error)
@@ -309,6 +455,7 @@ type StmtVisitor interface {
 	VisitDropPackageStatement(v *DropPackageStatement) (err error)
 	VisitDropProcedureStatement(v *DropProcedureStatement) (err error)
 	VisitDropTriggerStatement(v *DropTriggerStatement) (err error)
+	VisitErrorStatement(v *ErrorStatement) (err error)
 	VisitExecuteImmediateStatement(v *ExecuteImmediateStatement) (err error)
 	VisitExitStatement(v *ExitStatement) (err error)
 	VisitFetchStatement(v *FetchStatement) (err error)
@@ -316,6 +463,7 @@ type StmtVisitor interface {
 	VisitIfStatement(v *IfStatement) (err error)
 	VisitInsertStatement(v *InsertStatement) (err error)
 	VisitLabelDeclaration(v *LabelDeclaration) (err error)
+	VisitLockTableStatement(v *LockTableStatement) (err error)
 	VisitLoopStatement(v *LoopStatement) (err error)
 	VisitMergeInsertStatement(v *MergeInsertStatement) (err error)
 	VisitMergeStatement(v *MergeStatement) (err error)
@@ -327,8 +475,11 @@ type StmtVisitor interface {
 	VisitRaiseStatement(v *RaiseStatement) (err error)
 	VisitReturnStatement(v *ReturnStatement) (err error)
 	VisitRollbackStatement(v *RollbackStatement) (err error)
+	VisitSavepointStatement(v *SavepointStatement) (err error)
 	VisitSelectStatement(v *SelectStatement) (err error)
 	VisitSetOperationStatement(v *SetOperationStatement) (err error)
+	VisitSetTransactionStatement(v *SetTransactionStatement) (err error)
+	VisitSqlPlusCommand(v *SqlPlusCommand) (err error)
 	VisitTimingPoint(v *TimingPoint) (err error)
 	VisitTriggerBlock(v *TriggerBlock) (err error)
 	VisitUpdateStatement(v *UpdateStatement) (err error)
@@ -344,6 +495,10 @@ type StubStmtVisitor struct{ StmtVisitor }
 
 var _ StmtVisitor = &StubStmtVisitor{}
 
+func (s StubStmtVisitor) VisitAlterSessionStatement(_ *AlterSessionStatement) error {
+	return errors.New("visit func for AlterSessionStatement is not implemented")
+}
+
 func (s StubStmtVisitor) VisitAssignmentStatement(_ *AssignmentStatement) error {
 	return errors.New("visit func for AssignmentStatement is not implemented")
 }
@@ -388,6 +543,10 @@ func (s StubStmtVisitor) VisitCreateNestTableStatement(_ *CreateNestTableStateme
 	return errors.New("visit func for CreateNestTableStatement is not implemented")
 }
 
+func (s StubStmtVisitor) VisitCreateNonDmlTriggerStatement(_ *CreateNonDmlTriggerStatement) error {
+	return errors.New("visit func for CreateNonDmlTriggerStatement is not implemented")
+}
+
 func (s StubStmtVisitor) VisitCreatePackageBodyStatement(_ *CreatePackageBodyStatement) error {
 	return errors.New("visit func for CreatePackageBodyStatement is not implemented")
 }
@@ -436,6 +595,10 @@ func (s StubStmtVisitor) VisitDropTriggerStatement(_ *DropTriggerStatement) erro
 	return errors.New("visit func for DropTriggerStatement is not implemented")
 }
 
+func (s StubStmtVisitor) VisitErrorStatement(_ *ErrorStatement) error {
+	return errors.New("visit func for ErrorStatement is not implemented")
+}
+
 func (s StubStmtVisitor) VisitExecuteImmediateStatement(_ *ExecuteImmediateStatement) error {
 	return errors.New("visit func for ExecuteImmediateStatement is not implemented")
 }
@@ -464,6 +627,10 @@ func (s StubStmtVisitor) VisitLabelDeclaration(_ *LabelDeclaration) error {
 	return errors.New("visit func for LabelDeclaration is not implemented")
 }
 
+func (s StubStmtVisitor) VisitLockTableStatement(_ *LockTableStatement) error {
+	return errors.New("visit func for LockTableStatement is not implemented")
+}
+
 func (s StubStmtVisitor) VisitLoopStatement(_ *LoopStatement) error {
 	return errors.New("visit func for LoopStatement is not implemented")
 }
@@ -508,6 +675,10 @@ func (s StubStmtVisitor) VisitRollbackStatement(_ *RollbackStatement) error {
 	return errors.New("visit func for RollbackStatement is not implemented")
 }
 
+func (s StubStmtVisitor) VisitSavepointStatement(_ *SavepointStatement) error {
+	return errors.New("visit func for SavepointStatement is not implemented")
+}
+
 func (s StubStmtVisitor) VisitSelectStatement(_ *SelectStatement) error {
 	return errors.New("visit func for SelectStatement is not implemented")
 }
@@ -516,6 +687,14 @@ func (s StubStmtVisitor) VisitSetOperationStatement(_ *SetOperationStatement) er
 	return errors.New("visit func for SetOperationStatement is not implemented")
 }
 
+func (s StubStmtVisitor) VisitSetTransactionStatement(_ *SetTransactionStatement) error {
+	return errors.New("visit func for SetTransactionStatement is not implemented")
+}
+
+func (s StubStmtVisitor) VisitSqlPlusCommand(_ *SqlPlusCommand) error {
+	return errors.New("visit func for SqlPlusCommand is not implemented")
+}
+
 func (s StubStmtVisitor) VisitTimingPoint(_ *TimingPoint) error {
 	return errors.New("visit func for TimingPoint is not implemented")
 }
@@ -552,6 +731,10 @@ func (s StubStmtVisitor) VisitVariableDeclaration(_ *VariableDeclaration) error
 	return errors.New("visit func for VariableDeclaration is not implemented")
 }
 
+func (b *AlterSessionStatement) StmtAccept(visitor StmtVisitor) (err error) {
+	return visitor.VisitAlterSessionStatement(b)
+}
+
 func (b *AssignmentStatement) StmtAccept(visitor StmtVisitor) (err error) {
 	return visitor.VisitAssignmentStatement(b)
 }
@@ -596,6 +779,10 @@ func (b *CreateNestTableStatement) StmtAccept(visitor StmtVisitor) (err error) {
 	return visitor.VisitCreateNestTableStatement(b)
 }
 
+func (b *CreateNonDmlTriggerStatement) StmtAccept(visitor StmtVisitor) (err error) {
+	return visitor.VisitCreateNonDmlTriggerStatement(b)
+}
+
 func (b *CreatePackageBodyStatement) StmtAccept(visitor StmtVisitor) (err error) {
 	return visitor.VisitCreatePackageBodyStatement(b)
 }
@@ -644,6 +831,10 @@ func (b *DropTriggerStatement) StmtAccept(visitor StmtVisitor) (err error) {
 	return visitor.VisitDropTriggerStatement(b)
 }
 
+func (b *ErrorStatement) StmtAccept(visitor StmtVisitor) (err error) {
+	return visitor.VisitErrorStatement(b)
+}
+
 func (b *ExecuteImmediateStatement) StmtAccept(visitor StmtVisitor) (err error) {
 	return visitor.VisitExecuteImmediateStatement(b)
 }
@@ -672,6 +863,10 @@ func (b *LabelDeclaration) StmtAccept(visitor StmtVisitor) (err error) {
 	return visitor.VisitLabelDeclaration(b)
 }
 
+func (b *LockTableStatement) StmtAccept(visitor StmtVisitor) (err error) {
+	return visitor.VisitLockTableStatement(b)
+}
+
 func (b *LoopStatement) StmtAccept(visitor StmtVisitor) (err error) {
 	return visitor.VisitLoopStatement(b)
 }
@@ -716,6 +911,10 @@ func (b *RollbackStatement) StmtAccept(visitor StmtVisitor) (err error) {
 	return visitor.VisitRollbackStatement(b)
 }
 
+func (b *SavepointStatement) StmtAccept(visitor StmtVisitor) (err error) {
+	return visitor.VisitSavepointStatement(b)
+}
+
 func (b *SelectStatement) StmtAccept(visitor StmtVisitor) (err error) {
 	return visitor.VisitSelectStatement(b)
 }
@@ -724,6 +923,14 @@ func (b *SetOperationStatement) StmtAccept(visitor StmtVisitor) (err error) {
 	return visitor.VisitSetOperationStatement(b)
 }
 
+func (b *SetTransactionStatement) StmtAccept(visitor StmtVisitor) (err error) {
+	return visitor.VisitSetTransactionStatement(b)
+}
+
+func (b *SqlPlusCommand) StmtAccept(visitor StmtVisitor) (err error) {
+	return visitor.VisitSqlPlusCommand(b)
+}
+
 func (b *TimingPoint) StmtAccept(visitor StmtVisitor) (err error) {
 	return visitor.VisitTimingPoint(b)
 }
@@ -763,6 +970,7 @@ func (b *VariableDeclaration) StmtAccept(visitor StmtVisitor) (err error) {
 type NodeVisitor interface {
 	VisitChildren(n AstNode) (err error)
 	VisitAliasExpression(v *AliasExpression) (err error)
+	VisitAlterSessionStatement(v *AlterSessionStatement) (err error)
 	VisitArgument(v *Argument) (err error)
 	VisitAssignmentStatement(v *AssignmentStatement) (err error)
 	VisitAutonomousTransactionDeclaration(v *AutonomousTransactionDeclaration) (err error)
@@ -778,10 +986,15 @@ type NodeVisitor interface {
 	VisitCommitStatement(v *CommitStatement) (err error)
 	VisitCommonTableExpression(v *CommonTableExpression) (err error)
 	VisitCompoundTriggerBlock(v *CompoundTriggerBlock) (err error)
+	VisitConditionalBlock(v *ConditionalBlock) (err error)
+	VisitConditionalBranch(v *ConditionalBranch) (err error)
+	VisitConditionalInsertClause(v *ConditionalInsertClause) (err error)
 	VisitContinueStatement(v *ContinueStatement) (err error)
+	VisitCorrelationReference(v *CorrelationReference) (err error)
 	VisitCreateCompoundDmlTriggerStatement(v *CreateCompoundDmlTriggerStatement) (err error)
 	VisitCreateFunctionStatement(v *CreateFunctionStatement) (err error)
 	VisitCreateNestTableStatement(v *CreateNestTableStatement) (err error)
+	VisitCreateNonDmlTriggerStatement(v *CreateNonDmlTriggerStatement) (err error)
 	VisitCreatePackageBodyStatement(v *CreatePackageBodyStatement) (err error)
 	VisitCreatePackageStatement(v *CreatePackageStatement) (err error)
 	VisitCreateProcedureStatement(v *CreateProcedureStatement) (err error)
@@ -798,6 +1011,7 @@ type NodeVisitor interface {
 	VisitDropProcedureStatement(v *DropProcedureStatement) (err error)
 	VisitDropTriggerStatement(v *DropTriggerStatement) (err error)
 	VisitElseBlock(v *ElseBlock) (err error)
+	VisitErrorStatement(v *ErrorStatement) (err error)
 	VisitExceptionDeclaration(v *ExceptionDeclaration) (err error)
 	VisitExecuteImmediateStatement(v *ExecuteImmediateStatement) (err error)
 	VisitExistsExpression(v *ExistsExpression) (err error)
@@ -816,13 +1030,25 @@ type NodeVisitor interface {
 	VisitInsertIntoClause(v *InsertIntoClause) (err error)
 	VisitInsertStatement(v *InsertStatement) (err error)
 	VisitIntoClause(v *IntoClause) (err error)
+	VisitJsonArrayExpression(v *JsonArrayExpression) (err error)
+	VisitJsonObjectEntry(v *JsonObjectEntry) (err error)
+	VisitJsonObjectExpression(v *JsonObjectExpression) (err error)
+	VisitJsonOnClause(v *JsonOnClause) (err error)
+	VisitJsonTableColumn(v *JsonTableColumn) (err error)
+	VisitJsonTableExpression(v *JsonTableExpression) (err error)
+	VisitJsonValueExpression(v *JsonValueExpression) (err error)
 	VisitLabelDeclaration(v *LabelDeclaration) (err error)
 	VisitLikeExpression(v *LikeExpression) (err error)
 	VisitListaggExpression(v *ListaggExpression) (err error)
+	VisitLockTableStatement(v *LockTableStatement) (err error)
 	VisitLoopStatement(v *LoopStatement) (err error)
 	VisitMergeInsertStatement(v *MergeInsertStatement) (err error)
 	VisitMergeStatement(v *MergeStatement) (err error)
 	VisitMergeUpdateStatement(v *MergeUpdateStatement) (err error)
+	VisitModelCellExpression(v *ModelCellExpression) (err error)
+	VisitModelClause(v *ModelClause) (err error)
+	VisitModelDefinition(v *ModelDefinition) (err error)
+	VisitModelRule(v *ModelRule) (err error)
 	VisitNameExpression(v *NameExpression) (err error)
 	VisitNamedArgumentExpression(v *NamedArgumentExpression) (err error)
 	VisitNestTableTypeDeclaration(v *NestTableTypeDeclaration) (err error)
@@ -835,29 +1061,46 @@ type NodeVisitor interface {
 	VisitOrderByElement(v *OrderByElement) (err error)
 	VisitOuterJoinExpression(v *OuterJoinExpression) (err error)
 	VisitParameter(v *Parameter) (err error)
+	VisitPivotClause(v *PivotClause) (err error)
+	VisitPivotElement(v *PivotElement) (err error)
+	VisitPivotInElement(v *PivotInElement) (err error)
 	VisitProcedureCall(v *ProcedureCall) (err error)
+	VisitPseudoColumn(v *PseudoColumn) (err error)
 	VisitQueryExpression(v *QueryExpression) (err error)
 	VisitRaiseStatement(v *RaiseStatement) (err error)
 	VisitRelationalExpression(v *RelationalExpression) (err error)
 	VisitReturnStatement(v *ReturnStatement) (err error)
 	VisitRollbackStatement(v *RollbackStatement) (err error)
+	VisitSavepointStatement(v *SavepointStatement) (err error)
 	VisitScript(v *Script) (err error)
 	VisitSelectField(v *SelectField) (err error)
 	VisitSelectStatement(v *SelectStatement) (err error)
+	VisitSequenceReference(v *SequenceReference) (err error)
+	VisitSessionParameter(v *SessionParameter) (err error)
 	VisitSetOperationStatement(v *SetOperationStatement) (err error)
+	VisitSetTransactionStatement(v *SetTransactionStatement) (err error)
 	VisitSignExpression(v *SignExpression) (err error)
+	VisitSqlCursorAttribute(v *SqlCursorAttribute) (err error)
+	VisitSqlPlusCommand(v *SqlPlusCommand) (err error)
 	VisitStatementExpression(v *StatementExpression) (err error)
 	VisitStringLiteral(v *StringLiteral) (err error)
 	VisitTableRef(v *TableRef) (err error)
 	VisitTimingPoint(v *TimingPoint) (err error)
 	VisitTriggerBlock(v *TriggerBlock) (err error)
+	VisitTriggerReferencing(v *TriggerReferencing) (err error)
 	VisitUnaryLogicalExpression(v *UnaryLogicalExpression) (err error)
+	VisitUnpivotClause(v *UnpivotClause) (err error)
+	VisitUnpivotInElement(v *UnpivotInElement) (err error)
 	VisitUpdateStatement(v *UpdateStatement) (err error)
 	VisitUsingClause(v *UsingClause) (err error)
 	VisitUsingElement(v *UsingElement) (err error)
 	VisitVariableDeclaration(v *VariableDeclaration) (err error)
 	VisitWildCardField(v *WildCardField) (err error)
 	VisitWithClause(v *WithClause) (err error)
+	VisitXmlAggExpression(v *XmlAggExpression) (err error)
+	VisitXmlElementExpression(v *XmlElementExpression) (err error)
+	VisitXmlTableColumn(v *XmlTableColumn) (err error)
+	VisitXmlTableExpression(v *XmlTableExpression) (err error)
 }
 
 type StubNodeVisitor struct{ NodeVisitor }
@@ -868,6 +1111,10 @@ func (s *StubNodeVisitor) VisitAliasExpression(n *AliasExpression) error {
 	return s.VisitChildren(n) // AliasExpression
 }
 
+func (s *StubNodeVisitor) VisitAlterSessionStatement(n *AlterSessionStatement) error {
+	return s.VisitChildren(n) // AlterSessionStatement
+}
+
 func (s *StubNodeVisitor) VisitArgument(n *Argument) error {
 	return s.VisitChildren(n) // Argument
 }
@@ -928,10 +1175,26 @@ func (s *StubNodeVisitor) VisitCompoundTriggerBlock(n *CompoundTriggerBlock) err
 	return s.VisitChildren(n) // CompoundTriggerBlock
 }
 
+func (s *StubNodeVisitor) VisitConditionalBlock(n *ConditionalBlock) error {
+	return s.VisitChildren(n) // ConditionalBlock
+}
+
+func (s *StubNodeVisitor) VisitConditionalBranch(n *ConditionalBranch) error {
+	return s.VisitChildren(n) // ConditionalBranch
+}
+
+func (s *StubNodeVisitor) VisitConditionalInsertClause(n *ConditionalInsertClause) error {
+	return s.VisitChildren(n) // ConditionalInsertClause
+}
+
 func (s *StubNodeVisitor) VisitContinueStatement(n *ContinueStatement) error {
 	return s.VisitChildren(n) // ContinueStatement
 }
 
+func (s *StubNodeVisitor) VisitCorrelationReference(n *CorrelationReference) error {
+	return s.VisitChildren(n) // CorrelationReference
+}
+
 func (s *StubNodeVisitor) VisitCreateCompoundDmlTriggerStatement(n *CreateCompoundDmlTriggerStatement) error {
 	return s.VisitChildren(n) // CreateCompoundDmlTriggerStatement
 }
@@ -944,6 +1207,10 @@ func (s *StubNodeVisitor) VisitCreateNestTableStatement(n *CreateNestTableStatem
 	return s.VisitChildren(n) // CreateNestTableStatement
 }
 
+func (s *StubNodeVisitor) VisitCreateNonDmlTriggerStatement(n *CreateNonDmlTriggerStatement) error {
+	return s.VisitChildren(n) // CreateNonDmlTriggerStatement
+}
+
 func (s *StubNodeVisitor) VisitCreatePackageBodyStatement(n *CreatePackageBodyStatement) error {
 	return s.VisitChildren(n) // CreatePackageBodyStatement
 }
@@ -1008,6 +1275,10 @@ func (s *StubNodeVisitor) VisitElseBlock(n *ElseBlock) error {
 	return s.VisitChildren(n) // ElseBlock
 }
 
+func (s *StubNodeVisitor) VisitErrorStatement(n *ErrorStatement) error {
+	return s.VisitChildren(n) // ErrorStatement
+}
+
 func (s *StubNodeVisitor) VisitExceptionDeclaration(n *ExceptionDeclaration) error {
 	return s.VisitChildren(n) // ExceptionDeclaration
 }
@@ -1080,6 +1351,34 @@ func (s *StubNodeVisitor) VisitIntoClause(n *IntoClause) error {
 	return s.VisitChildren(n) // IntoClause
 }
 
+func (s *StubNodeVisitor) VisitJsonArrayExpression(n *JsonArrayExpression) error {
+	return s.VisitChildren(n) // JsonArrayExpression
+}
+
+func (s *StubNodeVisitor) VisitJsonObjectEntry(n *JsonObjectEntry) error {
+	return s.VisitChildren(n) // JsonObjectEntry
+}
+
+func (s *StubNodeVisitor) VisitJsonObjectExpression(n *JsonObjectExpression) error {
+	return s.VisitChildren(n) // JsonObjectExpression
+}
+
+func (s *StubNodeVisitor) VisitJsonOnClause(n *JsonOnClause) error {
+	return s.VisitChildren(n) // JsonOnClause
+}
+
+func (s *StubNodeVisitor) VisitJsonTableColumn(n *JsonTableColumn) error {
+	return s.VisitChildren(n) // JsonTableColumn
+}
+
+func (s *StubNodeVisitor) VisitJsonTableExpression(n *JsonTableExpression) error {
+	return s.VisitChildren(n) // JsonTableExpression
+}
+
+func (s *StubNodeVisitor) VisitJsonValueExpression(n *JsonValueExpression) error {
+	return s.VisitChildren(n) // JsonValueExpression
+}
+
 func (s *StubNodeVisitor) VisitLabelDeclaration(n *LabelDeclaration) error {
 	return s.VisitChildren(n) // LabelDeclaration
 }
@@ -1092,6 +1391,10 @@ func (s *StubNodeVisitor) VisitListaggExpression(n *ListaggExpression) error {
 	return s.VisitChildren(n) // ListaggExpression
 }
 
+func (s *StubNodeVisitor) VisitLockTableStatement(n *LockTableStatement) error {
+	return s.VisitChildren(n) // LockTableStatement
+}
+
 func (s *StubNodeVisitor) VisitLoopStatement(n *LoopStatement) error {
 	return s.VisitChildren(n) // LoopStatement
 }
@@ -1108,6 +1411,22 @@ func (s *StubNodeVisitor) VisitMergeUpdateStatement(n *MergeUpdateStatement) err
 	return s.VisitChildren(n) // MergeUpdateStatement
 }
 
+func (s *StubNodeVisitor) VisitModelCellExpression(n *ModelCellExpression) error {
+	return s.VisitChildren(n) // ModelCellExpression
+}
+
+func (s *StubNodeVisitor) VisitModelClause(n *ModelClause) error {
+	return s.VisitChildren(n) // ModelClause
+}
+
+func (s *StubNodeVisitor) VisitModelDefinition(n *ModelDefinition) error {
+	return s.VisitChildren(n) // ModelDefinition
+}
+
+func (s *StubNodeVisitor) VisitModelRule(n *ModelRule) error {
+	return s.VisitChildren(n) // ModelRule
+}
+
 func (s *StubNodeVisitor) VisitNameExpression(n *NameExpression) error {
 	return s.VisitChildren(n) // NameExpression
 }
@@ -1156,10 +1475,26 @@ func (s *StubNodeVisitor) VisitParameter(n *Parameter) error {
 	return s.VisitChildren(n) // Parameter
 }
 
+func (s *StubNodeVisitor) VisitPivotClause(n *PivotClause) error {
+	return s.VisitChildren(n) // PivotClause
+}
+
+func (s *StubNodeVisitor) VisitPivotElement(n *PivotElement) error {
+	return s.VisitChildren(n) // PivotElement
+}
+
+func (s *StubNodeVisitor) VisitPivotInElement(n *PivotInElement) error {
+	return s.VisitChildren(n) // PivotInElement
+}
+
 func (s *StubNodeVisitor) VisitProcedureCall(n *ProcedureCall) error {
 	return s.VisitChildren(n) // ProcedureCall
 }
 
+func (s *StubNodeVisitor) VisitPseudoColumn(n *PseudoColumn) error {
+	return s.VisitChildren(n) // PseudoColumn
+}
+
 func (s *StubNodeVisitor) VisitQueryExpression(n *QueryExpression) error {
 	return s.VisitChildren(n) // QueryExpression
 }
@@ -1180,6 +1515,10 @@ func (s *StubNodeVisitor) VisitRollbackStatement(n *RollbackStatement) error {
 	return s.VisitChildren(n) // RollbackStatement
 }
 
+func (s *StubNodeVisitor) VisitSavepointStatement(n *SavepointStatement) error {
+	return s.VisitChildren(n) // SavepointStatement
+}
+
 func (s *StubNodeVisitor) VisitScript(n *Script) error {
 	return s.VisitChildren(n) // Script
 }
@@ -1192,14 +1531,34 @@ func (s *StubNodeVisitor) VisitSelectStatement(n *SelectStatement) error {
 	return s.VisitChildren(n) // SelectStatement
 }
 
+func (s *StubNodeVisitor) VisitSequenceReference(n *SequenceReference) error {
+	return s.VisitChildren(n) // SequenceReference
+}
+
+func (s *StubNodeVisitor) VisitSessionParameter(n *SessionParameter) error {
+	return s.VisitChildren(n) // SessionParameter
+}
+
 func (s *StubNodeVisitor) VisitSetOperationStatement(n *SetOperationStatement) error {
 	return s.VisitChildren(n) // SetOperationStatement
 }
 
+func (s *StubNodeVisitor) VisitSetTransactionStatement(n *SetTransactionStatement) error {
+	return s.VisitChildren(n) // SetTransactionStatement
+}
+
 func (s *StubNodeVisitor) VisitSignExpression(n *SignExpression) error {
 	return s.VisitChildren(n) // SignExpression
 }
 
+func (s *StubNodeVisitor) VisitSqlCursorAttribute(n *SqlCursorAttribute) error {
+	return s.VisitChildren(n) // SqlCursorAttribute
+}
+
+func (s *StubNodeVisitor) VisitSqlPlusCommand(n *SqlPlusCommand) error {
+	return s.VisitChildren(n) // SqlPlusCommand
+}
+
 func (s *StubNodeVisitor) VisitStatementExpression(n *StatementExpression) error {
 	return s.VisitChildren(n) // StatementExpression
 }
@@ -1220,10 +1579,22 @@ func (s *StubNodeVisitor) VisitTriggerBlock(n *TriggerBlock) error {
 	return s.VisitChildren(n) // TriggerBlock
 }
 
+func (s *StubNodeVisitor) VisitTriggerReferencing(n *TriggerReferencing) error {
+	return s.VisitChildren(n) // TriggerReferencing
+}
+
 func (s *StubNodeVisitor) VisitUnaryLogicalExpression(n *UnaryLogicalExpression) error {
 	return s.VisitChildren(n) // UnaryLogicalExpression
 }
 
+func (s *StubNodeVisitor) VisitUnpivotClause(n *UnpivotClause) error {
+	return s.VisitChildren(n) // UnpivotClause
+}
+
+func (s *StubNodeVisitor) VisitUnpivotInElement(n *UnpivotInElement) error {
+	return s.VisitChildren(n) // UnpivotInElement
+}
+
 func (s *StubNodeVisitor) VisitUpdateStatement(n *UpdateStatement) error {
 	return s.VisitChildren(n) // UpdateStatement
 }
@@ -1248,10 +1619,30 @@ func (s *StubNodeVisitor) VisitWithClause(n *WithClause) error {
 	return s.VisitChildren(n) // WithClause
 }
 
+func (s *StubNodeVisitor) VisitXmlAggExpression(n *XmlAggExpression) error {
+	return s.VisitChildren(n) // XmlAggExpression
+}
+
+func (s *StubNodeVisitor) VisitXmlElementExpression(n *XmlElementExpression) error {
+	return s.VisitChildren(n) // XmlElementExpression
+}
+
+func (s *StubNodeVisitor) VisitXmlTableColumn(n *XmlTableColumn) error {
+	return s.VisitChildren(n) // XmlTableColumn
+}
+
+func (s *StubNodeVisitor) VisitXmlTableExpression(n *XmlTableExpression) error {
+	return s.VisitChildren(n) // XmlTableExpression
+}
+
 func (b *AliasExpression) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitAliasExpression(b)
 }
 
+func (b *AlterSessionStatement) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitAlterSessionStatement(b)
+}
+
 func (b *Argument) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitArgument(b)
 }
@@ -1312,10 +1703,26 @@ func (b *CompoundTriggerBlock) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitCompoundTriggerBlock(b)
 }
 
+func (b *ConditionalBlock) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitConditionalBlock(b)
+}
+
+func (b *ConditionalBranch) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitConditionalBranch(b)
+}
+
+func (b *ConditionalInsertClause) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitConditionalInsertClause(b)
+}
+
 func (b *ContinueStatement) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitContinueStatement(b)
 }
 
+func (b *CorrelationReference) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitCorrelationReference(b)
+}
+
 func (b *CreateCompoundDmlTriggerStatement) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitCreateCompoundDmlTriggerStatement(b)
 }
@@ -1328,6 +1735,10 @@ func (b *CreateNestTableStatement) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitCreateNestTableStatement(b)
 }
 
+func (b *CreateNonDmlTriggerStatement) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitCreateNonDmlTriggerStatement(b)
+}
+
 func (b *CreatePackageBodyStatement) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitCreatePackageBodyStatement(b)
 }
@@ -1392,6 +1803,10 @@ func (b *ElseBlock) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitElseBlock(b)
 }
 
+func (b *ErrorStatement) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitErrorStatement(b)
+}
+
 func (b *ExceptionDeclaration) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitExceptionDeclaration(b)
 }
@@ -1464,6 +1879,34 @@ func (b *IntoClause) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitIntoClause(b)
 }
 
+func (b *JsonArrayExpression) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitJsonArrayExpression(b)
+}
+
+func (b *JsonObjectEntry) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitJsonObjectEntry(b)
+}
+
+func (b *JsonObjectExpression) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitJsonObjectExpression(b)
+}
+
+func (b *JsonOnClause) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitJsonOnClause(b)
+}
+
+func (b *JsonTableColumn) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitJsonTableColumn(b)
+}
+
+func (b *JsonTableExpression) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitJsonTableExpression(b)
+}
+
+func (b *JsonValueExpression) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitJsonValueExpression(b)
+}
+
 func (b *LabelDeclaration) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitLabelDeclaration(b)
 }
@@ -1476,6 +1919,10 @@ func (b *ListaggExpression) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitListaggExpression(b)
 }
 
+func (b *LockTableStatement) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitLockTableStatement(b)
+}
+
 func (b *LoopStatement) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitLoopStatement(b)
 }
@@ -1492,6 +1939,22 @@ func (b *MergeUpdateStatement) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitMergeUpdateStatement(b)
 }
 
+func (b *ModelCellExpression) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitModelCellExpression(b)
+}
+
+func (b *ModelClause) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitModelClause(b)
+}
+
+func (b *ModelDefinition) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitModelDefinition(b)
+}
+
+func (b *ModelRule) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitModelRule(b)
+}
+
 func (b *NameExpression) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitNameExpression(b)
 }
@@ -1540,10 +2003,26 @@ func (b *Parameter) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitParameter(b)
 }
 
+func (b *PivotClause) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitPivotClause(b)
+}
+
+func (b *PivotElement) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitPivotElement(b)
+}
+
+func (b *PivotInElement) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitPivotInElement(b)
+}
+
 func (b *ProcedureCall) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitProcedureCall(b)
 }
 
+func (b *PseudoColumn) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitPseudoColumn(b)
+}
+
 func (b *QueryExpression) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitQueryExpression(b)
 }
@@ -1564,6 +2043,10 @@ func (b *RollbackStatement) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitRollbackStatement(b)
 }
 
+func (b *SavepointStatement) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitSavepointStatement(b)
+}
+
 func (b *Script) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitScript(b)
 }
@@ -1576,14 +2059,34 @@ func (b *SelectStatement) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitSelectStatement(b)
 }
 
+func (b *SequenceReference) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitSequenceReference(b)
+}
+
+func (b *SessionParameter) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitSessionParameter(b)
+}
+
 func (b *SetOperationStatement) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitSetOperationStatement(b)
 }
 
+func (b *SetTransactionStatement) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitSetTransactionStatement(b)
+}
+
 func (b *SignExpression) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitSignExpression(b)
 }
 
+func (b *SqlCursorAttribute) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitSqlCursorAttribute(b)
+}
+
+func (b *SqlPlusCommand) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitSqlPlusCommand(b)
+}
+
 func (b *StatementExpression) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitStatementExpression(b)
 }
@@ -1604,10 +2107,22 @@ func (b *TriggerBlock) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitTriggerBlock(b)
 }
 
+func (b *TriggerReferencing) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitTriggerReferencing(b)
+}
+
 func (b *UnaryLogicalExpression) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitUnaryLogicalExpression(b)
 }
 
+func (b *UnpivotClause) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitUnpivotClause(b)
+}
+
+func (b *UnpivotInElement) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitUnpivotInElement(b)
+}
+
 func (b *UpdateStatement) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitUpdateStatement(b)
 }
@@ -1632,8 +2147,25 @@ func (b *WithClause) Accept(visitor NodeVisitor) (err error) {
 	return visitor.VisitWithClause(b)
 }
 
+func (b *XmlAggExpression) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitXmlAggExpression(b)
+}
+
+func (b *XmlElementExpression) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitXmlElementExpression(b)
+}
+
+func (b *XmlTableColumn) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitXmlTableColumn(b)
+}
+
+func (b *XmlTableExpression) Accept(visitor NodeVisitor) (err error) {
+	return visitor.VisitXmlTableExpression(b)
+}
+
 var register = sync.OnceFunc(func() {
 	gob.Register(&AliasExpression{})
+	gob.Register(&AlterSessionStatement{})
 	gob.Register(&Argument{})
 	gob.Register(&AssignmentStatement{})
 	gob.Register(&AutonomousTransactionDeclaration{})
@@ -1649,10 +2181,15 @@ var register = sync.OnceFunc(func() {
 	gob.Register(&CommitStatement{})
 	gob.Register(&CommonTableExpression{})
 	gob.Register(&CompoundTriggerBlock{})
+	gob.Register(&ConditionalBlock{})
+	gob.Register(&ConditionalBranch{})
+	gob.Register(&ConditionalInsertClause{})
 	gob.Register(&ContinueStatement{})
+	gob.Register(&CorrelationReference{})
 	gob.Register(&CreateCompoundDmlTriggerStatement{})
 	gob.Register(&CreateFunctionStatement{})
 	gob.Register(&CreateNestTableStatement{})
+	gob.Register(&CreateNonDmlTriggerStatement{})
 	gob.Register(&CreatePackageBodyStatement{})
 	gob.Register(&CreatePackageStatement{})
 	gob.Register(&CreateProcedureStatement{})
@@ -1669,6 +2206,7 @@ var register = sync.OnceFunc(func() {
 	gob.Register(&DropProcedureStatement{})
 	gob.Register(&DropTriggerStatement{})
 	gob.Register(&ElseBlock{})
+	gob.Register(&ErrorStatement{})
 	gob.Register(&ExceptionDeclaration{})
 	gob.Register(&ExecuteImmediateStatement{})
 	gob.Register(&ExistsExpression{})
@@ -1687,13 +2225,25 @@ var register = sync.OnceFunc(func() {
 	gob.Register(&InsertIntoClause{})
 	gob.Register(&InsertStatement{})
 	gob.Register(&IntoClause{})
+	gob.Register(&JsonArrayExpression{})
+	gob.Register(&JsonObjectEntry{})
+	gob.Register(&JsonObjectExpression{})
+	gob.Register(&JsonOnClause{})
+	gob.Register(&JsonTableColumn{})
+	gob.Register(&JsonTableExpression{})
+	gob.Register(&JsonValueExpression{})
 	gob.Register(&LabelDeclaration{})
 	gob.Register(&LikeExpression{})
 	gob.Register(&ListaggExpression{})
+	gob.Register(&LockTableStatement{})
 	gob.Register(&LoopStatement{})
 	gob.Register(&MergeInsertStatement{})
 	gob.Register(&MergeStatement{})
 	gob.Register(&MergeUpdateStatement{})
+	gob.Register(&ModelCellExpression{})
+	gob.Register(&ModelClause{})
+	gob.Register(&ModelDefinition{})
+	gob.Register(&ModelRule{})
 	gob.Register(&NameExpression{})
 	gob.Register(&NamedArgumentExpression{})
 	gob.Register(&NestTableTypeDeclaration{})
@@ -1706,27 +2256,1116 @@ var register = sync.OnceFunc(func() {
 	gob.Register(&OrderByElement{})
 	gob.Register(&OuterJoinExpression{})
 	gob.Register(&Parameter{})
+	gob.Register(&PivotClause{})
+	gob.Register(&PivotElement{})
+	gob.Register(&PivotInElement{})
 	gob.Register(&ProcedureCall{})
+	gob.Register(&PseudoColumn{})
 	gob.Register(&QueryExpression{})
 	gob.Register(&RaiseStatement{})
 	gob.Register(&RelationalExpression{})
 	gob.Register(&ReturnStatement{})
 	gob.Register(&RollbackStatement{})
+	gob.Register(&SavepointStatement{})
 	gob.Register(&Script{})
 	gob.Register(&SelectField{})
 	gob.Register(&SelectStatement{})
+	gob.Register(&SequenceReference{})
+	gob.Register(&SessionParameter{})
 	gob.Register(&SetOperationStatement{})
+	gob.Register(&SetTransactionStatement{})
 	gob.Register(&SignExpression{})
+	gob.Register(&SqlCursorAttribute{})
+	gob.Register(&SqlPlusCommand{})
 	gob.Register(&StatementExpression{})
 	gob.Register(&StringLiteral{})
 	gob.Register(&TableRef{})
 	gob.Register(&TimingPoint{})
 	gob.Register(&TriggerBlock{})
+	gob.Register(&TriggerReferencing{})
 	gob.Register(&UnaryLogicalExpression{})
+	gob.Register(&UnpivotClause{})
+	gob.Register(&UnpivotInElement{})
 	gob.Register(&UpdateStatement{})
 	gob.Register(&UsingClause{})
 	gob.Register(&UsingElement{})
 	gob.Register(&VariableDeclaration{})
 	gob.Register(&WildCardField{})
 	gob.Register(&WithClause{})
+	gob.Register(&XmlAggExpression{})
+	gob.Register(&XmlElementExpression{})
+	gob.Register(&XmlTableColumn{})
+	gob.Register(&XmlTableExpression{})
 })
+
+// nodeKinds maps the kind of each node in JSON, the name of its type, to
+// a function making a zero node of the type.
+var nodeKinds = map[string]func() AstNode{
+	"AliasExpression":                   func() AstNode { return &AliasExpression{} },
+	"AlterSessionStatement":             func() AstNode { return &AlterSessionStatement{} },
+	"Argument":                          func() AstNode { return &Argument{} },
+	"AssignmentStatement":               func() AstNode { return &AssignmentStatement{} },
+	"AutonomousTransactionDeclaration":  func() AstNode { return &AutonomousTransactionDeclaration{} },
+	"BetweenExpression":                 func() AstNode { return &BetweenExpression{} },
+	"BinaryExpression":                  func() AstNode { return &BinaryExpression{} },
+	"BindNameExpression":                func() AstNode { return &BindNameExpression{} },
+	"BlockStatement":                    func() AstNode { return &BlockStatement{} },
+	"Body":                              func() AstNode { return &Body{} },
+	"CaseWhenBlock":                     func() AstNode { return &CaseWhenBlock{} },
+	"CaseWhenStatement":                 func() AstNode { return &CaseWhenStatement{} },
+	"CastExpression":                    func() AstNode { return &CastExpression{} },
+	"CloseStatement":                    func() AstNode { return &CloseStatement{} },
+	"CommitStatement":                   func() AstNode { return &CommitStatement{} },
+	"CommonTableExpression":             func() AstNode { return &CommonTableExpression{} },
+	"CompoundTriggerBlock":              func() AstNode { return &CompoundTriggerBlock{} },
+	"ConditionalBlock":                  func() AstNode { return &ConditionalBlock{} },
+	"ConditionalBranch":                 func() AstNode { return &ConditionalBranch{} },
+	"ConditionalInsertClause":           func() AstNode { return &ConditionalInsertClause{} },
+	"ContinueStatement":                 func() AstNode { return &ContinueStatement{} },
+	"CorrelationReference":              func() AstNode { return &CorrelationReference{} },
+	"CreateCompoundDmlTriggerStatement": func() AstNode { return &CreateCompoundDmlTriggerStatement{} },
+	"CreateFunctionStatement":           func() AstNode { return &CreateFunctionStatement{} },
+	"CreateNestTableStatement":          func() AstNode { return &CreateNestTableStatement{} },
+	"CreateNonDmlTriggerStatement":      func() AstNode { return &CreateNonDmlTriggerStatement{} },
+	"CreatePackageBodyStatement":        func() AstNode { return &CreatePackageBodyStatement{} },
+	"CreatePackageStatement":            func() AstNode { return &CreatePackageStatement{} },
+	"CreateProcedureStatement":          func() AstNode { return &CreateProcedureStatement{} },
+	"CreateSimpleDmlTriggerStatement":   func() AstNode { return &CreateSimpleDmlTriggerStatement{} },
+	"CreateSynonymStatement":            func() AstNode { return &CreateSynonymStatement{} },
+	"CreateTriggerStatement":            func() AstNode { return &CreateTriggerStatement{} },
+	"CreateTypeStatement":               func() AstNode { return &CreateTypeStatement{} },
+	"CursorAttribute":                   func() AstNode { return &CursorAttribute{} },
+	"CursorDeclaration":                 func() AstNode { return &CursorDeclaration{} },
+	"DeleteStatement":                   func() AstNode { return &DeleteStatement{} },
+	"DotExpression":                     func() AstNode { return &DotExpression{} },
+	"DropFunctionStatement":             func() AstNode { return &DropFunctionStatement{} },
+	"DropPackageStatement":              func() AstNode { return &DropPackageStatement{} },
+	"DropProcedureStatement":            func() AstNode { return &DropProcedureStatement{} },
+	"DropTriggerStatement":              func() AstNode { return &DropTriggerStatement{} },
+	"ElseBlock":                         func() AstNode { return &ElseBlock{} },
+	"ErrorStatement":                    func() AstNode { return &ErrorStatement{} },
+	"ExceptionDeclaration":              func() AstNode { return &ExceptionDeclaration{} },
+	"ExecuteImmediateStatement":         func() AstNode { return &ExecuteImmediateStatement{} },
+	"ExistsExpression":                  func() AstNode { return &ExistsExpression{} },
+	"ExitStatement":                     func() AstNode { return &ExitStatement{} },
+	"ExprListExpression":                func() AstNode { return &ExprListExpression{} },
+	"FetchStatement":                    func() AstNode { return &FetchStatement{} },
+	"FieldList":                         func() AstNode { return &FieldList{} },
+	"ForUpdateClause":                   func() AstNode { return &ForUpdateClause{} },
+	"ForUpdateOptionsExpression":        func() AstNode { return &ForUpdateOptionsExpression{} },
+	"FromClause":                        func() AstNode { return &FromClause{} },
+	"FunctionCallExpression":            func() AstNode { return &FunctionCallExpression{} },
+	"FunctionDeclaration":               func() AstNode { return &FunctionDeclaration{} },
+	"GotoStatement":                     func() AstNode { return &GotoStatement{} },
+	"IfStatement":                       func() AstNode { return &IfStatement{} },
+	"InExpression":                      func() AstNode { return &InExpression{} },
+	"InsertIntoClause":                  func() AstNode { return &InsertIntoClause{} },
+	"InsertStatement":                   func() AstNode { return &InsertStatement{} },
+	"IntoClause":                        func() AstNode { return &IntoClause{} },
+	"JsonArrayExpression":               func() AstNode { return &JsonArrayExpression{} },
+	"JsonObjectEntry":                   func() AstNode { return &JsonObjectEntry{} },
+	"JsonObjectExpression":              func() AstNode { return &JsonObjectExpression{} },
+	"JsonOnClause":                      func() AstNode { return &JsonOnClause{} },
+	"JsonTableColumn":                   func() AstNode { return &JsonTableColumn{} },
+	"JsonTableExpression":               func() AstNode { return &JsonTableExpression{} },
+	"JsonValueExpression":               func() AstNode { return &JsonValueExpression{} },
+	"LabelDeclaration":                  func() AstNode { return &LabelDeclaration{} },
+	"LikeExpression":                    func() AstNode { return &LikeExpression{} },
+	"ListaggExpression":                 func() AstNode { return &ListaggExpression{} },
+	"LockTableStatement":                func() AstNode { return &LockTableStatement{} },
+	"LoopStatement":                     func() AstNode { return &LoopStatement{} },
+	"MergeInsertStatement":              func() AstNode { return &MergeInsertStatement{} },
+	"MergeStatement":                    func() AstNode { return &MergeStatement{} },
+	"MergeUpdateStatement":              func() AstNode { return &MergeUpdateStatement{} },
+	"ModelCellExpression":               func() AstNode { return &ModelCellExpression{} },
+	"ModelClause":                       func() AstNode { return &ModelClause{} },
+	"ModelDefinition":                   func() AstNode { return &ModelDefinition{} },
+	"ModelRule":                         func() AstNode { return &ModelRule{} },
+	"NameExpression":                    func() AstNode { return &NameExpression{} },
+	"NamedArgumentExpression":           func() AstNode { return &NamedArgumentExpression{} },
+	"NestTableTypeDeclaration":          func() AstNode { return &NestTableTypeDeclaration{} },
+	"NullExpression":                    func() AstNode { return &NullExpression{} },
+	"NullStatement":                     func() AstNode { return &NullStatement{} },
+	"NumericLiteral":                    func() AstNode { return &NumericLiteral{} },
+	"OpenForStatement":                  func() AstNode { return &OpenForStatement{} },
+	"OpenStatement":                     func() AstNode { return &OpenStatement{} },
+	"OrderByClause":                     func() AstNode { return &OrderByClause{} },
+	"OrderByElement":                    func() AstNode { return &OrderByElement{} },
+	"OuterJoinExpression":               func() AstNode { return &OuterJoinExpression{} },
+	"Parameter":                         func() AstNode { return &Parameter{} },
+	"PivotClause":                       func() AstNode { return &PivotClause{} },
+	"PivotElement":                      func() AstNode { return &PivotElement{} },
+	"PivotInElement":                    func() AstNode { return &PivotInElement{} },
+	"ProcedureCall":                     func() AstNode { return &ProcedureCall{} },
+	"PseudoColumn":                      func() AstNode { return &PseudoColumn{} },
+	"QueryExpression":                   func() AstNode { return &QueryExpression{} },
+	"RaiseStatement":                    func() AstNode { return &RaiseStatement{} },
+	"RelationalExpression":              func() AstNode { return &RelationalExpression{} },
+	"ReturnStatement":                   func() AstNode { return &ReturnStatement{} },
+	"RollbackStatement":                 func() AstNode { return &RollbackStatement{} },
+	"SavepointStatement":                func() AstNode { return &SavepointStatement{} },
+	"Script":                            func() AstNode { return &Script{} },
+	"SelectField":                       func() AstNode { return &SelectField{} },
+	"SelectStatement":                   func() AstNode { return &SelectStatement{} },
+	"SequenceReference":                 func() AstNode { return &SequenceReference{} },
+	"SessionParameter":                  func() AstNode { return &SessionParameter{} },
+	"SetOperationStatement":             func() AstNode { return &SetOperationStatement{} },
+	"SetTransactionStatement":           func() AstNode { return &SetTransactionStatement{} },
+	"SignExpression":                    func() AstNode { return &SignExpression{} },
+	"SqlCursorAttribute":                func() AstNode { return &SqlCursorAttribute{} },
+	"SqlPlusCommand":                    func() AstNode { return &SqlPlusCommand{} },
+	"StatementExpression":               func() AstNode { return &StatementExpression{} },
+	"StringLiteral":                     func() AstNode { return &StringLiteral{} },
+	"TableRef":                          func() AstNode { return &TableRef{} },
+	"TimingPoint":                       func() AstNode { return &TimingPoint{} },
+	"TriggerBlock":                      func() AstNode { return &TriggerBlock{} },
+	"TriggerReferencing":                func() AstNode { return &TriggerReferencing{} },
+	"UnaryLogicalExpression":            func() AstNode { return &UnaryLogicalExpression{} },
+	"UnpivotClause":                     func() AstNode { return &UnpivotClause{} },
+	"UnpivotInElement":                  func() AstNode { return &UnpivotInElement{} },
+	"UpdateStatement":                   func() AstNode { return &UpdateStatement{} },
+	"UsingClause":                       func() AstNode { return &UsingClause{} },
+	"UsingElement":                      func() AstNode { return &UsingElement{} },
+	"VariableDeclaration":               func() AstNode { return &VariableDeclaration{} },
+	"WildCardField":                     func() AstNode { return &WildCardField{} },
+	"WithClause":                        func() AstNode { return &WithClause{} },
+	"XmlAggExpression":                  func() AstNode { return &XmlAggExpression{} },
+	"XmlElementExpression":              func() AstNode { return &XmlElementExpression{} },
+	"XmlTableColumn":                    func() AstNode { return &XmlTableColumn{} },
+	"XmlTableExpression":                func() AstNode { return &XmlTableExpression{} },
+}
+
+// appendChildren appends the children of node to children, in the order
+// of the fields of its type. Types the generator did not see fall back to
+// reflection.
+func appendChildren(children []AstNode, node AstNode) []AstNode {
+	switch n := node.(type) {
+	case *AliasExpression:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Expr)
+	case *AlterSessionStatement:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Parameters {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+	case *Argument:
+	case *AssignmentStatement:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Right)
+	case *AutonomousTransactionDeclaration:
+	case *BetweenExpression:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Expr)
+		for _, c := range n.Elems {
+			children = appendChild(children, c)
+		}
+	case *BinaryExpression:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Left)
+		children = appendChild(children, n.Right)
+	case *BindNameExpression:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Name)
+	case *BlockStatement:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Declarations {
+			children = appendChild(children, c)
+		}
+		if n.Body != nil {
+			children = append(children, n.Body)
+		}
+	case *Body:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Statements {
+			children = appendChild(children, c)
+		}
+	case *CaseWhenBlock:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Condition)
+		children = appendChild(children, n.Expr)
+		for _, c := range n.Stmts {
+			children = appendChild(children, c)
+		}
+	case *CaseWhenStatement:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Expr)
+		for _, c := range n.WhenClauses {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+		if n.ElseClause != nil {
+			children = append(children, n.ElseClause)
+		}
+	case *CastExpression:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Expr)
+	case *CloseStatement:
+	case *CommitStatement:
+	case *CommonTableExpression:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Name)
+		if n.Query != nil {
+			children = append(children, n.Query)
+		}
+		for _, c := range n.ColNameList {
+			children = appendChild(children, c)
+		}
+	case *CompoundTriggerBlock:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Declarations {
+			children = appendChild(children, c)
+		}
+		for _, c := range n.TimingPoints {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+	case *ConditionalBlock:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Branches {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+	case *ConditionalBranch:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Nodes {
+			children = appendChild(children, c)
+		}
+	case *ConditionalInsertClause:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Condition)
+		for _, c := range n.Into {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+	case *ContinueStatement:
+	case *CorrelationReference:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Expr)
+	case *CreateCompoundDmlTriggerStatement:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.When)
+		children = appendChild(children, n.TriggerBody)
+		if n.Referencing != nil {
+			children = append(children, n.Referencing)
+		}
+	case *CreateFunctionStatement:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Parameters {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+		for _, c := range n.Declarations {
+			children = appendChild(children, c)
+		}
+		if n.Body != nil {
+			children = append(children, n.Body)
+		}
+	case *CreateNestTableStatement:
+	case *CreateNonDmlTriggerStatement:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.When)
+		children = appendChild(children, n.TriggerBody)
+	case *CreatePackageBodyStatement:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Procedures {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+		for _, c := range n.Functions {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+	case *CreatePackageStatement:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Procedures {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+		for _, c := range n.Types {
+			children = appendChild(children, c)
+		}
+		for _, c := range n.Variables {
+			children = appendChild(children, c)
+		}
+	case *CreateProcedureStatement:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Parameters {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+		for _, c := range n.Declarations {
+			children = appendChild(children, c)
+		}
+		if n.Body != nil {
+			children = append(children, n.Body)
+		}
+	case *CreateSimpleDmlTriggerStatement:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.When)
+		children = appendChild(children, n.TriggerBody)
+		if n.Referencing != nil {
+			children = append(children, n.Referencing)
+		}
+	case *CreateSynonymStatement:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Synonym)
+		children = appendChild(children, n.Original)
+	case *CreateTriggerStatement:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.When)
+		children = appendChild(children, n.TriggerBody)
+	case *CreateTypeStatement:
+	case *CursorAttribute:
+	case *CursorDeclaration:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Parameters {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+		children = appendChild(children, n.Stmt)
+	case *DeleteStatement:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Table)
+		children = appendChild(children, n.Where)
+	case *DotExpression:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Name)
+		children = appendChild(children, n.Parent)
+	case *DropFunctionStatement:
+	case *DropPackageStatement:
+	case *DropProcedureStatement:
+	case *DropTriggerStatement:
+	case *ElseBlock:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Statements {
+			children = appendChild(children, c)
+		}
+	case *ErrorStatement:
+	case *ExceptionDeclaration:
+	case *ExecuteImmediateStatement:
+		if n == nil {
+			break
+		}
+		if n.Into != nil {
+			children = append(children, n.Into)
+		}
+		if n.Using != nil {
+			children = append(children, n.Using)
+		}
+	case *ExistsExpression:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Expr)
+	case *ExitStatement:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Condition)
+	case *ExprListExpression:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Exprs {
+			children = appendChild(children, c)
+		}
+	case *FetchStatement:
+	case *FieldList:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Fields {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+	case *ForUpdateClause:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Options)
+	case *ForUpdateOptionsExpression:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Wait)
+	case *FromClause:
+		if n == nil {
+			break
+		}
+		for _, c := range n.TableRefs {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+	case *FunctionCallExpression:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Name)
+		for _, c := range n.Args {
+			children = appendChild(children, c)
+		}
+	case *FunctionDeclaration:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Parameters {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+	case *GotoStatement:
+	case *IfStatement:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Condition)
+		for _, c := range n.ThenBlock {
+			children = appendChild(children, c)
+		}
+		for _, c := range n.ElseBlock {
+			children = appendChild(children, c)
+		}
+		for _, c := range n.ElseIfs {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+	case *InExpression:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Expr)
+		for _, c := range n.Elems {
+			children = appendChild(children, c)
+		}
+	case *InsertIntoClause:
+		if n == nil {
+			break
+		}
+		if n.Table != nil {
+			children = append(children, n.Table)
+		}
+		for _, c := range n.Columns {
+			children = appendChild(children, c)
+		}
+		for _, c := range n.Values {
+			children = appendChild(children, c)
+		}
+	case *InsertStatement:
+		if n == nil {
+			break
+		}
+		for _, c := range n.AllInto {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+		for _, c := range n.Conditions {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+		for _, c := range n.Else {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+		if n.Select != nil {
+			children = append(children, n.Select)
+		}
+	case *IntoClause:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Vars {
+			children = appendChild(children, c)
+		}
+	case *JsonArrayExpression:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Elements {
+			children = appendChild(children, c)
+		}
+		if n.OrderBy != nil {
+			children = append(children, n.OrderBy)
+		}
+		if n.OnNull != nil {
+			children = append(children, n.OnNull)
+		}
+	case *JsonObjectEntry:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Key)
+		children = appendChild(children, n.Value)
+	case *JsonObjectExpression:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Entries {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+		if n.OnNull != nil {
+			children = append(children, n.OnNull)
+		}
+	case *JsonOnClause:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Default)
+	case *JsonTableColumn:
+		if n == nil {
+			break
+		}
+		if n.OnError != nil {
+			children = append(children, n.OnError)
+		}
+		if n.OnEmpty != nil {
+			children = append(children, n.OnEmpty)
+		}
+		for _, c := range n.Nested {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+	case *JsonTableExpression:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Expr)
+		if n.OnError != nil {
+			children = append(children, n.OnError)
+		}
+		if n.OnEmpty != nil {
+			children = append(children, n.OnEmpty)
+		}
+		for _, c := range n.Columns {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+	case *JsonValueExpression:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Expr)
+		if n.OnError != nil {
+			children = append(children, n.OnError)
+		}
+		if n.OnEmpty != nil {
+			children = append(children, n.OnEmpty)
+		}
+		if n.OnMismatch != nil {
+			children = append(children, n.OnMismatch)
+		}
+	case *LabelDeclaration:
+	case *LikeExpression:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Expr)
+		children = appendChild(children, n.LikeExpr)
+	case *ListaggExpression:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Args {
+			children = appendChild(children, c)
+		}
+		children = appendChild(children, n.Within)
+		children = appendChild(children, n.Over)
+	case *LockTableStatement:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Tables {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+		children = appendChild(children, n.Wait)
+	case *LoopStatement:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Statements {
+			children = appendChild(children, c)
+		}
+	case *MergeInsertStatement:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Columns {
+			children = appendChild(children, c)
+		}
+		for _, c := range n.Values {
+			children = appendChild(children, c)
+		}
+		children = appendChild(children, n.Where)
+	case *MergeStatement:
+		if n == nil {
+			break
+		}
+		if n.Table != nil {
+			children = append(children, n.Table)
+		}
+		children = appendChild(children, n.Using)
+		children = appendChild(children, n.OnCondition)
+		if n.MergeUpdate != nil {
+			children = append(children, n.MergeUpdate)
+		}
+		if n.MergeInsert != nil {
+			children = append(children, n.MergeInsert)
+		}
+	case *MergeUpdateStatement:
+		if n == nil {
+			break
+		}
+		for _, c := range n.SetElems {
+			children = appendChild(children, c)
+		}
+		children = appendChild(children, n.Where)
+		children = appendChild(children, n.Delete)
+	case *ModelCellExpression:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Measure)
+		for _, c := range n.Dimensions {
+			children = appendChild(children, c)
+		}
+	case *ModelClause:
+		if n == nil {
+			break
+		}
+		for _, c := range n.ReferenceModels {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+		if n.MainModel != nil {
+			children = append(children, n.MainModel)
+		}
+	case *ModelDefinition:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Query)
+		for _, c := range n.PartitionBy {
+			children = appendChild(children, c)
+		}
+		for _, c := range n.DimensionBy {
+			children = appendChild(children, c)
+		}
+		for _, c := range n.Measures {
+			children = appendChild(children, c)
+		}
+		for _, c := range n.Rules {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+	case *ModelRule:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Cell)
+		children = appendChild(children, n.OrderBy)
+		children = appendChild(children, n.Expr)
+	case *NameExpression:
+	case *NamedArgumentExpression:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Name)
+		children = appendChild(children, n.Value)
+	case *NestTableTypeDeclaration:
+	case *NullExpression:
+	case *NullStatement:
+	case *NumericLiteral:
+	case *OpenForStatement:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Name)
+		children = appendChild(children, n.For)
+		children = appendChild(children, n.Using)
+	case *OpenStatement:
+	case *OrderByClause:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Elements {
+			children = appendChild(children, c)
+		}
+	case *OrderByElement:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Item)
+	case *OuterJoinExpression:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Expr)
+	case *Parameter:
+	case *PivotClause:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Aggregates {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+		for _, c := range n.For {
+			children = appendChild(children, c)
+		}
+		for _, c := range n.In {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+		children = appendChild(children, n.InQuery)
+	case *PivotElement:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Expr)
+	case *PivotInElement:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Values {
+			children = appendChild(children, c)
+		}
+	case *ProcedureCall:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Name)
+		for _, c := range n.Arguments {
+			children = appendChild(children, c)
+		}
+	case *PseudoColumn:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Expr)
+	case *QueryExpression:
+		if n == nil {
+			break
+		}
+		if n.Query != nil {
+			children = append(children, n.Query)
+		}
+	case *RaiseStatement:
+	case *RelationalExpression:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Left)
+		children = appendChild(children, n.Right)
+	case *ReturnStatement:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Name)
+	case *RollbackStatement:
+	case *SavepointStatement:
+	case *Script:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Statements {
+			children = appendChild(children, c)
+		}
+		for _, c := range n.Conditionals {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+	case *SelectField:
+		if n == nil {
+			break
+		}
+		if n.WildCard != nil {
+			children = append(children, n.WildCard)
+		}
+		children = appendChild(children, n.Expr)
+	case *SelectStatement:
+		if n == nil {
+			break
+		}
+		if n.Fields != nil {
+			children = append(children, n.Fields)
+		}
+		if n.From != nil {
+			children = append(children, n.From)
+		}
+		children = appendChild(children, n.Where)
+		if n.ForUpdate != nil {
+			children = append(children, n.ForUpdate)
+		}
+		if n.With != nil {
+			children = append(children, n.With)
+		}
+		if n.Model != nil {
+			children = append(children, n.Model)
+		}
+	case *SequenceReference:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Expr)
+	case *SessionParameter:
+	case *SetOperationStatement:
+		if n == nil {
+			break
+		}
+		for _, c := range n.SelectList {
+			children = appendChild(children, c)
+		}
+	case *SetTransactionStatement:
+	case *SignExpression:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Expr)
+	case *SqlCursorAttribute:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Expr)
+	case *SqlPlusCommand:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Call)
+		if n.Include != nil {
+			children = append(children, n.Include)
+		}
+	case *StatementExpression:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Stmt)
+	case *StringLiteral:
+	case *TableRef:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Source)
+		if n.Pivot != nil {
+			children = append(children, n.Pivot)
+		}
+		if n.Unpivot != nil {
+			children = append(children, n.Unpivot)
+		}
+	case *TimingPoint:
+		if n == nil {
+			break
+		}
+		if n.Body != nil {
+			children = append(children, n.Body)
+		}
+	case *TriggerBlock:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Declarations {
+			children = appendChild(children, c)
+		}
+		if n.Body != nil {
+			children = append(children, n.Body)
+		}
+	case *TriggerReferencing:
+	case *UnaryLogicalExpression:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Expr)
+	case *UnpivotClause:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Columns {
+			children = appendChild(children, c)
+		}
+		for _, c := range n.For {
+			children = appendChild(children, c)
+		}
+		for _, c := range n.In {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+	case *UnpivotInElement:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Columns {
+			children = appendChild(children, c)
+		}
+		for _, c := range n.Values {
+			children = appendChild(children, c)
+		}
+	case *UpdateStatement:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Table)
+		children = appendChild(children, n.Where)
+		for _, c := range n.SetExprs {
+			children = appendChild(children, c)
+		}
+		children = appendChild(children, n.SetValue)
+	case *UsingClause:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Elems {
+			children = appendChild(children, c)
+		}
+	case *UsingElement:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Elem)
+	case *VariableDeclaration:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Initialization)
+	case *WildCardField:
+	case *WithClause:
+		if n == nil {
+			break
+		}
+		for _, c := range n.CTEs {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+	case *XmlAggExpression:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Expr)
+		if n.OrderBy != nil {
+			children = append(children, n.OrderBy)
+		}
+	case *XmlElementExpression:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Name)
+		for _, c := range n.Attributes {
+			children = appendChild(children, c)
+		}
+		for _, c := range n.Content {
+			children = appendChild(children, c)
+		}
+	case *XmlTableColumn:
+		if n == nil {
+			break
+		}
+		children = appendChild(children, n.Default)
+	case *XmlTableExpression:
+		if n == nil {
+			break
+		}
+		for _, c := range n.Namespaces {
+			children = appendChild(children, c)
+		}
+		children = appendChild(children, n.Query)
+		for _, c := range n.Passing {
+			children = appendChild(children, c)
+		}
+		for _, c := range n.Columns {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+	default:
+		return reflectChildren(children, node)
+	}
+	return children
+}
@@ -0,0 +1,52 @@
+package semantic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is an Oracle Database release, numbered by its major version:
+// 11 for 11g, 19 for 19c and 23 for 23ai. The zero Version stands for no
+// release in particular.
+type Version int
+
+const (
+	Version10 Version = 10
+	Version11 Version = 11
+	Version12 Version = 12
+	Version18 Version = 18
+	Version19 Version = 19
+	Version21 Version = 21
+	Version23 Version = 23
+)
+
+// String returns the release name Oracle uses, such as 11g, 19c or 23ai.
+func (v Version) String() string {
+	switch {
+	case v <= 0:
+		return ""
+	case v <= Version11:
+		return fmt.Sprintf("%dg", int(v))
+	case v >= Version23:
+		return fmt.Sprintf("%dai", int(v))
+	}
+	return fmt.Sprintf("%dc", int(v))
+}
+
+// ParseVersion parses a release given as 19c, 23ai, 11g or just the major
+// version, such as 19 or 12.2.
+func ParseVersion(s string) (Version, error) {
+	text := strings.ToLower(strings.TrimSpace(s))
+	for _, suffix := range []string{"ai", "c", "g"} {
+		text = strings.TrimSuffix(text, suffix)
+	}
+	if i := strings.IndexByte(text, '.'); i >= 0 {
+		text = text[:i]
+	}
+	major, err := strconv.Atoi(text)
+	if err != nil || major <= 0 {
+		return 0, fmt.Errorf("invalid Oracle version %q", s)
+	}
+	return Version(major), nil
+}
@@ -0,0 +1,40 @@
+package semantic
+
+// A Visitor's Visit method is called by Walk for each node it meets. If the
+// result visitor w is not nil, Walk visits each of the children of node
+// with w, followed by a call of w.Visit(nil).
+type Visitor interface {
+	Visit(node AstNode) (w Visitor)
+}
+
+// Walk traverses the tree rooted at node in depth-first order. It starts by
+// calling v.Visit(node), which is the pre-order hook: returning nil skips
+// the children of node. The call w.Visit(nil) made after the children is
+// the post-order hook. Children are visited in the order of the fields of
+// their parent's type, the elements of a slice in order.
+func Walk(v Visitor, node AstNode) {
+	if v = v.Visit(node); v == nil {
+		return
+	}
+	var buf [8]AstNode
+	for _, child := range appendChildren(buf[:0], node) {
+		Walk(v, child)
+	}
+	v.Visit(nil)
+}
+
+type inspector func(AstNode) bool
+
+func (f inspector) Visit(node AstNode) Visitor {
+	if f(node) {
+		return f
+	}
+	return nil
+}
+
+// Inspect traverses the tree rooted at node in depth-first order, calling
+// f(node) for each node. If f returns true, Inspect visits the children of
+// node, then calls f(nil).
+func Inspect(node AstNode, f func(AstNode) bool) {
+	Walk(inspector(f), node)
+}
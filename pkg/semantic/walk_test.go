@@ -0,0 +1,159 @@
+package semantic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testProcedure returns the tree of
+//
+//	create or replace procedure archive is
+//	begin
+//		loop
+//			update orders set archived = 1 where id = 1;
+//			exit;
+//		end loop;
+//		delete from orders;
+//	end;
+func testProcedure() *Script {
+	return &Script{Statements: []Statement{
+		&CreateProcedureStatement{
+			Name:      "archive",
+			IsReplace: true,
+			Body: &Body{Statements: []Statement{
+				&LoopStatement{Statements: []Statement{
+					&UpdateStatement{
+						Table: &NameExpression{Name: "orders"},
+						SetExprs: []Expr{&BinaryExpression{
+							Left:     &NameExpression{Name: "archived"},
+							Operator: "=",
+							Right:    &NumericLiteral{Value: 1},
+						}},
+						Where: &BinaryExpression{
+							Left:     &NameExpression{Name: "id"},
+							Operator: "=",
+							Right:    &NumericLiteral{Value: 1},
+						},
+					},
+					&ExitStatement{},
+				}},
+				&DeleteStatement{Table: &NameExpression{Name: "orders"}},
+			}},
+		},
+	}}
+}
+
+func TestWalk(t *testing.T) {
+	tests := []struct {
+		name string
+		root AstNode
+		// skip returns true for the nodes whose children are skipped.
+		skip func(AstNode) bool
+		want []string
+	}{
+		{
+			name: "expression",
+			root: &BinaryExpression{
+				Left:     &NameExpression{Name: "a"},
+				Operator: "+",
+				Right:    &NumericLiteral{Value: 1},
+			},
+			want: []string{"*semantic.BinaryExpression", "*semantic.NameExpression", "*semantic.NumericLiteral"},
+		},
+		{
+			name: "fields in order",
+			root: &IfStatement{
+				Condition: &NameExpression{Name: "done"},
+				ThenBlock: []Statement{&NullStatement{}},
+				ElseBlock: []Statement{&CommitStatement{}},
+			},
+			want: []string{"*semantic.IfStatement", "*semantic.NameExpression", "*semantic.NullStatement", "*semantic.CommitStatement"},
+		},
+		{
+			name: "nil fields",
+			root: &DeleteStatement{Table: &NameExpression{Name: "orders"}},
+			want: []string{"*semantic.DeleteStatement", "*semantic.NameExpression"},
+		},
+		{
+			name: "procedure",
+			root: testProcedure(),
+			want: []string{
+				"*semantic.Script",
+				"*semantic.CreateProcedureStatement",
+				"*semantic.Body",
+				"*semantic.LoopStatement",
+				"*semantic.UpdateStatement",
+				"*semantic.NameExpression",
+				"*semantic.BinaryExpression",
+				"*semantic.NameExpression",
+				"*semantic.NumericLiteral",
+				"*semantic.BinaryExpression",
+				"*semantic.NameExpression",
+				"*semantic.NumericLiteral",
+				"*semantic.ExitStatement",
+				"*semantic.DeleteStatement",
+				"*semantic.NameExpression",
+			},
+		},
+		{
+			name: "skip children",
+			root: testProcedure(),
+			skip: func(node AstNode) bool {
+				switch node.(type) {
+				case *UpdateStatement, *DeleteStatement:
+					return true
+				}
+				return false
+			},
+			want: []string{
+				"*semantic.Script",
+				"*semantic.CreateProcedureStatement",
+				"*semantic.Body",
+				"*semantic.LoopStatement",
+				"*semantic.UpdateStatement",
+				"*semantic.ExitStatement",
+				"*semantic.DeleteStatement",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			var depth, post int
+			Inspect(tt.root, func(node AstNode) bool {
+				if node == nil {
+					depth--
+					post++
+					return false
+				}
+				got = append(got, fmt.Sprintf("%T", node))
+				if tt.skip != nil && tt.skip(node) {
+					return false
+				}
+				depth++
+				return true
+			})
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, 0, depth)
+			if tt.skip == nil {
+				assert.Equal(t, len(got), post)
+			}
+		})
+	}
+}
+
+func TestGetChildren(t *testing.T) {
+	update := &UpdateStatement{
+		Table: &NameExpression{Name: "orders"},
+		Where: &NameExpression{Name: "stale"},
+	}
+	children := GetChildren(update)
+	assert.Len(t, children, 2)
+	assert.Same(t, update.Table, children[0])
+	assert.Same(t, update.Where, children[1])
+
+	assert.Empty(t, GetChildren(&NullStatement{}))
+	assert.Empty(t, GetChildren(&UpdateStatement{}))
+}
@@ -159,6 +159,7 @@ func print(w io.Writer, pkg *ast.Package, kind ast.ObjKind, format string) {
 		for name, object := range f.Scope.Objects {
 			if object.Kind == kind && (unexported || ast.IsExported(name)) {
 				if strings.HasPrefix(name, "NodeDecoder") ||
+					strings.HasPrefix(name, "JSONNode") ||
 					strings.HasPrefix(name, "NodeEncoder") ||
 					strings.HasPrefix(name, "Stack") ||
 					strings.HasPrefix(name, "SyntaxNode") ||
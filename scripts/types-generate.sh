@@ -15,7 +15,7 @@ mv $GENERATOR.tmp $GENERATOR
 go run $SCRIPT_DIR/pkgreflect.go -nofuncs -novars -norecurs -noconsts -stdout $TOP_DIR/pkg/semantic  >> $GENERATOR
 
 # exec tools/gen-ast-types.go
-go run $GENERATOR -dir $TOP_DIR/pkg/semantic/internal
+go run $GENERATOR -dir $TOP_DIR/pkg/semantic/internal -schema $TOP_DIR/pkg/semantic/ast.schema.json
 
 # exec go generate
 go generate $TOP_DIR/pkg/semantic/ast.go
@@ -3,6 +3,7 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"go/format"
@@ -14,10 +15,14 @@ import (
 	"procinspect/pkg/semantic"
 )
 
-var dir string
+var (
+	dir    string
+	schema string
+)
 
 func main() {
 	flag.StringVar(&dir, "dir", ".", "Directory of the generated .go file")
+	flag.StringVar(&schema, "schema", "", "Path of the JSON Schema of the JSON form of nodes")
 	flag.Parse()
 
 	tuples := []struct {
@@ -44,6 +49,9 @@ func main() {
 	for _, t := range tuples {
 		generalTypesFile(t.nodeType, t.varName, t.fileName)
 	}
+	if schema != "" {
+		generateSchema(schema)
+	}
 }
 
 func generalTypesFile(nodeType reflect.Type, varName string, fileName string) {
@@ -64,6 +72,13 @@ func generalTypesFile(nodeType reflect.Type, varName string, fileName string) {
 		buf.WriteString(fmt.Sprintf("Name:\"%s\",\n", n))
 		buf.WriteString(fmt.Sprintf("Fields:\"%s.%s\",\n", "semantic", n))
 		buf.WriteString(fmt.Sprintf("Comment:\"\",\n"))
+		if children := childFields(AstTypes[n]); len(children) > 0 {
+			buf.WriteString("Children: []Child{")
+			for _, c := range children {
+				buf.WriteString(fmt.Sprintf("{%q, %s},", c.name, c.kind))
+			}
+			buf.WriteString("},\n")
+		}
 		buf.WriteString(fmt.Sprintf("},"))
 	}
 	buf.WriteString(fmt.Sprintf("}\n"))
@@ -86,12 +101,183 @@ func generalTypesFile(nodeType reflect.Type, varName string, fileName string) {
 	}
 }
 
+var astNodeType = reflect.TypeOf((*semantic.AstNode)(nil)).Elem()
+
+type childField struct {
+	name string
+	kind string
+}
+
+// childFields returns the fields of t that may hold child nodes, in field
+// order. The fields of embedded structs are listed in place, by their
+// promoted names.
+func childFields(t reflect.Type) (fields []childField) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			fields = append(fields, childFields(f.Type)...)
+			continue
+		}
+		if kind := childKind(f.Type); kind != "" {
+			fields = append(fields, childField{name: f.Name, kind: kind})
+		}
+	}
+	return fields
+}
+
+// childKind returns the kind of child a field of type t holds, or "" when
+// it holds none.
+func childKind(t reflect.Type) string {
+	switch {
+	case t.Kind() == reflect.Ptr && t.Implements(astNodeType):
+		return "ChildNode"
+	case t.Kind() == reflect.Interface:
+		return "ChildInterface"
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Ptr && t.Elem().Implements(astNodeType):
+		return "ChildNodes"
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Interface:
+		return "ChildInterfaces"
+	}
+	return ""
+}
+
+// generateSchema writes the JSON Schema of the trees JSONNodeEncoder
+// writes to path.
+func generateSchema(path string) {
+	g := schemaGenerator{defs: map[string]any{}}
+	for _, name := range sortedTypeNames() {
+		if t := AstTypes[name]; isNodeType(t) {
+			g.defs[name] = g.object(t)
+		}
+	}
+	doc := map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title":   "procinspect syntax tree",
+		"$ref":    "#/$defs/AstNode",
+		"$defs":   g.defs,
+	}
+	g.typeSchema(astNodeType)
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	err = os.WriteFile(path, append(data, '\n'), 0644)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func sortedTypeNames() []string {
+	names := make([]string, 0, len(AstTypes))
+	for name := range AstTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func isNodeType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && reflect.PtrTo(t).Implements(astNodeType)
+}
+
+type schemaGenerator struct {
+	defs map[string]any
+}
+
+// object returns the schema of the struct t, whose fields are laid out as
+// in semantic/json.go.
+func (g *schemaGenerator) object(t reflect.Type) map[string]any {
+	props := map[string]any{}
+	required := []string{}
+	if isNodeType(t) {
+		props["kind"] = map[string]any{"const": t.Name()}
+		required = append(required, "kind")
+	}
+	required = g.fields(t, props, required)
+	return map[string]any{
+		"type":       "object",
+		"properties": props,
+		"required":   required,
+	}
+}
+
+func (g *schemaGenerator) fields(t reflect.Type, props map[string]any, required []string) []string {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && !isNodeType(f.Type) {
+			required = g.fields(f.Type, props, required)
+			continue
+		}
+		props[f.Name] = g.typeSchema(f.Type)
+		required = append(required, f.Name)
+	}
+	return required
+}
+
+func (g *schemaGenerator) typeSchema(t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Ptr:
+		return nullable(g.typeSchema(t.Elem()))
+	case reflect.Slice:
+		return map[string]any{"type": []string{"array", "null"}, "items": g.typeSchema(t.Elem())}
+	case reflect.Interface:
+		if t.Name() == "" {
+			return map[string]any{}
+		}
+		if _, ok := g.defs[t.Name()]; !ok {
+			var refs []any
+			for _, name := range sortedTypeNames() {
+				if n := AstTypes[name]; isNodeType(n) && reflect.PtrTo(n).Implements(t) {
+					refs = append(refs, ref(name))
+				}
+			}
+			g.defs[t.Name()] = map[string]any{"anyOf": refs}
+		}
+		return nullable(ref(t.Name()))
+	case reflect.Struct:
+		if _, ok := g.defs[t.Name()]; !ok && !isNodeType(t) {
+			g.defs[t.Name()] = g.object(t)
+		}
+		return ref(t.Name())
+	}
+	panic(fmt.Sprintf("no JSON form for %s", t))
+}
+
+func ref(name string) map[string]any {
+	return map[string]any{"$ref": "#/$defs/" + name}
+}
+
+func nullable(schema map[string]any) map[string]any {
+	return map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}
+}
+
 // 下面的代码是自动生成的
 // Code generated by scripts/pkgreflect.go DO NOT EDIT.
 var AstTypes = map[string]reflect.Type{
 	"AliasExpression":                   reflect.TypeOf((*semantic.AliasExpression)(nil)).Elem(),
+	"AlterSessionStatement":             reflect.TypeOf((*semantic.AlterSessionStatement)(nil)).Elem(),
+	"ApplyFunc":                         reflect.TypeOf((*semantic.ApplyFunc)(nil)).Elem(),
 	"Argument":                          reflect.TypeOf((*semantic.Argument)(nil)).Elem(),
 	"AssignmentStatement":               reflect.TypeOf((*semantic.AssignmentStatement)(nil)).Elem(),
+	"AstFile":                           reflect.TypeOf((*semantic.AstFile)(nil)).Elem(),
+	"AstFileEntry":                      reflect.TypeOf((*semantic.AstFileEntry)(nil)).Elem(),
+	"AstFileHeader":                     reflect.TypeOf((*semantic.AstFileHeader)(nil)).Elem(),
 	"AstNode":                           reflect.TypeOf((*semantic.AstNode)(nil)).Elem(),
 	"AutonomousTransactionDeclaration":  reflect.TypeOf((*semantic.AutonomousTransactionDeclaration)(nil)).Elem(),
 	"BetweenExpression":                 reflect.TypeOf((*semantic.BetweenExpression)(nil)).Elem(),
@@ -106,10 +292,15 @@ var AstTypes = map[string]reflect.Type{
 	"CommitStatement":                   reflect.TypeOf((*semantic.CommitStatement)(nil)).Elem(),
 	"CommonTableExpression":             reflect.TypeOf((*semantic.CommonTableExpression)(nil)).Elem(),
 	"CompoundTriggerBlock":              reflect.TypeOf((*semantic.CompoundTriggerBlock)(nil)).Elem(),
+	"ConditionalBlock":                  reflect.TypeOf((*semantic.ConditionalBlock)(nil)).Elem(),
+	"ConditionalBranch":                 reflect.TypeOf((*semantic.ConditionalBranch)(nil)).Elem(),
+	"ConditionalInsertClause":           reflect.TypeOf((*semantic.ConditionalInsertClause)(nil)).Elem(),
 	"ContinueStatement":                 reflect.TypeOf((*semantic.ContinueStatement)(nil)).Elem(),
+	"CorrelationReference":              reflect.TypeOf((*semantic.CorrelationReference)(nil)).Elem(),
 	"CreateCompoundDmlTriggerStatement": reflect.TypeOf((*semantic.CreateCompoundDmlTriggerStatement)(nil)).Elem(),
 	"CreateFunctionStatement":           reflect.TypeOf((*semantic.CreateFunctionStatement)(nil)).Elem(),
 	"CreateNestTableStatement":          reflect.TypeOf((*semantic.CreateNestTableStatement)(nil)).Elem(),
+	"CreateNonDmlTriggerStatement":      reflect.TypeOf((*semantic.CreateNonDmlTriggerStatement)(nil)).Elem(),
 	"CreatePackageBodyStatement":        reflect.TypeOf((*semantic.CreatePackageBodyStatement)(nil)).Elem(),
 	"CreatePackageStatement":            reflect.TypeOf((*semantic.CreatePackageStatement)(nil)).Elem(),
 	"CreateProcedureStatement":          reflect.TypeOf((*semantic.CreateProcedureStatement)(nil)).Elem(),
@@ -117,16 +308,21 @@ var AstTypes = map[string]reflect.Type{
 	"CreateSynonymStatement":            reflect.TypeOf((*semantic.CreateSynonymStatement)(nil)).Elem(),
 	"CreateTriggerStatement":            reflect.TypeOf((*semantic.CreateTriggerStatement)(nil)).Elem(),
 	"CreateTypeStatement":               reflect.TypeOf((*semantic.CreateTypeStatement)(nil)).Elem(),
+	"Cursor":                            reflect.TypeOf((*semantic.Cursor)(nil)).Elem(),
 	"CursorAttribute":                   reflect.TypeOf((*semantic.CursorAttribute)(nil)).Elem(),
 	"CursorDeclaration":                 reflect.TypeOf((*semantic.CursorDeclaration)(nil)).Elem(),
 	"Declaration":                       reflect.TypeOf((*semantic.Declaration)(nil)).Elem(),
 	"DeleteStatement":                   reflect.TypeOf((*semantic.DeleteStatement)(nil)).Elem(),
+	"Diagnostic":                        reflect.TypeOf((*semantic.Diagnostic)(nil)).Elem(),
 	"DotExpression":                     reflect.TypeOf((*semantic.DotExpression)(nil)).Elem(),
 	"DropFunctionStatement":             reflect.TypeOf((*semantic.DropFunctionStatement)(nil)).Elem(),
 	"DropPackageStatement":              reflect.TypeOf((*semantic.DropPackageStatement)(nil)).Elem(),
 	"DropProcedureStatement":            reflect.TypeOf((*semantic.DropProcedureStatement)(nil)).Elem(),
 	"DropTriggerStatement":              reflect.TypeOf((*semantic.DropTriggerStatement)(nil)).Elem(),
+	"Edit":                              reflect.TypeOf((*semantic.Edit)(nil)).Elem(),
+	"EditKind":                          reflect.TypeOf((*semantic.EditKind)(nil)).Elem(),
 	"ElseBlock":                         reflect.TypeOf((*semantic.ElseBlock)(nil)).Elem(),
+	"ErrorStatement":                    reflect.TypeOf((*semantic.ErrorStatement)(nil)).Elem(),
 	"ExceptionDeclaration":              reflect.TypeOf((*semantic.ExceptionDeclaration)(nil)).Elem(),
 	"ExecuteImmediateStatement":         reflect.TypeOf((*semantic.ExecuteImmediateStatement)(nil)).Elem(),
 	"ExistsExpression":                  reflect.TypeOf((*semantic.ExistsExpression)(nil)).Elem(),
@@ -139,22 +335,37 @@ var AstTypes = map[string]reflect.Type{
 	"FieldList":                         reflect.TypeOf((*semantic.FieldList)(nil)).Elem(),
 	"ForUpdateClause":                   reflect.TypeOf((*semantic.ForUpdateClause)(nil)).Elem(),
 	"ForUpdateOptionsExpression":        reflect.TypeOf((*semantic.ForUpdateOptionsExpression)(nil)).Elem(),
+	"FormatOptions":                     reflect.TypeOf((*semantic.FormatOptions)(nil)).Elem(),
 	"FromClause":                        reflect.TypeOf((*semantic.FromClause)(nil)).Elem(),
 	"FunctionCallExpression":            reflect.TypeOf((*semantic.FunctionCallExpression)(nil)).Elem(),
 	"FunctionDeclaration":               reflect.TypeOf((*semantic.FunctionDeclaration)(nil)).Elem(),
 	"GotoStatement":                     reflect.TypeOf((*semantic.GotoStatement)(nil)).Elem(),
+	"Identifier":                        reflect.TypeOf((*semantic.Identifier)(nil)).Elem(),
 	"IfStatement":                       reflect.TypeOf((*semantic.IfStatement)(nil)).Elem(),
 	"InExpression":                      reflect.TypeOf((*semantic.InExpression)(nil)).Elem(),
 	"InsertIntoClause":                  reflect.TypeOf((*semantic.InsertIntoClause)(nil)).Elem(),
 	"InsertStatement":                   reflect.TypeOf((*semantic.InsertStatement)(nil)).Elem(),
 	"IntoClause":                        reflect.TypeOf((*semantic.IntoClause)(nil)).Elem(),
+	"JsonArrayExpression":               reflect.TypeOf((*semantic.JsonArrayExpression)(nil)).Elem(),
+	"JsonObjectEntry":                   reflect.TypeOf((*semantic.JsonObjectEntry)(nil)).Elem(),
+	"JsonObjectExpression":              reflect.TypeOf((*semantic.JsonObjectExpression)(nil)).Elem(),
+	"JsonOnClause":                      reflect.TypeOf((*semantic.JsonOnClause)(nil)).Elem(),
+	"JsonTableColumn":                   reflect.TypeOf((*semantic.JsonTableColumn)(nil)).Elem(),
+	"JsonTableExpression":               reflect.TypeOf((*semantic.JsonTableExpression)(nil)).Elem(),
+	"JsonValueExpression":               reflect.TypeOf((*semantic.JsonValueExpression)(nil)).Elem(),
+	"KeywordCase":                       reflect.TypeOf((*semantic.KeywordCase)(nil)).Elem(),
 	"LabelDeclaration":                  reflect.TypeOf((*semantic.LabelDeclaration)(nil)).Elem(),
 	"LikeExpression":                    reflect.TypeOf((*semantic.LikeExpression)(nil)).Elem(),
 	"ListaggExpression":                 reflect.TypeOf((*semantic.ListaggExpression)(nil)).Elem(),
+	"LockTableStatement":                reflect.TypeOf((*semantic.LockTableStatement)(nil)).Elem(),
 	"LoopStatement":                     reflect.TypeOf((*semantic.LoopStatement)(nil)).Elem(),
 	"MergeInsertStatement":              reflect.TypeOf((*semantic.MergeInsertStatement)(nil)).Elem(),
 	"MergeStatement":                    reflect.TypeOf((*semantic.MergeStatement)(nil)).Elem(),
 	"MergeUpdateStatement":              reflect.TypeOf((*semantic.MergeUpdateStatement)(nil)).Elem(),
+	"ModelCellExpression":               reflect.TypeOf((*semantic.ModelCellExpression)(nil)).Elem(),
+	"ModelClause":                       reflect.TypeOf((*semantic.ModelClause)(nil)).Elem(),
+	"ModelDefinition":                   reflect.TypeOf((*semantic.ModelDefinition)(nil)).Elem(),
+	"ModelRule":                         reflect.TypeOf((*semantic.ModelRule)(nil)).Elem(),
 	"NameExpression":                    reflect.TypeOf((*semantic.NameExpression)(nil)).Elem(),
 	"NamedArgumentExpression":           reflect.TypeOf((*semantic.NamedArgumentExpression)(nil)).Elem(),
 	"NestTableTypeDeclaration":          reflect.TypeOf((*semantic.NestTableTypeDeclaration)(nil)).Elem(),
@@ -170,20 +381,36 @@ var AstTypes = map[string]reflect.Type{
 	"OrderByElement":                    reflect.TypeOf((*semantic.OrderByElement)(nil)).Elem(),
 	"OuterJoinExpression":               reflect.TypeOf((*semantic.OuterJoinExpression)(nil)).Elem(),
 	"Parameter":                         reflect.TypeOf((*semantic.Parameter)(nil)).Elem(),
+	"Path":                              reflect.TypeOf((*semantic.Path)(nil)).Elem(),
+	"PivotClause":                       reflect.TypeOf((*semantic.PivotClause)(nil)).Elem(),
+	"PivotElement":                      reflect.TypeOf((*semantic.PivotElement)(nil)).Elem(),
+	"PivotInElement":                    reflect.TypeOf((*semantic.PivotInElement)(nil)).Elem(),
+	"Position":                          reflect.TypeOf((*semantic.Position)(nil)).Elem(),
 	"ProcedureCall":                     reflect.TypeOf((*semantic.ProcedureCall)(nil)).Elem(),
+	"PseudoColumn":                      reflect.TypeOf((*semantic.PseudoColumn)(nil)).Elem(),
+	"QualifiedName":                     reflect.TypeOf((*semantic.QualifiedName)(nil)).Elem(),
 	"QueryExpression":                   reflect.TypeOf((*semantic.QueryExpression)(nil)).Elem(),
 	"RaiseStatement":                    reflect.TypeOf((*semantic.RaiseStatement)(nil)).Elem(),
+	"Range":                             reflect.TypeOf((*semantic.Range)(nil)).Elem(),
 	"RelationalExpression":              reflect.TypeOf((*semantic.RelationalExpression)(nil)).Elem(),
 	"ReturnStatement":                   reflect.TypeOf((*semantic.ReturnStatement)(nil)).Elem(),
 	"RollbackStatement":                 reflect.TypeOf((*semantic.RollbackStatement)(nil)).Elem(),
+	"SavepointStatement":                reflect.TypeOf((*semantic.SavepointStatement)(nil)).Elem(),
 	"Script":                            reflect.TypeOf((*semantic.Script)(nil)).Elem(),
 	"SelectField":                       reflect.TypeOf((*semantic.SelectField)(nil)).Elem(),
 	"SelectStatement":                   reflect.TypeOf((*semantic.SelectStatement)(nil)).Elem(),
+	"Selector":                          reflect.TypeOf((*semantic.Selector)(nil)).Elem(),
+	"SequenceReference":                 reflect.TypeOf((*semantic.SequenceReference)(nil)).Elem(),
+	"SessionParameter":                  reflect.TypeOf((*semantic.SessionParameter)(nil)).Elem(),
 	"SetOperationStatement":             reflect.TypeOf((*semantic.SetOperationStatement)(nil)).Elem(),
 	"SetOperator":                       reflect.TypeOf((*semantic.SetOperator)(nil)).Elem(),
 	"SetPosition":                       reflect.TypeOf((*semantic.SetPosition)(nil)).Elem(),
+	"SetTransactionStatement":           reflect.TypeOf((*semantic.SetTransactionStatement)(nil)).Elem(),
+	"Severity":                          reflect.TypeOf((*semantic.Severity)(nil)).Elem(),
 	"SignExpression":                    reflect.TypeOf((*semantic.SignExpression)(nil)).Elem(),
 	"Span":                              reflect.TypeOf((*semantic.Span)(nil)).Elem(),
+	"SqlCursorAttribute":                reflect.TypeOf((*semantic.SqlCursorAttribute)(nil)).Elem(),
+	"SqlPlusCommand":                    reflect.TypeOf((*semantic.SqlPlusCommand)(nil)).Elem(),
 	"Statement":                         reflect.TypeOf((*semantic.Statement)(nil)).Elem(),
 	"StatementDepth":                    reflect.TypeOf((*semantic.StatementDepth)(nil)).Elem(),
 	"StatementExpression":               reflect.TypeOf((*semantic.StatementExpression)(nil)).Elem(),
@@ -197,11 +424,20 @@ var AstTypes = map[string]reflect.Type{
 	"TimingPoint":                       reflect.TypeOf((*semantic.TimingPoint)(nil)).Elem(),
 	"TriggerBlock":                      reflect.TypeOf((*semantic.TriggerBlock)(nil)).Elem(),
 	"TriggerBody":                       reflect.TypeOf((*semantic.TriggerBody)(nil)).Elem(),
+	"TriggerReferencing":                reflect.TypeOf((*semantic.TriggerReferencing)(nil)).Elem(),
 	"UnaryLogicalExpression":            reflect.TypeOf((*semantic.UnaryLogicalExpression)(nil)).Elem(),
+	"UnpivotClause":                     reflect.TypeOf((*semantic.UnpivotClause)(nil)).Elem(),
+	"UnpivotInElement":                  reflect.TypeOf((*semantic.UnpivotInElement)(nil)).Elem(),
 	"UpdateStatement":                   reflect.TypeOf((*semantic.UpdateStatement)(nil)).Elem(),
 	"UsingClause":                       reflect.TypeOf((*semantic.UsingClause)(nil)).Elem(),
 	"UsingElement":                      reflect.TypeOf((*semantic.UsingElement)(nil)).Elem(),
 	"VariableDeclaration":               reflect.TypeOf((*semantic.VariableDeclaration)(nil)).Elem(),
+	"Version":                           reflect.TypeOf((*semantic.Version)(nil)).Elem(),
+	"Visitor":                           reflect.TypeOf((*semantic.Visitor)(nil)).Elem(),
 	"WildCardField":                     reflect.TypeOf((*semantic.WildCardField)(nil)).Elem(),
 	"WithClause":                        reflect.TypeOf((*semantic.WithClause)(nil)).Elem(),
+	"XmlAggExpression":                  reflect.TypeOf((*semantic.XmlAggExpression)(nil)).Elem(),
+	"XmlElementExpression":              reflect.TypeOf((*semantic.XmlElementExpression)(nil)).Elem(),
+	"XmlTableColumn":                    reflect.TypeOf((*semantic.XmlTableColumn)(nil)).Elem(),
+	"XmlTableExpression":                reflect.TypeOf((*semantic.XmlTableExpression)(nil)).Elem(),
 }